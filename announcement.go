@@ -3,8 +3,10 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // AnnouncementService は決算発表予定データを取得するサービスです。
@@ -25,13 +27,13 @@ func NewAnnouncementService(c client.HTTPClient) *AnnouncementService {
 // J-Quants API /equities/earnings-calendar エンドポイントのレスポンスデータ。
 // 注意: このAPIは翌営業日の決算発表予定のみを返します。
 type Announcement struct {
-	Date     string `json:"Date"`     // 日付（YYYY-MM-DD形式、決算発表予定日が未定の場合は空文字）
-	Code     string `json:"Code"`     // 銘柄コード
-	CoName   string `json:"CoName"`   // 会社名
-	FY       string `json:"FY"`       // 決算期末（例: "9月30日"）
-	SectorNm string `json:"SectorNm"` // 業種名
-	FQ       string `json:"FQ"`       // 決算種別（例: "第１四半期"、"第２四半期"、"第３四半期"、"通期"）
-	Section  string `json:"Section"`  // 市場区分（例: "マザーズ"）
+	Date     types.Date `json:"Date"`     // 日付（決算発表予定日が未定の場合はゼロ値）
+	Code     string     `json:"Code"`     // 銘柄コード
+	CoName   string     `json:"CoName"`   // 会社名
+	FY       string     `json:"FY"`       // 決算期末（例: "9月30日"）
+	SectorNm string     `json:"SectorNm"` // 業種名
+	FQ       string     `json:"FQ"`       // 決算種別（例: "第１四半期"、"第２四半期"、"第３四半期"、"通期"）
+	Section  string     `json:"Section"`  // 市場区分（例: "マザーズ"）
 }
 
 // AnnouncementResponse は決算発表予定のレスポンスです。
@@ -53,9 +55,9 @@ type AnnouncementParams struct {
 func (s *AnnouncementService) GetAnnouncement(ctx context.Context, params AnnouncementParams) (*AnnouncementResponse, error) {
 	path := "/equities/earnings-calendar"
 
-	if params.PaginationKey != "" {
-		path += fmt.Sprintf("?pagination_key=%s", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("pagination_key", params.PaginationKey)
+	path += query.encode()
 
 	var resp AnnouncementResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -65,32 +67,22 @@ func (s *AnnouncementService) GetAnnouncement(ctx context.Context, params Announ
 	return &resp, nil
 }
 
-// GetAllAnnouncements は翌営業日の全決算発表予定を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *AnnouncementService) GetAllAnnouncements(ctx context.Context) ([]Announcement, error) {
-	var allAnnouncements []Announcement
-	paginationKey := ""
-
-	for {
-		params := AnnouncementParams{
-			PaginationKey: paginationKey,
-		}
-
+// AllAnnouncements は指定条件の決算発表予定を1件ずつ返すイテレータです。
+func (s *AnnouncementService) AllAnnouncements(ctx context.Context, params AnnouncementParams) iter.Seq2[Announcement, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Announcement, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetAnnouncement(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allAnnouncements = append(allAnnouncements, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allAnnouncements, nil
+// GetAllAnnouncements は翌営業日の全決算発表予定を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *AnnouncementService) GetAllAnnouncements(ctx context.Context) ([]Announcement, error) {
+	return collectAll(s.AllAnnouncements(ctx, AnnouncementParams{}))
 }
 
 // GetAnnouncementByCode は指定銘柄の決算発表予定を取得します。
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestAnnouncementService_GetAnnouncement(t *testing.T) {
@@ -38,7 +39,7 @@ func TestAnnouncementService_GetAnnouncement(t *testing.T) {
 			mockResponse := AnnouncementResponse{
 				Data: []Announcement{
 					{
-						Date:     "2024-02-14",
+						Date:     types.MustParseDate("2024-02-14"),
 						Code:     "43760",
 						CoName:   "くふうカンパニー",
 						FY:       "9月30日",
@@ -47,7 +48,7 @@ func TestAnnouncementService_GetAnnouncement(t *testing.T) {
 						Section:  "マザーズ",
 					},
 					{
-						Date:     "2024-02-14",
+						Date:     types.MustParseDate("2024-02-14"),
 						Code:     "7203",
 						CoName:   "トヨタ自動車",
 						FY:       "3月31日",
@@ -95,7 +96,7 @@ func TestAnnouncementService_GetAllAnnouncements(t *testing.T) {
 	mockResponse1 := AnnouncementResponse{
 		Data: []Announcement{
 			{
-				Date:     "2024-02-14",
+				Date:     types.MustParseDate("2024-02-14"),
 				Code:     "7203",
 				CoName:   "トヨタ自動車",
 				FY:       "3月31日",
@@ -104,7 +105,7 @@ func TestAnnouncementService_GetAllAnnouncements(t *testing.T) {
 				Section:  "プライム",
 			},
 			{
-				Date:     "2024-02-14",
+				Date:     types.MustParseDate("2024-02-14"),
 				Code:     "9984",
 				CoName:   "ソフトバンクグループ",
 				FY:       "3月31日",
@@ -120,7 +121,7 @@ func TestAnnouncementService_GetAllAnnouncements(t *testing.T) {
 	mockResponse2 := AnnouncementResponse{
 		Data: []Announcement{
 			{
-				Date:     "2024-02-14",
+				Date:     types.MustParseDate("2024-02-14"),
 				Code:     "43760",
 				CoName:   "くふうカンパニー",
 				FY:       "9月30日",
@@ -166,7 +167,7 @@ func TestAnnouncementService_GetAnnouncementByCode(t *testing.T) {
 	mockResponse := AnnouncementResponse{
 		Data: []Announcement{
 			{
-				Date:     "2024-02-14",
+				Date:     types.MustParseDate("2024-02-14"),
 				Code:     "7203",
 				CoName:   "トヨタ自動車",
 				FY:       "3月31日",
@@ -175,7 +176,7 @@ func TestAnnouncementService_GetAnnouncementByCode(t *testing.T) {
 				Section:  "プライム",
 			},
 			{
-				Date:     "2024-02-14",
+				Date:     types.MustParseDate("2024-02-14"),
 				Code:     "9984",
 				CoName:   "ソフトバンクグループ",
 				FY:       "3月31日",
@@ -0,0 +1,199 @@
+// Package bars は日次株価・株価分足を週足・月足などの長い期間や、5分足・15分足・60分足などの足に集計します。
+//
+// 日足以上の足は期間（Period）ごとに、始値は最初の日の始値、高値・安値は期間中の最高値・最安値、
+// 終値は最後の日の終値、出来高・売買代金は合計とします。売買が成立しなかった日は四本値の集計に含めません。
+// 期間の最初と最後の営業日は取引カレンダー（Calendar）で求めるため、祝日や年末年始を挟む期間でも正しく区切られます。
+//
+// 日中足は東証の前場・後場ごとに区切り、昼休みをまたぐ足は作りません。
+//
+//	quotes, _ := jq.Quotes.GetDailyQuotesByCode(ctx, "7203")
+//	days, _ := jq.TradingCalendar.GetTradingCalendarByDateRange(ctx, types.NewDate(2024, 1, 1), types.NewDate(2024, 12, 31))
+//	weekly, err := bars.Resample(bars.FromDailyQuotes(quotes, bars.Adjusted), bars.Weekly, bars.NewCalendar(days))
+package bars
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
+)
+
+// Bar は一定の期間・時間の四本値と出来高・売買代金です。
+type Bar struct {
+	Code    string                 // 銘柄コード
+	Start   types.Date             // 期間の最初の営業日（日中足は日付）
+	End     types.Date             // 期間の最後の営業日（日中足は日付）
+	Time    string                 // 日中足の開始時刻（HH:mm形式）。日足以上の足は空文字
+	Session jquants.TradingSession // 日中足の立会時間の区分。日足以上の足は0
+
+	// 四本値
+	O float64 // 始値
+	H float64 // 高値
+	L float64 // 安値
+	C float64 // 終値
+
+	Vo    float64 // 出来高
+	Va    float64 // 売買代金
+	Count int     // 集計した日足（日中足は分足）の本数
+
+	// Complete は足の期間が入力のデータの範囲で終わっているかどうかです。
+	// 入力の最後のデータが期間の途中の場合（集計中の今週・今月など）はfalseです。
+	Complete bool
+}
+
+func (b *Bar) add(o, h, l, c, vo, va float64) {
+	if b.Count == 0 {
+		b.O, b.H, b.L = o, h, l
+	}
+	b.H = max(b.H, h)
+	b.L = min(b.L, l)
+	b.C = c
+	b.Vo += vo
+	b.Va += va
+	b.Count++
+}
+
+// PriceField は日次株価のうち集計に使用する値の種類です。
+type PriceField int
+
+const (
+	Unadjusted PriceField = iota + 1 // 調整前の値（O, H, L, C, Vo）
+	Adjusted                         // 調整済みの値（AdjO, AdjH, AdjL, AdjC, AdjVo）
+)
+
+// Daily は集計の入力となる日足です。売買が成立しなかった日の四本値はnilです。
+type Daily struct {
+	Date types.Date // 日付
+	Code string     // 銘柄コード
+
+	O  *float64 // 始値
+	H  *float64 // 高値
+	L  *float64 // 安値
+	C  *float64 // 終値
+	Vo *float64 // 出来高
+	Va *float64 // 売買代金
+}
+
+// FromDailyQuotes は日次株価をfieldで指定した値の日足に変換します。売買代金は調整しないため常にVaを使用します。
+func FromDailyQuotes(quotes []jquants.DailyQuote, field PriceField) []Daily {
+	days := make([]Daily, len(quotes))
+	for i, q := range quotes {
+		days[i] = Daily{Date: q.Date, Code: q.Code, O: q.O, H: q.H, L: q.L, C: q.C, Vo: q.Vo, Va: q.Va}
+		if field == Adjusted {
+			days[i].O, days[i].H, days[i].L, days[i].C, days[i].Vo = q.AdjO, q.AdjH, q.AdjL, q.AdjC, q.AdjVo
+		}
+	}
+	return days
+}
+
+// FromAdjustedSeries はjquants.AdjustDailyQuotesで任意の基準日に調整した株価を日足に変換します。
+func FromAdjustedSeries(s *jquants.AdjustedSeries) []Daily {
+	days := make([]Daily, len(s.Quotes))
+	for i, q := range s.Quotes {
+		days[i] = Daily{Date: q.Date, Code: q.Code, O: q.O, H: q.H, L: q.L, C: q.C, Vo: q.Vo, Va: q.Va}
+	}
+	return days
+}
+
+// Period は日付dを含む期間の初日と最終日（暦日）を返す関数です。
+// Weekly・Monthly・Quarterly・Yearly のほか、決算期に合わせた年度などの独自の期間を定義できます。
+type Period func(d types.Date) (start, end types.Date)
+
+// Weekly は月曜日から日曜日までの週です。
+func Weekly(d types.Date) (start, end types.Date) {
+	start = d.AddDays(-((int(d.Weekday()) + 6) % 7))
+	return start, start.AddDays(6)
+}
+
+// Monthly は暦月です。
+func Monthly(d types.Date) (start, end types.Date) {
+	return types.NewDate(d.Year, d.Month, 1), types.NewDate(d.Year, d.Month+1, 0)
+}
+
+// Quarterly は暦四半期（1〜3月、4〜6月、7〜9月、10〜12月）です。
+func Quarterly(d types.Date) (start, end types.Date) {
+	m := d.Month - (d.Month-1)%3
+	return types.NewDate(d.Year, m, 1), types.NewDate(d.Year, m+3, 0)
+}
+
+// Yearly は暦年です。
+func Yearly(d types.Date) (start, end types.Date) {
+	return types.NewDate(d.Year, time.January, 1), types.NewDate(d.Year, time.December, 31)
+}
+
+// Resample は日足をperiodの期間ごとの足に集計し、銘柄コード・期間の順に並べて返します。入力の順序は問いません。
+// 期間の最初と最後の営業日はcalで求めます（calがnilの場合は土日以外を営業日とみなします）。
+// 売買が成立した日がない期間の足は返しません。
+func Resample(days []Daily, period Period, cal *Calendar) ([]Bar, error) {
+	if period == nil {
+		return nil, fmt.Errorf("period parameter is required")
+	}
+
+	type day struct {
+		*Daily
+		date types.Date
+	}
+	byCode := map[string][]day{}
+	for i := range days {
+		if days[i].Date.IsZero() {
+			return nil, fmt.Errorf("date is not available for %s", days[i].Code)
+		}
+		byCode[days[i].Code] = append(byCode[days[i].Code], day{&days[i], days[i].Date})
+	}
+
+	var bars []Bar
+	for _, code := range slices.Sorted(maps.Keys(byCode)) {
+		ds := byCode[code]
+		slices.SortStableFunc(ds, func(a, b day) int { return a.date.Compare(b.date) })
+		last := ds[len(ds)-1].date
+
+		var (
+			bar               *Bar
+			periodEnd, endDay types.Date
+		)
+		flush := func() {
+			if bar != nil && bar.Count > 0 {
+				bar.End = endDay
+				bar.Complete = !last.Before(endDay)
+				bars = append(bars, *bar)
+			}
+		}
+		for _, d := range ds {
+			if bar == nil || d.date.After(periodEnd) {
+				flush()
+				var start types.Date
+				start, periodEnd = period(d.date)
+				first, lastDay, ok := cal.tradingDays(start, periodEnd)
+				if !ok {
+					first, lastDay = d.date, d.date
+				}
+				if d.date.Before(first) {
+					first = d.date
+				}
+				bar = &Bar{Code: code, Start: first}
+				endDay = lastDay
+			}
+			// カレンダーで休業日の日にデータがある場合は、その日まで期間に含める
+			if d.date.After(endDay) {
+				endDay = d.date
+			}
+			if d.C == nil {
+				continue
+			}
+			c := *d.C
+			bar.add(or(d.O, c), or(d.H, c), or(d.L, c), c, or(d.Vo, 0), or(d.Va, 0))
+		}
+		flush()
+	}
+	return bars, nil
+}
+
+func or(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
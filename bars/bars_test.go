@@ -0,0 +1,183 @@
+package bars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testCalendar() *Calendar {
+	return NewCalendar([]jquants.TradingCalendar{
+		{Date: types.MustParseDate("2024-04-29"), HolDiv: jquants.HolidayDivisionNonTradingDay},
+		{Date: types.MustParseDate("2024-04-30"), HolDiv: jquants.HolidayDivisionTradingDay},
+		{Date: types.MustParseDate("2024-05-03"), HolDiv: jquants.HolidayDivisionNonTradingDay},
+		{Date: types.MustParseDate("2024-05-06"), HolDiv: jquants.HolidayDivisionNonTradingDay},
+		{Date: types.MustParseDate("2024-12-30"), HolDiv: jquants.HolidayDivisionTSEHalfDay},
+	})
+}
+
+func testQuotes() []jquants.DailyQuote {
+	return []jquants.DailyQuote{
+		{Date: types.MustParseDate("2024-05-02"), Code: "86970", O: ptr(121.0), H: ptr(122.0), L: ptr(90.0), C: ptr(95.0), Vo: ptr(30.0), Va: ptr(3000.0),
+			AdjO: ptr(121.0), AdjH: ptr(122.0), AdjL: ptr(90.0), AdjC: ptr(95.0), AdjVo: ptr(30.0)},
+		{Date: types.MustParseDate("2024-04-25"), Code: "86970", O: ptr(200.0), H: ptr(220.0), L: ptr(190.0), C: ptr(210.0), Vo: ptr(5.0), Va: ptr(1000.0),
+			AdjO: ptr(100.0), AdjH: ptr(110.0), AdjL: ptr(95.0), AdjC: ptr(105.0), AdjVo: ptr(10.0)},
+		{Date: types.MustParseDate("2024-04-26"), Code: "86970", O: ptr(105.0), H: ptr(120.0), L: ptr(100.0), C: ptr(118.0), Vo: ptr(20.0), Va: ptr(2000.0),
+			AdjO: ptr(105.0), AdjH: ptr(120.0), AdjL: ptr(100.0), AdjC: ptr(118.0), AdjVo: ptr(20.0)},
+		{Date: types.MustParseDate("2024-04-30"), Code: "86970", O: ptr(118.0), H: ptr(125.0), L: ptr(115.0), C: ptr(120.0), Vo: ptr(5.0), Va: ptr(600.0),
+			AdjO: ptr(118.0), AdjH: ptr(125.0), AdjL: ptr(115.0), AdjC: ptr(120.0), AdjVo: ptr(5.0)},
+		{Date: types.MustParseDate("2024-05-01"), Code: "86970"}, // 売買不成立
+	}
+}
+
+func checkBar(t *testing.T, name string, got Bar, want Bar) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s = %+v, want %+v", name, got, want)
+	}
+}
+
+func TestResample(t *testing.T) {
+	weekly, err := Resample(FromDailyQuotes(testQuotes(), Adjusted), Weekly, testCalendar())
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	if len(weekly) != 2 {
+		t.Fatalf("len(weekly) = %d, want 2", len(weekly))
+	}
+	checkBar(t, "weekly[0]", weekly[0], Bar{Code: "86970", Start: types.MustParseDate("2024-04-22"), End: types.MustParseDate("2024-04-26"),
+		O: 100, H: 120, L: 95, C: 118, Vo: 30, Va: 3000, Count: 2, Complete: true})
+	// 月曜日の祝日を除き、火曜日から始まる週
+	checkBar(t, "weekly[1]", weekly[1], Bar{Code: "86970", Start: types.MustParseDate("2024-04-30"), End: types.MustParseDate("2024-05-02"),
+		O: 118, H: 125, L: 90, C: 95, Vo: 35, Va: 3600, Count: 2, Complete: true})
+
+	monthly, err := Resample(FromDailyQuotes(testQuotes(), Unadjusted), Monthly, testCalendar())
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	checkBar(t, "monthly[0]", monthly[0], Bar{Code: "86970", Start: types.MustParseDate("2024-04-01"), End: types.MustParseDate("2024-04-30"),
+		O: 200, H: 220, L: 100, C: 120, Vo: 30, Va: 3600, Count: 3, Complete: true})
+	// 月の途中までのデータは未確定
+	if m := monthly[1]; m.End.String() != "2024-05-31" || m.Complete || m.C != 95 {
+		t.Errorf("monthly[1] = %+v", m)
+	}
+
+	if _, err := Resample([]Daily{{Code: "86970"}}, Weekly, nil); err == nil {
+		t.Error("missing date: expected error")
+	}
+	if _, err := Resample(nil, nil, nil); err == nil {
+		t.Error("nil period: expected error")
+	}
+}
+
+func TestResample_AdjustedSeries(t *testing.T) {
+	quotes := testQuotes()
+	quotes[0].AdjFactor = 0.5 // 2024-05-02に1:2の株式分割
+	series, err := jquants.AdjustDailyQuotes(quotes)
+	if err != nil {
+		t.Fatalf("AdjustDailyQuotes() error = %v", err)
+	}
+	weekly, err := Resample(FromAdjustedSeries(series), Weekly, testCalendar())
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	if w := weekly[1]; w.O != 59 || w.H != 122 || w.Vo != 40 || w.Va != 3600 {
+		t.Errorf("weekly[1] = %+v", w)
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	tests := []struct {
+		name       string
+		period     Period
+		start, end string
+	}{
+		{"Weekly", Weekly, "2024-04-29", "2024-05-05"},
+		{"Monthly", Monthly, "2024-05-01", "2024-05-31"},
+		{"Quarterly", Quarterly, "2024-04-01", "2024-06-30"},
+		{"Yearly", Yearly, "2024-01-01", "2024-12-31"},
+	}
+	for _, tt := range tests {
+		start, end := tt.period(types.MustParseDate("2024-05-05"))
+		if start.String() != tt.start || end.String() != tt.end {
+			t.Errorf("%s() = %s, %s, want %s, %s", tt.name, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func minute(date, tm string, o, h, l, c, vo float64) jquants.MinuteQuote {
+	return jquants.MinuteQuote{Date: types.MustParseDate(date), Time: tm, Code: "86970", O: o, H: h, L: l, C: c, Vo: vo, Va: c * vo}
+}
+
+func TestIntraday(t *testing.T) {
+	quotes := []jquants.MinuteQuote{
+		minute("2024-04-30", "15:30", 110, 110, 110, 110, 50), // 大引けの板寄せ
+		minute("2024-04-30", "09:00", 100, 101, 99, 100, 10),
+		minute("2024-04-30", "09:59", 100, 103, 100, 102, 5),
+		minute("2024-04-30", "10:00", 102, 102, 101, 101, 1),
+		minute("2024-04-30", "11:30", 105, 105, 105, 105, 20), // 前引けの板寄せ
+		minute("2024-04-30", "12:30", 106, 107, 106, 107, 3),
+		minute("2024-04-30", "14:59", 108, 109, 108, 109, 2),
+	}
+	bars, err := Intraday(quotes, time.Hour, testCalendar())
+	if err != nil {
+		t.Fatalf("Intraday() error = %v", err)
+	}
+	want := []struct {
+		time    string
+		session jquants.TradingSession
+		o, c    float64
+		count   int
+	}{
+		{"09:00", jquants.SessionMorning, 100, 102, 2},
+		{"10:00", jquants.SessionMorning, 102, 101, 1},
+		{"11:00", jquants.SessionMorning, 105, 105, 1},
+		{"12:30", jquants.SessionAfternoon, 106, 107, 1},
+		{"14:30", jquants.SessionAfternoon, 108, 110, 2},
+	}
+	if len(bars) != len(want) {
+		t.Fatalf("len(bars) = %d, want %d: %+v", len(bars), len(want), bars)
+	}
+	for i, w := range want {
+		b := bars[i]
+		if b.Time != w.time || b.Session != w.session || b.O != w.o || b.C != w.c || b.Count != w.count || !b.Complete {
+			t.Errorf("bars[%d] = %+v, want %+v", i, b, w)
+		}
+	}
+	if b := bars[0]; b.H != 103 || b.L != 99 || b.Vo != 15 || b.Va != 1510 || b.Start.String() != "2024-04-30" {
+		t.Errorf("bars[0] = %+v", b)
+	}
+
+	// 足の途中までのデータは未確定
+	bars, err = Intraday(quotes[1:2], 15*time.Minute, nil)
+	if err != nil || len(bars) != 1 || bars[0].Complete {
+		t.Errorf("Intraday() = %+v, %v", bars, err)
+	}
+}
+
+func TestIntraday_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		quote    jquants.MinuteQuote
+		interval time.Duration
+	}{
+		{"interval", minute("2024-04-30", "09:00", 1, 1, 1, 1, 1), 90 * time.Second},
+		{"off hours", minute("2024-04-30", "11:31", 1, 1, 1, 1, 1), time.Minute},
+		{"holiday", minute("2024-04-29", "09:00", 1, 1, 1, 1, 1), time.Minute},
+		{"weekend", minute("2024-05-04", "09:00", 1, 1, 1, 1, 1), time.Minute},
+		{"half day afternoon", minute("2024-12-30", "12:30", 1, 1, 1, 1, 1), time.Minute},
+		{"time", minute("2024-04-30", "9am", 1, 1, 1, 1, 1), time.Minute},
+	}
+	for _, tt := range tests {
+		if _, err := Intraday([]jquants.MinuteQuote{tt.quote}, tt.interval, testCalendar()); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if _, err := Intraday([]jquants.MinuteQuote{minute("2024-12-30", "11:30", 1, 1, 1, 1, 1)}, time.Minute, testCalendar()); err != nil {
+		t.Errorf("half day morning: %v", err)
+	}
+}
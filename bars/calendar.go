@@ -0,0 +1,55 @@
+package bars
+
+import (
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
+)
+
+// Calendar は足の区切りに使用する取引カレンダーです。
+// 東証の営業日（休日区分が営業日・半日立会日の日）を営業日とします。
+// カレンダーに含まれない日付、およびnilのCalendarでは土日以外を営業日とみなします。
+type Calendar struct {
+	holDiv map[types.Date]string
+}
+
+// NewCalendar は取引カレンダー（/markets/calendar）からCalendarを作成します。日付のない行は無視します。
+func NewCalendar(days []jquants.TradingCalendar) *Calendar {
+	c := &Calendar{holDiv: make(map[types.Date]string, len(days))}
+	for _, day := range days {
+		if !day.Date.IsZero() {
+			c.holDiv[day.Date] = day.HolDiv
+		}
+	}
+	return c
+}
+
+// IsTradingDay は東証の営業日（半日立会日を含む）かどうかを返します。
+func (c *Calendar) IsTradingDay(d types.Date) bool {
+	if c != nil {
+		if div, ok := c.holDiv[d]; ok {
+			return div == jquants.HolidayDivisionTradingDay || div == jquants.HolidayDivisionTSEHalfDay
+		}
+	}
+	wd := d.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
+
+// IsHalfDay は東証の半日立会日（前場のみ）かどうかを返します。
+func (c *Calendar) IsHalfDay(d types.Date) bool {
+	return c != nil && c.holDiv[d] == jquants.HolidayDivisionTSEHalfDay
+}
+
+// tradingDays はstartからendまでの最初と最後の営業日を返します。営業日がない場合はokにfalseを返します。
+func (c *Calendar) tradingDays(start, end types.Date) (first, last types.Date, ok bool) {
+	for d := start; !d.After(end); d = d.AddDays(1) {
+		if c.IsTradingDay(d) {
+			if !ok {
+				first, ok = d, true
+			}
+			last = d
+		}
+	}
+	return first, last, ok
+}
@@ -0,0 +1,113 @@
+package bars
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
+)
+
+// session は立会時間（0時からの分）です。終了時刻の分足は引けの板寄せとしてその立会に含めます。
+type session struct {
+	kind        jquants.TradingSession
+	open, close int
+}
+
+// 東証の現物市場の立会時間（前場 9:00〜11:30、後場 12:30〜15:30）
+var sessions = []session{
+	{jquants.SessionMorning, 9 * 60, 11*60 + 30},
+	{jquants.SessionAfternoon, 12*60 + 30, 15*60 + 30},
+}
+
+// sessionOf は0時からの分mが属する立会を返します。
+func sessionOf(m int) (session, bool) {
+	for _, s := range sessions {
+		if m >= s.open && m <= s.close {
+			return s, true
+		}
+	}
+	return session{}, false
+}
+
+// Intraday は株価分足をintervalごとの日中足に集計し、銘柄コード・日付・時刻の順に並べて返します。入力の順序は問いません。
+// intervalは1分の倍数です（例: 5分足は5*time.Minute）。
+//
+// 足は前場（9:00〜11:30）・後場（12:30〜15:30）それぞれの開始時刻を起点に区切り、昼休みをまたぐ足は作りません
+// （60分足の後場は12:30・13:30・14:30）。立会の終了時刻の分足（引けの板寄せ）はその立会の最後の足に含めます。
+// calで営業日でない日の分足、半日立会日の後場の分足、立会時間外の分足はエラーを返します
+// （calがnilの場合は土日以外を営業日とみなします）。
+func Intraday(quotes []jquants.MinuteQuote, interval time.Duration, cal *Calendar) ([]Bar, error) {
+	if interval <= 0 || interval%time.Minute != 0 {
+		return nil, fmt.Errorf("interval must be a positive multiple of a minute: %s", interval)
+	}
+	iv := int(interval / time.Minute)
+
+	type minute struct {
+		*jquants.MinuteQuote
+		m int
+	}
+	ms := make([]minute, len(quotes))
+	for i := range quotes {
+		q := &quotes[i]
+		if q.Date.IsZero() {
+			return nil, fmt.Errorf("date is not available for %s", q.Code)
+		}
+		tod, err := time.Parse("15:04", q.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time for %s %s: %q", q.Code, q.Date, q.Time)
+		}
+		if !cal.IsTradingDay(q.Date) {
+			return nil, fmt.Errorf("minute quote on non-trading day: %s %s", q.Code, q.Date)
+		}
+		ms[i] = minute{q, tod.Hour()*60 + tod.Minute()}
+	}
+	slices.SortStableFunc(ms, func(a, b minute) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), a.Date.Compare(b.Date), cmp.Compare(a.m, b.m))
+	})
+
+	var (
+		bars []Bar
+		bar  *Bar
+		// 集計中の足の日付・開始時刻と、足の最後の分（立会の最後の足は終了時刻）
+		date        types.Date
+		start, last int
+	)
+	for i, q := range ms {
+		s, ok := sessionOf(q.m)
+		if !ok {
+			return nil, fmt.Errorf("minute quote outside trading sessions: %s %s %s", q.Code, q.Date, q.Time)
+		}
+		if s.kind == jquants.SessionAfternoon && cal.IsHalfDay(q.Date) {
+			return nil, fmt.Errorf("afternoon minute quote on half trading day: %s %s %s", q.Code, q.Date, q.Time)
+		}
+
+		// 立会の終了時刻の分足は最後の足に含める
+		offset := min((q.m-s.open)/iv*iv, (s.close-s.open-1)/iv*iv)
+		if bar == nil || bar.Code != q.Code || q.Date != date || s.open+offset != start {
+			if bar != nil {
+				// 同じ銘柄の後続の分足があれば確定している
+				bar.Complete = q.Code == bar.Code || ms[i-1].m >= last
+				bars = append(bars, *bar)
+			}
+			date, start = q.Date, s.open+offset
+			last = start + iv - 1
+			if start+iv >= s.close {
+				last = s.close
+			}
+			bar = &Bar{Code: q.Code, Start: q.Date, End: q.Date, Time: clock(start), Session: s.kind}
+		}
+		bar.add(q.O, q.H, q.L, q.C, q.Vo, q.Va)
+	}
+	if bar != nil {
+		bar.Complete = ms[len(ms)-1].m >= last
+		bars = append(bars, *bar)
+	}
+	return bars, nil
+}
+
+func clock(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
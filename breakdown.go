@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -27,11 +27,11 @@ func NewBreakdownService(c client.HTTPClient) *BreakdownService {
 
 // BreakdownParams は売買内訳データのリクエストパラメータです。
 type BreakdownParams struct {
-	Code          string // 銘柄コード（4桁または5桁）
-	Date          string // 基準日付（YYYYMMDD または YYYY-MM-DD）
-	From          string // 開始日付（YYYYMMDD または YYYY-MM-DD）
-	To            string // 終了日付（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（4桁または5桁）
+	Date          types.Date // 基準日付
+	From          types.Date // 開始日付
+	To            types.Date // 終了日付
+	PaginationKey string     // ページネーションキー
 }
 
 // BreakdownResponse は売買内訳データのレスポンスです。
@@ -46,8 +46,8 @@ type BreakdownResponse struct {
 // 注意: このデータはプレミアムプラン専用APIで取得されます。
 type Breakdown struct {
 	// 基本情報
-	Date string `json:"Date"` // 売買日（YYYY-MM-DD形式）
-	Code string `json:"Code"` // 銘柄コード
+	Date types.Date `json:"Date"` // 売買日
+	Code string     `json:"Code"` // 銘柄コード
 
 	// 売りの約定代金内訳（単位：円）
 	LongSellVa      float64 `json:"LongSellVa"`      // 実売りの約定代金
@@ -74,7 +74,7 @@ type Breakdown struct {
 
 // RawBreakdown is used for unmarshaling JSON response with mixed types
 type RawBreakdown struct {
-	Date            string                `json:"Date"`
+	Date            types.Date            `json:"Date"`
 	Code            string                `json:"Code"`
 	LongSellVa      types.NullableFloat64 `json:"LongSellVa"`
 	ShrtNoMrgnVa    types.NullableFloat64 `json:"ShrtNoMrgnVa"`
@@ -147,26 +147,17 @@ func (r *BreakdownResponse) UnmarshalJSON(data []byte) error {
 func (s *BreakdownService) GetBreakdown(ctx context.Context, params BreakdownParams) (*BreakdownResponse, error) {
 	path := "/markets/breakdown"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp BreakdownResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -176,70 +167,40 @@ func (s *BreakdownService) GetBreakdown(ctx context.Context, params BreakdownPar
 	return &resp, nil
 }
 
+// AllBreakdown は指定条件の売買内訳データを1件ずつ返すイテレータです。
+func (s *BreakdownService) AllBreakdown(ctx context.Context, params BreakdownParams) iter.Seq2[Breakdown, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Breakdown, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetBreakdown(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetBreakdownByCode は指定銘柄の過去N日間の売買内訳データを取得します。
 // ページネーションを使用して全データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *BreakdownService) GetBreakdownByCode(ctx context.Context, code string, days int) ([]Breakdown, error) {
-	to := time.Now()
-	from := to.AddDate(0, 0, -days)
-
-	var allBreakdown []Breakdown
-	paginationKey := ""
-
-	for {
-		params := BreakdownParams{
-			Code:          code,
-			From:          from.Format("20060102"),
-			To:            to.Format("20060102"),
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetBreakdown(ctx, params)
-		if err != nil {
-			return nil, err
-		}
+	to := types.Today()
+	from := to.AddDays(-days)
 
-		allBreakdown = append(allBreakdown, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allBreakdown, nil
+	return collectAll(s.AllBreakdown(ctx, BreakdownParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetBreakdownByDate は指定日の全銘柄の売買内訳データを取得します。
 // ページネーションを使用して大量データを分割取得します。
-func (s *BreakdownService) GetBreakdownByDate(ctx context.Context, date string) ([]Breakdown, error) {
-	var allBreakdown []Breakdown
-	paginationKey := ""
-
-	for {
-		params := BreakdownParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetBreakdown(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allBreakdown = append(allBreakdown, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allBreakdown, nil
+func (s *BreakdownService) GetBreakdownByDate(ctx context.Context, date types.Date) ([]Breakdown, error) {
+	return collectAll(s.AllBreakdown(ctx, BreakdownParams{
+		Date: date,
+	}))
 }
 
 // 売り合計を計算するヘルパーメソッド
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestBreakdownService_GetBreakdown(t *testing.T) {
@@ -19,33 +20,33 @@ func TestBreakdownService_GetBreakdown(t *testing.T) {
 			name: "with all parameters",
 			params: BreakdownParams{
 				Code:          "7203",
-				Date:          "20240101",
-				From:          "20240101",
-				To:            "20240131",
+				Date:          types.MustParseDate("20240101"),
+				From:          types.MustParseDate("20240101"),
+				To:            types.MustParseDate("20240131"),
 				PaginationKey: "key123",
 			},
-			wantPath: "/markets/breakdown?code=7203&date=20240101&from=20240101&to=20240131&pagination_key=key123",
+			wantPath: "/markets/breakdown?code=72030&date=20240101&from=20240101&to=20240131&pagination_key=key123",
 		},
 		{
 			name: "with code and date range",
 			params: BreakdownParams{
 				Code: "7203",
-				From: "20240101",
-				To:   "20240131",
+				From: types.MustParseDate("20240101"),
+				To:   types.MustParseDate("20240131"),
 			},
-			wantPath: "/markets/breakdown?code=7203&from=20240101&to=20240131",
+			wantPath: "/markets/breakdown?code=72030&from=20240101&to=20240131",
 		},
 		{
 			name: "with code only",
 			params: BreakdownParams{
 				Code: "7203",
 			},
-			wantPath: "/markets/breakdown?code=7203",
+			wantPath: "/markets/breakdown?code=72030",
 		},
 		{
 			name: "with date only",
 			params: BreakdownParams{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 			},
 			wantPath: "/markets/breakdown?date=20240101",
 		},
@@ -66,7 +67,7 @@ func TestBreakdownService_GetBreakdown(t *testing.T) {
 			mockResponse := BreakdownResponse{
 				Data: []Breakdown{
 					{
-						Date:            "2024-01-31",
+						Date:            types.MustParseDate("2024-01-31"),
 						Code:            "72030",
 						LongSellVa:      115164000.0,
 						ShrtNoMrgnVa:    93561000.0,
@@ -117,14 +118,14 @@ func TestBreakdownService_GetBreakdownByCode(t *testing.T) {
 	// Calculate expected dates
 	to := time.Now()
 	from := to.AddDate(0, 0, -30)
-	expectedPath := fmt.Sprintf("/markets/breakdown?code=7203&from=%s&to=%s",
+	expectedPath := fmt.Sprintf("/markets/breakdown?code=72030&from=%s&to=%s",
 		from.Format("20060102"), to.Format("20060102"))
 
 	// Mock response
 	mockResponse := BreakdownResponse{
 		Data: []Breakdown{
 			{
-				Date:       "2024-02-01",
+				Date:       types.MustParseDate("2024-02-01"),
 				Code:       "72030",
 				LongSellVa: 100000000.0,
 				LongBuyVa:  120000000.0,
@@ -160,12 +161,12 @@ func TestBreakdownService_GetBreakdownByDate(t *testing.T) {
 	mockResponse1 := BreakdownResponse{
 		Data: []Breakdown{
 			{
-				Date:       "2024-01-01",
+				Date:       types.MustParseDate("2024-01-01"),
 				Code:       "13010",
 				LongSellVa: 100000000.0,
 			},
 			{
-				Date:       "2024-01-01",
+				Date:       types.MustParseDate("2024-01-01"),
 				Code:       "13020",
 				LongSellVa: 200000000.0,
 			},
@@ -177,7 +178,7 @@ func TestBreakdownService_GetBreakdownByDate(t *testing.T) {
 	mockResponse2 := BreakdownResponse{
 		Data: []Breakdown{
 			{
-				Date:       "2024-01-01",
+				Date:       types.MustParseDate("2024-01-01"),
 				Code:       "72030",
 				LongSellVa: 300000000.0,
 			},
@@ -189,7 +190,7 @@ func TestBreakdownService_GetBreakdownByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/breakdown?date=20240101&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	breakdown, err := service.GetBreakdownByDate(context.Background(), "20240101")
+	breakdown, err := service.GetBreakdownByDate(context.Background(), types.MustParseDate("20240101"))
 
 	// Verify
 	if err != nil {
@@ -206,7 +207,7 @@ func TestBreakdownService_GetBreakdown_Error(t *testing.T) {
 	service := NewBreakdownService(mockClient)
 
 	// Set error response
-	mockClient.SetError("GET", "/markets/breakdown?code=7203", fmt.Errorf("unauthorized"))
+	mockClient.SetError("GET", "/markets/breakdown?code=72030", fmt.Errorf("unauthorized"))
 
 	// Execute
 	_, err := service.GetBreakdown(context.Background(), BreakdownParams{Code: "7203"})
@@ -85,6 +85,11 @@ type BulkGetParams struct {
 // GetFiles はダウンロード可能ファイル一覧を取得します。
 // endpointとして取引カレンダー（/markets/calendar）を指定した場合、from/toの期間に関わらず最新の1ファイルのみが返されます。
 func (s *BulkService) GetFiles(ctx context.Context, params BulkListParams) (*BulkListResponse, error) {
+	return s.getFiles(ctx, params, false)
+}
+
+// getFiles はファイル一覧を取得します。noCacheがtrueの場合はキャッシュを経由しません。
+func (s *BulkService) getFiles(ctx context.Context, params BulkListParams, noCache bool) (*BulkListResponse, error) {
 	// endpoint、dateのいずれかが必須
 	if params.Endpoint == "" && params.Date == "" {
 		return nil, fmt.Errorf("either endpoint or date parameter is required")
@@ -96,26 +101,25 @@ func (s *BulkService) GetFiles(ctx context.Context, params BulkListParams) (*Bul
 
 	path := "/bulk/list"
 
-	query := "?"
-	if params.Endpoint != "" {
-		query += fmt.Sprintf("endpoint=%s&", params.Endpoint)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
+	query := newQuery()
+	query.set("endpoint", params.Endpoint)
+	query.setDateOrMonth("date", params.Date)
+	query.setDateOrMonth("from", params.From)
+	query.setDateOrMonth("to", params.To)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp BulkListResponse
-	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
+	var err error
+	if noCache {
+		err = client.DoRequestNoCache(ctx, s.client, "GET", path, nil, &resp)
+	} else {
+		err = s.client.DoRequest(ctx, "GET", path, nil, &resp)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to get bulk file list: %w", err)
 	}
 
@@ -135,20 +139,15 @@ func (s *BulkService) GetDownloadURL(ctx context.Context, params BulkGetParams)
 
 	path := "/bulk/get"
 
-	query := "?"
-	if params.Key != "" {
-		query += fmt.Sprintf("key=%s&", params.Key)
-	}
-	if params.Endpoint != "" {
-		query += fmt.Sprintf("endpoint=%s&", params.Endpoint)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
+	query := newQuery()
+	query.set("key", params.Key)
+	query.set("endpoint", params.Endpoint)
+	query.setDateOrMonth("date", params.Date)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return "", err
 	}
+	path += query.encode()
 
 	var resp struct {
 		URL string `json:"url"`
@@ -0,0 +1,365 @@
+package jquants
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/utahta/jquants/client"
+)
+
+// downloadAttempts は1ファイルのダウンロードで署名付きURLを取得し直す最大回数です。
+const downloadAttempts = 3
+
+// Download はkeyで指定したファイルをダウンロードし、gzipを展開したCSVをwに書き込みます。
+// 署名付きURLの取得からダウンロードまでを行い、途中で接続が切れたりURLが失効した場合は
+// 新しいURLを取得して中断した位置から再開します。
+func (s *BulkService) Download(ctx context.Context, key string, w io.Writer) error {
+	rc, err := s.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("failed to download bulk file %s: %w", key, err)
+	}
+	return nil
+}
+
+// Open はkeyで指定したファイルのダウンロードを開始し、gzipを展開したCSVを読み出すReaderを返します。
+// ファイル全体をメモリに保持しないため、大きなファイルも一定のメモリで処理できます。
+// 行を型付きの構造体として読むにはBulkRowsを使用します。呼び出し元はCloseする必要があります。
+//
+//	rc, err := jq.Bulk.Open(ctx, file.Key)
+//	if err != nil {
+//		return err
+//	}
+//	defer rc.Close()
+//	for q, err := range jquants.BulkRows[jquants.DailyQuote](rc) {
+//		...
+//	}
+func (s *BulkService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key parameter is required")
+	}
+
+	body := s.newBody(ctx, key)
+	if err := body.open(); err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, fmt.Errorf("failed to read bulk file %s: %w", key, err)
+	}
+	return &bulkReader{Reader: zr, body: body}, nil
+}
+
+// bulkReader はgzipを展開しながらダウンロード中のファイルを読み出します。
+type bulkReader struct {
+	*gzip.Reader
+	body *downloadBody
+}
+
+// Close implements io.Closer.
+func (r *bulkReader) Close() error {
+	err := r.Reader.Close()
+	if cerr := r.body.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// newBody はkeyで指定したファイルを圧縮されたまま読み出すdownloadBodyを作成します。
+func (s *BulkService) newBody(ctx context.Context, key string) *downloadBody {
+	return &downloadBody{
+		ctx:    ctx,
+		client: s.client,
+		name:   "bulk file " + key,
+		signURL: func(ctx context.Context) (string, error) {
+			return s.GetDownloadURL(ctx, BulkGetParams{Key: key})
+		},
+	}
+}
+
+// downloadBody は署名付きURLのファイルを読み出すReaderです。
+// 読み出し中のエラーでは署名付きURLを取得し直し、読み終えた位置から再開します。
+type downloadBody struct {
+	ctx     context.Context
+	client  client.HTTPClient
+	name    string                                    // エラーメッセージに使用するファイルの名前
+	signURL func(ctx context.Context) (string, error) // 新しい署名付きURLを取得する
+
+	rc       io.ReadCloser
+	offset   int64 // 読み出し済みのバイト数
+	attempts int
+
+	contentType string // 最初のレスポンスのContent-Type
+	size        int64  // ファイル全体のサイズ（不明な場合は-1）
+}
+
+// open は新しい署名付きURLを取得してoffsetからダウンロードを開始します。
+// 再試行で解決しうるエラーの場合はdownloadAttemptsまで繰り返します。
+func (b *downloadBody) open() error {
+	for {
+		b.attempts++
+		url, err := b.signURL(b.ctx)
+		if err != nil {
+			return err
+		}
+		rc, err := client.FetchURL(b.ctx, b.client, url, b.offset)
+		if err == nil {
+			b.rc = rc
+			if b.offset == 0 {
+				b.contentType, b.size = "", -1
+				if ub, ok := rc.(*client.URLBody); ok {
+					b.contentType, b.size = ub.ContentType, ub.ContentLength
+				}
+			}
+			return nil
+		}
+		if !b.retryable(err) {
+			return fmt.Errorf("failed to download %s: %w", b.name, err)
+		}
+	}
+}
+
+// retryable は新しいURLで再試行すべきエラーかどうかを返します。
+// 署名付きURLの失効は403として返されます。
+func (b *downloadBody) retryable(err error) bool {
+	if b.attempts >= downloadAttempts || b.ctx.Err() != nil {
+		return false
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+			return true
+		}
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// Read implements io.Reader.
+func (b *downloadBody) Read(p []byte) (int, error) {
+	if b.rc == nil {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := b.rc.Read(p)
+	b.offset += int64(n)
+	if err == nil || err == io.EOF || !b.retryable(err) {
+		return n, err
+	}
+
+	_ = b.rc.Close()
+	b.rc = nil
+	if err := b.open(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (b *downloadBody) Close() error {
+	if b.rc == nil {
+		return nil
+	}
+	err := b.rc.Close()
+	b.rc = nil
+	return err
+}
+
+// BulkRows はBulk APIのCSV（Openで展開したもの）を1行ずつ型Tの構造体として返すイテレータです。
+// Tには各サービスが返すデータ型（DailyQuote、Statementなど）を指定します。
+//
+// CSVのヘッダーはAPIのJSONフィールド名と同じであるため、各列は同名のフィールドに設定されます。
+// 数値の空欄・"-"（未定）・"*"（該当なし）はJSON APIと同じく値なし（nil）になり、
+// types.Nullable型のフィールドでは未定（IsUndetermined）も区別されます。
+// 入れ子のオブジェクトや配列のフィールドはCSVでは表現されないため設定されません。
+//
+// 行の変換に失敗した場合はその行のエラーを返して次の行に進みます。
+// CSVとして読めない場合はエラーを返して終了します。
+func BulkRows[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		cr := csv.NewReader(r)
+		cr.ReuseRecord = true
+		header, err := cr.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			yield(zero, fmt.Errorf("failed to read bulk CSV header: %w", err))
+			return
+		}
+		dec := newBulkRowDecoder[T](header)
+
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to read bulk CSV: %w", err))
+				return
+			}
+			v, err := dec.decode(record)
+			if err != nil {
+				line, _ := cr.FieldPos(0)
+				if !yield(zero, fmt.Errorf("failed to decode bulk CSV line %d: %w", line, err)) {
+					return
+				}
+				continue
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// bulkDecoders は、JSON APIでレスポンス型のUnmarshalJSONを経由して変換されるデータ型の一覧です。
+// これらの型は1行を {"data":[{...}]} として対応するレスポンス型でデコードし、
+// JSON APIと同じ変換（types.Nullableによる欠損値の扱いなど）を適用します。
+var bulkDecoders = map[reflect.Type]func([]byte) (any, error){
+	reflect.TypeFor[DailyQuote]():           bulkDecoder(func(r *DailyQuotesResponse) []DailyQuote { return r.Data }),
+	reflect.TypeFor[PriceAM]():              bulkDecoder(func(r *PricesAMResponse) []PriceAM { return r.Data }),
+	reflect.TypeFor[MinuteQuote]():          bulkDecoder(func(r *MinuteQuotesResponse) []MinuteQuote { return r.Data }),
+	reflect.TypeFor[Statement]():            bulkDecoder(func(r *StatementsResponse) []Statement { return r.Data }),
+	reflect.TypeFor[Breakdown]():            bulkDecoder(func(r *BreakdownResponse) []Breakdown { return r.Data }),
+	reflect.TypeFor[ShortSelling]():         bulkDecoder(func(r *ShortSellingResponse) []ShortSelling { return r.Data }),
+	reflect.TypeFor[ShortSellingPosition](): bulkDecoder(func(r *ShortSellingPositionsResponse) []ShortSellingPosition { return r.Data }),
+	reflect.TypeFor[WeeklyMarginInterest](): bulkDecoder(func(r *WeeklyMarginInterestResponse) []WeeklyMarginInterest { return r.Data }),
+	reflect.TypeFor[Index]():                bulkDecoder(func(r *IndicesResponse) []Index { return r.Data }),
+	reflect.TypeFor[TOPIXData]():            bulkDecoder(func(r *TOPIXResponse) []TOPIXData { return r.Data }),
+	reflect.TypeFor[Futures]():              bulkDecoder(func(r *FuturesResponse) []Futures { return r.Data }),
+	reflect.TypeFor[Option]():               bulkDecoder(func(r *OptionsResponse) []Option { return r.Data }),
+	reflect.TypeFor[IndexOption]():          bulkDecoder(func(r *IndexOptionResponse) []IndexOption { return r.Data }),
+}
+
+func bulkDecoder[R any, T any](data func(*R) []T) func([]byte) (any, error) {
+	return func(b []byte) (any, error) {
+		var resp R
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return nil, err
+		}
+		rows := data(&resp)
+		if len(rows) != 1 {
+			return nil, fmt.Errorf("unexpected number of rows: %d", len(rows))
+		}
+		return rows[0], nil
+	}
+}
+
+// bulkRowDecoder はCSVの1行をJSONオブジェクトに組み立ててTにデコードします。
+type bulkRowDecoder[T any] struct {
+	keys    [][]byte       // JSONエンコード済みの列名
+	kinds   []reflect.Kind // 列に対応するTのフィールドの種類（ポインタは要素の種類）
+	viaResp func([]byte) (any, error)
+	buf     bytes.Buffer
+}
+
+func newBulkRowDecoder[T any](header []string) *bulkRowDecoder[T] {
+	fields := map[string]reflect.Kind{}
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" {
+				name = f.Name
+			}
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			fields[name] = ft.Kind()
+		}
+	}
+
+	d := &bulkRowDecoder[T]{viaResp: bulkDecoders[t]}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // UTF-8 BOM
+		}
+		key, _ := json.Marshal(name)
+		d.keys = append(d.keys, key)
+		d.kinds = append(d.kinds, fields[name])
+	}
+	return d
+}
+
+func (d *bulkRowDecoder[T]) decode(record []string) (T, error) {
+	var v T
+	if len(record) != len(d.keys) {
+		return v, fmt.Errorf("expected %d fields, got %d", len(d.keys), len(record))
+	}
+
+	d.buf.Reset()
+	if d.viaResp != nil {
+		d.buf.WriteString(`{"data":[`)
+	}
+	d.buf.WriteByte('{')
+	for i, cell := range record {
+		if i > 0 {
+			d.buf.WriteByte(',')
+		}
+		d.buf.Write(d.keys[i])
+		d.buf.WriteByte(':')
+		writeBulkValue(&d.buf, d.kinds[i], cell)
+	}
+	d.buf.WriteByte('}')
+
+	if d.viaResp == nil {
+		err := json.Unmarshal(d.buf.Bytes(), &v)
+		return v, err
+	}
+	d.buf.WriteString(`]}`)
+	row, err := d.viaResp(d.buf.Bytes())
+	if err != nil {
+		return v, err
+	}
+	return row.(T), nil
+}
+
+// writeBulkValue はCSVのセルをフィールドの種類に応じたJSON値として書き込みます。
+// 数値・真偽値のフィールドでは、欠損を表すセル（空欄・"-"・"*"）をnullとし、
+// それ以外は文字列として渡してフィールド側の変換（types.Nullableなど）に任せます。
+func writeBulkValue(buf *bytes.Buffer, kind reflect.Kind, cell string) {
+	switch kind {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch cell {
+		case "", "-", "*":
+			buf.WriteString("null")
+			return
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil && json.Valid([]byte(cell)) {
+			buf.WriteString(cell)
+			return
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(cell); err == nil {
+			buf.WriteString(strconv.FormatBool(b))
+		} else {
+			buf.WriteString("null")
+		}
+		return
+	}
+	s, _ := json.Marshal(cell)
+	buf.Write(s)
+}
@@ -0,0 +1,248 @@
+package jquants
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+)
+
+const testBulkCSV = "Date,Code,O,H,L,C,UL,LL,Vo,Va,AdjFactor,AdjC,MktCap,ExRT\n" +
+	"2025-01-06,72030,2900,2950,2880,2930,0,0,1000000,2930000000,1,2930,,\n" +
+	"2025-01-06,13010,,,,-,0,0,0,0,1,*,-,\n" +
+	"2025-01-06,99840,9000,9100,8900,9050,0,0,500000,4525000000,0.5,4525,15000000,\"SPLIT\"\n"
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newBulkTestService はhandlerが配信するファイルを指す署名付きURLを返すBulkServiceを作成します。
+func newBulkTestService(t *testing.T, handler http.HandlerFunc) (*BulkService, *client.MockClient) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/bulk/get?key=equities%2Fbars%2Fdaily%2Ftest.csv.gz", map[string]string{"url": server.URL + "/test.csv.gz"})
+	return NewBulkService(mockClient), mockClient
+}
+
+func TestBulkService_Download(t *testing.T) {
+	data := gzipBytes(t, testBulkCSV)
+	service, mockClient := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "" {
+			t.Error("API key must not be sent to the signed URL")
+		}
+		_, _ = w.Write(data)
+	})
+
+	var buf bytes.Buffer
+	if err := service.Download(context.Background(), "equities/bars/daily/test.csv.gz", &buf); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if buf.String() != testBulkCSV {
+		t.Errorf("Download() wrote %q, want %q", buf.String(), testBulkCSV)
+	}
+	if !mockClient.LastSkipCache {
+		t.Error("signed URL must be requested without cache")
+	}
+}
+
+func TestBulkService_Download_ResumeWithFreshURL(t *testing.T) {
+	data := gzipBytes(t, testBulkCSV)
+	var calls int64
+	var gotRange string
+	service, mockClient := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt64(&calls, 1) {
+		case 1:
+			// 途中で接続が切れる
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write(data[:len(data)/2])
+		case 2:
+			// 再取得したURLは失効している
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Request has expired</Message></Error>"))
+		default:
+			gotRange = r.Header.Get("Range")
+			var offset int
+			_, _ = fmt.Sscanf(gotRange, "bytes=%d-", &offset)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(data)-1, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(data[offset:])
+		}
+	})
+
+	var buf bytes.Buffer
+	if err := service.Download(context.Background(), "equities/bars/daily/test.csv.gz", &buf); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if buf.String() != testBulkCSV {
+		t.Errorf("Download() wrote %q, want %q", buf.String(), testBulkCSV)
+	}
+	if want := fmt.Sprintf("bytes=%d-", len(data)/2); gotRange != want {
+		t.Errorf("Range = %q, want %q", gotRange, want)
+	}
+	// 再試行のたびに新しい署名付きURLを取得する
+	if mockClient.RequestCount != 3 {
+		t.Errorf("GetDownloadURL called %d times, want 3", mockClient.RequestCount)
+	}
+}
+
+func TestBulkService_Download_RangeIgnored(t *testing.T) {
+	data := gzipBytes(t, testBulkCSV)
+	var calls int64
+	service, _ := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if atomic.AddInt64(&calls, 1) == 1 {
+			_, _ = w.Write(data[:10])
+			return
+		}
+		// Rangeに対応しないサーバーは全体を返す
+		_, _ = w.Write(data)
+	})
+
+	var buf bytes.Buffer
+	if err := service.Download(context.Background(), "equities/bars/daily/test.csv.gz", &buf); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if buf.String() != testBulkCSV {
+		t.Errorf("Download() wrote %q, want %q", buf.String(), testBulkCSV)
+	}
+}
+
+func TestBulkService_Open_Errors(t *testing.T) {
+	t.Run("not found is not retried", func(t *testing.T) {
+		var calls int64
+		service, _ := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&calls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		})
+		_, err := service.Open(context.Background(), "equities/bars/daily/test.csv.gz")
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Fatalf("Open() error = %v, want APIError 404", err)
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var calls int64
+		service, _ := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&calls, 1)
+			w.WriteHeader(http.StatusForbidden)
+		})
+		if _, err := service.Open(context.Background(), "equities/bars/daily/test.csv.gz"); err == nil {
+			t.Fatal("Open() expected error")
+		}
+		if calls != downloadAttempts {
+			t.Errorf("calls = %d, want %d", calls, downloadAttempts)
+		}
+	})
+
+	t.Run("not gzip", func(t *testing.T) {
+		service, _ := newBulkTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testBulkCSV))
+		})
+		if _, err := service.Open(context.Background(), "equities/bars/daily/test.csv.gz"); err == nil {
+			t.Fatal("Open() expected error for non-gzip content")
+		}
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		service := NewBulkService(client.NewMockClient())
+		if _, err := service.Open(context.Background(), ""); err == nil {
+			t.Fatal("Open() expected error for empty key")
+		}
+	})
+}
+
+func TestBulkRows_DailyQuote(t *testing.T) {
+	var quotes []DailyQuote
+	for q, err := range BulkRows[DailyQuote](strings.NewReader("\ufeff" + testBulkCSV)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		quotes = append(quotes, q)
+	}
+	if len(quotes) != 3 {
+		t.Fatalf("got %d rows, want 3", len(quotes))
+	}
+
+	q := quotes[0]
+	if q.Date.String() != "2025-01-06" || q.Code != "72030" {
+		t.Errorf("Date = %q, Code = %q", q.Date, q.Code)
+	}
+	if q.C == nil || *q.C != 2930 || q.Vo == nil || *q.Vo != 1000000 {
+		t.Errorf("C = %v, Vo = %v", ptrToStr(q.C), ptrToStr(q.Vo))
+	}
+	if q.MktCap != nil || q.ExRT != nil {
+		t.Errorf("empty cells: MktCap = %v, ExRT = %v, want nil", ptrToStr(q.MktCap), q.ExRT)
+	}
+
+	// 空欄・"-"・"*" は値なし
+	q = quotes[1]
+	if q.O != nil || q.C != nil || q.AdjC != nil || q.MktCap != nil {
+		t.Errorf("missing values: O = %v, C = %v, AdjC = %v, MktCap = %v, want nil",
+			ptrToStr(q.O), ptrToStr(q.C), ptrToStr(q.AdjC), ptrToStr(q.MktCap))
+	}
+
+	q = quotes[2]
+	if q.AdjFactor != 0.5 || q.ExRT == nil || *q.ExRT != "SPLIT" {
+		t.Errorf("AdjFactor = %v, ExRT = %v", q.AdjFactor, q.ExRT)
+	}
+}
+
+func TestBulkRows_PlainStruct(t *testing.T) {
+	// レスポンス型を経由しない型はフィールドへ直接デコードされる
+	csv := "Date,Code,CoName,S33,Mkt\n2025-01-06,72030,トヨタ自動車,3700,0111\n"
+	var got []ListedInfo
+	for info, err := range BulkRows[ListedInfo](strings.NewReader(csv)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, info)
+	}
+	if len(got) != 1 || got[0].CoName != "トヨタ自動車" || got[0].Mkt != "0111" || got[0].S33 != "3700" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestBulkRows_Errors(t *testing.T) {
+	csv := "Date,Code,C\n2025-01-06,72030,abc\n2025-01-06,99840,9050\n2025-01-06\n"
+	var rows, errs int
+	for _, err := range BulkRows[DailyQuote](strings.NewReader(csv)) {
+		if err != nil {
+			errs++
+			continue
+		}
+		rows++
+	}
+	// 変換できない行はエラーを返して次の行に進み、CSVとして読めない行で終了する
+	if rows != 1 || errs != 2 {
+		t.Errorf("rows = %d, errs = %d, want 1, 2", rows, errs)
+	}
+
+	for range BulkRows[DailyQuote](strings.NewReader("")) {
+		t.Error("expected no rows for empty input")
+	}
+}
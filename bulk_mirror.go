@@ -0,0 +1,266 @@
+package jquants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/utahta/jquants/client"
+)
+
+// BulkManifestFile は Mirror がダウンロード済みファイルを記録するマニフェストのファイル名です。
+const BulkManifestFile = "manifest.json"
+
+// デフォルトの同時ダウンロード数
+const defaultMirrorConcurrency = 4
+
+// MirrorOption はMirrorの動作を設定するオプションです。
+type MirrorOption func(*mirrorConfig)
+
+type mirrorConfig struct {
+	concurrency int
+	limiter     *client.RateLimiter
+}
+
+// WithMirrorConcurrency は同時にダウンロードするファイル数を設定します（デフォルト: 4）。
+func WithMirrorConcurrency(n int) MirrorOption {
+	return func(c *mirrorConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithMirrorRateLimit はファイルのダウンロード開始（署名付きURLの取得）を
+// 1分あたりreqPerMinute回に制限します。0以下の場合は制限しません（デフォルト）。
+// クライアントにWithRateLimitを設定している場合は、そちらの制限も適用されます。
+func WithMirrorRateLimit(reqPerMinute int) MirrorOption {
+	return func(c *mirrorConfig) {
+		c.limiter = nil
+		if reqPerMinute > 0 {
+			c.limiter = client.NewRateLimiter(reqPerMinute, 1)
+		}
+	}
+}
+
+// MirrorResult はMirrorの結果です。各スライスはファイルのキーを昇順で保持します。
+type MirrorResult struct {
+	Added   []string // 新たにダウンロードしたファイル
+	Updated []string // 更新されていたため再ダウンロードしたファイル
+	Skipped []string // 変更がないためダウンロードしなかったファイル
+	Bytes   int64    // ダウンロードしたバイト数
+}
+
+// bulkManifest はダウンロード済みファイルのキーと、ダウンロード時点の一覧の情報です。
+type bulkManifest struct {
+	Files map[string]BulkFile `json:"files"`
+}
+
+// Mirror はendpointのfrom〜to（YYYYMM等。空の場合は全期間）のファイルを、dir以下にキーと同じ
+// ディレクトリ構成でダウンロードします（gzip圧縮のまま保存します）。
+//
+// dir/manifest.json にダウンロード済みファイルのLastModifiedとSizeを記録し、
+// 一覧と一致してファイルも存在する場合はダウンロードしません。そのため毎日再実行しても
+// 新規・更新されたファイルだけを取得します。ファイルは一時ファイルに書き込んでから
+// リネームするため、中断しても不完全なファイルが残ることはありません。
+//
+// いずれかのファイルでエラーが発生した場合は残りのダウンロードを中止し、
+// それまでに完了したファイルをマニフェストに記録してエラーを返します。
+func (s *BulkService) Mirror(ctx context.Context, endpoint, dir, from, to string, opts ...MirrorOption) (*MirrorResult, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint parameter is required")
+	}
+	cfg := mirrorConfig{concurrency: defaultMirrorConcurrency}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// 一覧のLastModified・Sizeで更新を検出するため、キャッシュを経由せずに取得する
+	list, err := s.getFiles(ctx, BulkListParams{Endpoint: endpoint, From: from, To: to}, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	manifest, err := readBulkManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(list.Data))
+	for i, file := range list.Data {
+		if paths[i], err = mirrorPath(dir, file.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	// ダウンロードが必要なファイルはワーカーの開始前に決めておき、ワーカーからはマニフェストを参照しない
+	result := &MirrorResult{}
+	var jobs []mirrorJob
+	for i, file := range list.Data {
+		prev, known := manifest.Files[file.Key]
+		if known && prev == file && fileHasSize(paths[i], file.Size) {
+			result.Skipped = append(result.Skipped, file.Key)
+			continue
+		}
+		jobs = append(jobs, mirrorJob{file: file, path: paths[i], known: known})
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.concurrency)
+	for i := range jobs {
+		job := &jobs[i]
+		g.Go(func() error {
+			if cfg.limiter != nil {
+				if err := cfg.limiter.Wait(gctx); err != nil {
+					return err
+				}
+			}
+			n, err := s.downloadFile(gctx, job.file, job.path)
+			if err != nil {
+				return err
+			}
+			job.bytes, job.done = n, true
+			return nil
+		})
+	}
+
+	// 完了したファイルだけをマニフェストと結果に反映する
+	err = g.Wait()
+	for _, job := range jobs {
+		if !job.done {
+			continue
+		}
+		manifest.Files[job.file.Key] = job.file
+		result.Bytes += job.bytes
+		if job.known {
+			result.Updated = append(result.Updated, job.file.Key)
+		} else {
+			result.Added = append(result.Added, job.file.Key)
+		}
+	}
+	if werr := writeBulkManifest(dir, manifest); err == nil {
+		err = werr
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Skipped)
+	return result, err
+}
+
+// mirrorJob はMirrorでダウンロードするファイルと、その結果です。
+// bytesとdoneはファイルを担当するワーカーだけが書き込みます。
+type mirrorJob struct {
+	file  BulkFile
+	path  string
+	known bool // マニフェストに記録済み（更新）かどうか
+
+	bytes int64
+	done  bool
+}
+
+// downloadFile はファイルを圧縮されたままpathへアトミックに書き込み、書き込んだバイト数を返します。
+func (s *BulkService) downloadFile(ctx context.Context, file BulkFile, path string) (int64, error) {
+	body := s.newBody(ctx, file.Key)
+	if err := body.open(); err != nil {
+		return 0, err
+	}
+	defer func() { _ = body.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create directory for %s: %w", file.Key, err)
+	}
+	n, err := writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		n, err := io.Copy(w, body)
+		if err == nil && file.Size > 0 && n != file.Size {
+			err = fmt.Errorf("size mismatch: got %d bytes, want %d", n, file.Size)
+		}
+		return n, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to download bulk file %s: %w", file.Key, err)
+	}
+	return n, nil
+}
+
+// mirrorPath はキーに対応するdir以下のパスを返します。dirの外を指すキーはエラーです。
+func mirrorPath(dir, key string) (string, error) {
+	rel := filepath.FromSlash(key)
+	if key == "" || !filepath.IsLocal(rel) || rel == BulkManifestFile {
+		return "", fmt.Errorf("invalid bulk file key %q", key)
+	}
+	return filepath.Join(dir, rel), nil
+}
+
+func fileHasSize(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Size() == size
+}
+
+func readBulkManifest(dir string) (*bulkManifest, error) {
+	m := &bulkManifest{}
+	data, err := os.ReadFile(filepath.Join(dir, BulkManifestFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	default:
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+	}
+	if m.Files == nil {
+		m.Files = map[string]BulkFile{}
+	}
+	return m, nil
+}
+
+func writeBulkManifest(dir string, m *bulkManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	_, err = writeFileAtomic(filepath.Join(dir, BulkManifestFile), func(w io.Writer) (int64, error) {
+		n, err := w.Write(append(data, '\n'))
+		return int64(n), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic はwriteの内容を同じディレクトリの一時ファイルに書き込み、
+// 成功した場合のみpathへリネームします。
+func writeFileAtomic(path string, write func(io.Writer) (int64, error)) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+strings.TrimPrefix(filepath.Base(path), ".")+".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	n, err := write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,284 @@
+package jquants
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+)
+
+// syncMockClient はMirrorの並行ダウンロードから呼び出せるよう、MockClientを排他制御します。
+type syncMockClient struct {
+	mu sync.Mutex
+	*client.MockClient
+}
+
+func (c *syncMockClient) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.MockClient.DoRequest(ctx, method, path, body, result)
+}
+
+func (c *syncMockClient) DoRequestNoCache(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.MockClient.DoRequestNoCache(ctx, method, path, body, result)
+}
+
+// cachingClient はclient.WithCacheと同様に、DoRequestのレスポンスをパスごとに保持し続けるテスト用のクライアントです。
+// DoRequestNoCacheはキャッシュを経由しません。ポーリングや更新検出がキャッシュに隠されないことの確認に使います。
+type cachingClient struct {
+	client.HTTPClient
+	mu    sync.Mutex
+	cache map[string][]byte
+}
+
+func newCachingClient(c client.HTTPClient) *cachingClient {
+	return &cachingClient{HTTPClient: c, cache: map[string][]byte{}}
+}
+
+func (c *cachingClient) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	c.mu.Lock()
+	data, ok := c.cache[path]
+	c.mu.Unlock()
+	if !ok {
+		var raw json.RawMessage
+		if err := c.HTTPClient.DoRequest(ctx, method, path, body, &raw); err != nil {
+			return err
+		}
+		data = raw
+		c.mu.Lock()
+		c.cache[path] = data
+		c.mu.Unlock()
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (c *cachingClient) DoRequestNoCache(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	return client.DoRequestNoCache(ctx, c.HTTPClient, method, path, body, result)
+}
+
+type mirrorTestServer struct {
+	mock      *client.MockClient
+	service   *BulkService
+	serverURL string
+	contents  map[string]string
+	downloads atomic.Int64
+}
+
+func newMirrorTestServer(t *testing.T) *mirrorTestServer {
+	ts := &mirrorTestServer{mock: client.NewMockClient(), contents: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.downloads.Add(1)
+		_, _ = w.Write([]byte(ts.contents[strings.TrimPrefix(r.URL.Path, "/")]))
+	}))
+	t.Cleanup(server.Close)
+
+	// キャッシュを有効にしたクライアントでも一覧の更新を検出できることを確認する
+	ts.service = NewBulkService(newCachingClient(&syncMockClient{MockClient: ts.mock}))
+	ts.serverURL = server.URL
+	return ts
+}
+
+func (ts *mirrorTestServer) setFiles(files map[string]string, lastModified string) {
+	ts.mock.Responses = map[string]interface{}{}
+	var list BulkListResponse
+	for key, content := range files {
+		ts.contents[key] = content
+		list.Data = append(list.Data, BulkFile{Key: key, LastModified: lastModified, Size: int64(len(content))})
+		ts.mock.SetResponse("GET", "/bulk/get?key="+url.QueryEscape(key), map[string]string{"url": ts.serverURL + "/" + key})
+	}
+	ts.mock.SetResponse("GET", "/bulk/list?endpoint=%2Fequities%2Fbars%2Fdaily&from=202501&to=202502", list)
+}
+
+func TestBulkService_Mirror(t *testing.T) {
+	ts := newMirrorTestServer(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	files := map[string]string{
+		"equities/bars/daily/historical/2025/equities_bars_daily_202501.csv.gz": "january",
+		"equities/bars/daily/historical/2025/equities_bars_daily_202502.csv.gz": "february",
+	}
+	ts.setFiles(files, "2025-03-01T00:00:00Z")
+
+	result, err := ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502", WithMirrorConcurrency(2), WithMirrorRateLimit(6000))
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Added) != 2 || len(result.Updated) != 0 || len(result.Skipped) != 0 {
+		t.Errorf("first run: %+v, want 2 added", result)
+	}
+	if result.Bytes != int64(len("january")+len("february")) {
+		t.Errorf("Bytes = %d", result.Bytes)
+	}
+	for key, content := range files {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+		if err != nil || string(got) != content {
+			t.Errorf("%s = (%q, %v), want %q", key, got, err, content)
+		}
+	}
+
+	// 変更がなければダウンロードしない
+	ts.downloads.Store(0)
+	result, err = ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502")
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Skipped) != 2 || len(result.Added)+len(result.Updated) != 0 {
+		t.Errorf("second run: %+v, want 2 skipped", result)
+	}
+	if ts.downloads.Load() != 0 {
+		t.Errorf("downloaded %d files, want 0", ts.downloads.Load())
+	}
+
+	// 一覧のLastModifiedが変わったファイルは再ダウンロードする
+	files["equities/bars/daily/historical/2025/equities_bars_daily_202502.csv.gz"] = "february (revised)"
+	ts.setFiles(files, "2025-03-02T00:00:00Z")
+	result, err = ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502")
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Updated) != 2 {
+		t.Errorf("third run: %+v, want 2 updated", result)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "equities/bars/daily/historical/2025/equities_bars_daily_202502.csv.gz"))
+	if string(got) != "february (revised)" {
+		t.Errorf("updated file = %q", got)
+	}
+
+	// ローカルから削除されたファイルは再ダウンロードする
+	if err := os.Remove(filepath.Join(dir, "equities/bars/daily/historical/2025/equities_bars_daily_202501.csv.gz")); err != nil {
+		t.Fatal(err)
+	}
+	result, err = ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502")
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Updated) != 1 || len(result.Skipped) != 1 {
+		t.Errorf("fourth run: %+v, want 1 updated and 1 skipped", result)
+	}
+
+	var manifest bulkManifest
+	data, err := os.ReadFile(filepath.Join(dir, BulkManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if f := manifest.Files["equities/bars/daily/historical/2025/equities_bars_daily_202502.csv.gz"]; f.LastModified != "2025-03-02T00:00:00Z" || f.Size != int64(len("february (revised)")) {
+		t.Errorf("manifest entry = %+v", f)
+	}
+
+	// 一時ファイルが残っていない
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if strings.Contains(d.Name(), ".tmp-") {
+			t.Errorf("temporary file left: %s", path)
+		}
+		return nil
+	})
+}
+
+// 多数のファイルを並行にダウンロードしてもマニフェストと結果が壊れない（go test -raceで確認する）
+func TestBulkService_Mirror_Concurrent(t *testing.T) {
+	ts := newMirrorTestServer(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	files := map[string]string{}
+	for i := range 32 {
+		files[fmt.Sprintf("equities/bars/daily/2025/%02d.csv.gz", i)] = strings.Repeat("x", i+1)
+	}
+	ts.setFiles(files, "2025-03-01T00:00:00Z")
+
+	result, err := ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502", WithMirrorConcurrency(4))
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Added) != len(files) || !slices.IsSorted(result.Added) {
+		t.Errorf("Added = %v, want %d sorted keys", result.Added, len(files))
+	}
+	if result.Bytes != 32*33/2 {
+		t.Errorf("Bytes = %d, want %d", result.Bytes, 32*33/2)
+	}
+
+	// 半分のファイルを更新して再実行する
+	for key := range files {
+		if key < "equities/bars/daily/2025/16" {
+			files[key] += "!"
+		}
+	}
+	ts.setFiles(files, "2025-03-01T00:00:00Z")
+	result, err = ts.service.Mirror(ctx, "/equities/bars/daily", dir, "202501", "202502", WithMirrorConcurrency(4))
+	if err != nil {
+		t.Fatalf("Mirror() error = %v", err)
+	}
+	if len(result.Updated) != 16 || len(result.Skipped) != 16 || len(result.Added) != 0 {
+		t.Errorf("second run: %d updated, %d skipped, %d added, want 16, 16, 0", len(result.Updated), len(result.Skipped), len(result.Added))
+	}
+	manifest, err := readBulkManifest(dir)
+	if err != nil {
+		t.Fatalf("readBulkManifest() error = %v", err)
+	}
+	for key, content := range files {
+		if f := manifest.Files[key]; f.Size != int64(len(content)) {
+			t.Errorf("manifest %s size = %d, want %d", key, f.Size, len(content))
+		}
+	}
+}
+
+func TestBulkService_Mirror_SizeMismatch(t *testing.T) {
+	ts := newMirrorTestServer(t)
+	dir := t.TempDir()
+
+	key := "equities/bars/daily/historical/2025/equities_bars_daily_202501.csv.gz"
+	ts.setFiles(map[string]string{key: "january"}, "2025-03-01T00:00:00Z")
+	ts.contents[key] = "jan" // 一覧のSizeと異なる
+
+	result, err := ts.service.Mirror(context.Background(), "/equities/bars/daily", dir, "202501", "202502")
+	if err == nil {
+		t.Fatal("Mirror() expected error for size mismatch")
+	}
+	if len(result.Added) != 0 {
+		t.Errorf("Added = %v, want none", result.Added)
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(key))); !os.IsNotExist(err) {
+		t.Errorf("incomplete file must not be written: %v", err)
+	}
+}
+
+func TestBulkService_Mirror_InvalidKey(t *testing.T) {
+	ts := newMirrorTestServer(t)
+	ts.setFiles(map[string]string{"../escape.csv.gz": "x"}, "2025-03-01T00:00:00Z")
+
+	if _, err := ts.service.Mirror(context.Background(), "/equities/bars/daily", t.TempDir(), "202501", "202502"); err == nil {
+		t.Fatal("Mirror() expected error for a key outside the directory")
+	}
+	if ts.downloads.Load() != 0 {
+		t.Errorf("downloaded %d files, want 0", ts.downloads.Load())
+	}
+}
+
+func TestMirrorPath(t *testing.T) {
+	for _, key := range []string{"", "../a.csv.gz", "/abs.csv.gz", BulkManifestFile} {
+		if _, err := mirrorPath("dir", key); err == nil {
+			t.Errorf("mirrorPath(%q) expected error", key)
+		}
+	}
+	got, err := mirrorPath("dir", "equities/bars/daily/a.csv.gz")
+	if err != nil || !slices.Equal(strings.Split(filepath.ToSlash(got), "/"), []string{"dir", "equities", "bars", "daily", "a.csv.gz"}) {
+		t.Errorf("mirrorPath() = (%q, %v)", got, err)
+	}
+}
@@ -21,7 +21,7 @@ func TestBulkService_GetFiles(t *testing.T) {
 			params: BulkListParams{
 				Endpoint: "/equities/bars/daily",
 			},
-			wantPath: "/bulk/list?endpoint=/equities/bars/daily",
+			wantPath: "/bulk/list?endpoint=%2Fequities%2Fbars%2Fdaily",
 		},
 		{
 			name: "with date only",
@@ -37,7 +37,7 @@ func TestBulkService_GetFiles(t *testing.T) {
 				From:     "202401",
 				To:       "202412",
 			},
-			wantPath: "/bulk/list?endpoint=/equities/bars/daily&from=202401&to=202412",
+			wantPath: "/bulk/list?endpoint=%2Fequities%2Fbars%2Fdaily&from=202401&to=202412",
 		},
 		{
 			name:     "with no required parameters",
@@ -192,7 +192,7 @@ func TestBulkService_GetDownloadURL(t *testing.T) {
 			params: BulkGetParams{
 				Key: "equities/bars/daily/historical/2025/equities_bars_daily_202501.csv.gz",
 			},
-			wantPath: "/bulk/get?key=equities/bars/daily/historical/2025/equities_bars_daily_202501.csv.gz",
+			wantPath: "/bulk/get?key=equities%2Fbars%2Fdaily%2Fhistorical%2F2025%2Fequities_bars_daily_202501.csv.gz",
 		},
 		{
 			name: "with endpoint and date",
@@ -200,7 +200,7 @@ func TestBulkService_GetDownloadURL(t *testing.T) {
 				Endpoint: "/equities/bars/daily",
 				Date:     "202501",
 			},
-			wantPath: "/bulk/get?endpoint=/equities/bars/daily&date=202501",
+			wantPath: "/bulk/get?endpoint=%2Fequities%2Fbars%2Fdaily&date=202501",
 		},
 		{
 			name: "with key and endpoint",
@@ -280,7 +280,7 @@ func TestBulkService_GetDownloadURL_Error(t *testing.T) {
 	service := NewBulkService(mockClient)
 
 	// Set error response
-	mockClient.SetError("GET", "/bulk/get?key=some/file.csv.gz", fmt.Errorf("unauthorized"))
+	mockClient.SetError("GET", "/bulk/get?key=some%2Ffile.csv.gz", fmt.Errorf("unauthorized"))
 
 	// Execute
 	_, err := service.GetDownloadURL(context.Background(), BulkGetParams{Key: "some/file.csv.gz"})
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NoExpiration is the TTL for cache entries that never expire.
+const NoExpiration time.Duration = 0
+
+// CacheTTLRecent is the TTL that DefaultCacheTTL assigns to responses that may
+// still change, such as today's data or open-ended ranges.
+const CacheTTLRecent = 10 * time.Minute
+
+// Cache stores raw API responses keyed by the normalized request.
+// Implementations must be safe for concurrent use. Failures of a persistent
+// store are not reported: a failed Get is a miss and a failed Set is dropped.
+type Cache interface {
+	// Get returns the data stored for key. Expired entries are reported as
+	// missing.
+	Get(key string) ([]byte, bool)
+	// Set stores data for key. A ttl of NoExpiration keeps the entry until it
+	// is deleted or evicted.
+	Set(key string, data []byte, ttl time.Duration)
+	// Delete removes the entry for key, if any.
+	Delete(key string)
+}
+
+// CacheTTLFunc decides how long the response to a GET request for path (the
+// request path including the query string) may be cached. It returns
+// NoExpiration to keep the response indefinitely and a negative value to skip
+// caching.
+type CacheTTLFunc func(path string, now time.Time) time.Duration
+
+// cacheClearer is implemented by caches that can remove all entries.
+type cacheClearer interface {
+	Clear()
+}
+
+// cacheLener is implemented by caches that can report their number of entries.
+type cacheLener interface {
+	Len() int
+}
+
+// CacheStats holds cache counters since the cache was created.
+type CacheStats struct {
+	Hits      int64 // lookups served from the cache
+	Misses    int64 // lookups not found or expired
+	Evictions int64 // entries removed to stay within the size limits
+}
+
+// cachePeeker is implemented by caches that can look up an entry without
+// counting it in CacheStats.
+type cachePeeker interface {
+	peek(key string) ([]byte, bool)
+}
+
+// cacheStatser is implemented by caches that can report CacheStats.
+type cacheStatser interface {
+	Stats() CacheStats
+}
+
+// WithCacheStore enables caching backed by the given Cache, such as a
+// FileCache that persists responses across processes.
+// Entries are stored with DefaultCacheTTL unless WithCacheTTL is specified.
+func WithCacheStore(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cacheEnabled = true
+		c.cache = cache
+		if c.cacheTTL == nil {
+			c.cacheTTL = DefaultCacheTTL
+		}
+	}
+}
+
+// WithCacheTTL sets the function that decides the TTL of each cached response.
+// It applies to both WithCache and WithCacheStore.
+func WithCacheTTL(fn CacheTTLFunc) ClientOption {
+	return func(c *Client) {
+		c.cacheTTL = fn
+	}
+}
+
+// jst is the time zone that the J-Quants API uses for dates.
+var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// DefaultCacheTTL is a CacheTTLFunc based on the dates in the request:
+//
+//   - /markets/calendar expires at the next midnight JST
+//   - requests whose date (or to) lies entirely before today (JST) never expire
+//   - other requests, including today's data and open-ended ranges such as a
+//     code-only query, expire after CacheTTLRecent
+func DefaultCacheTTL(path string, now time.Time) time.Duration {
+	endpoint, rawQuery, _ := strings.Cut(path, "?")
+	now = now.In(jst)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, jst)
+
+	if endpoint == "/markets/calendar" {
+		return today.AddDate(0, 0, 1).Sub(now)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return CacheTTLRecent
+	}
+	last := query.Get("date")
+	if last == "" {
+		last = query.Get("to")
+	}
+	if end, ok := periodEnd(last); ok && !end.After(today) {
+		return NoExpiration
+	}
+	return CacheTTLRecent
+}
+
+// periodEnd returns the day after the last day covered by a date parameter in
+// one of the formats the API accepts (YYYY-MM-DD, YYYYMMDD, YYYY-MM, YYYYMM).
+func periodEnd(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, jst); err == nil {
+			return t.AddDate(0, 0, 1), true
+		}
+	}
+	for _, layout := range []string{"2006-01", "200601"} {
+		if t, err := time.ParseInLocation(layout, s, jst); err == nil {
+			return t.AddDate(0, 1, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cacheKey returns the cache key of a request. Query parameters are sorted so
+// that the same request built in a different order shares an entry.
+func cacheKey(method, path string) string {
+	endpoint, rawQuery, ok := strings.Cut(path, "?")
+	if !ok {
+		return method + ":" + path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return method + ":" + path
+	}
+	return method + ":" + endpoint + "?" + query.Encode()
+}
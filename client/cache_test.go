@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDefaultCacheTTL(t *testing.T) {
+	// 2024-06-15 10:00 JST
+	now := time.Date(2024, 6, 15, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		path string
+		want time.Duration
+	}{
+		{name: "past date", path: "/equities/bars/daily?date=20240614", want: NoExpiration},
+		{name: "past date with hyphens", path: "/equities/bars/daily?date=2024-06-14", want: NoExpiration},
+		{name: "past range", path: "/equities/bars/daily?code=7203&from=20240101&to=20240531", want: NoExpiration},
+		{name: "past month", path: "/bulk/list?endpoint=%2Fequities%2Fbars%2Fdaily&date=2024-05", want: NoExpiration},
+		{name: "today", path: "/equities/bars/daily?date=20240615", want: CacheTTLRecent},
+		{name: "current month", path: "/bulk/list?date=202406", want: CacheTTLRecent},
+		{name: "range up to today", path: "/equities/bars/daily?code=7203&from=20240101&to=20240615", want: CacheTTLRecent},
+		{name: "code only", path: "/equities/bars/daily?code=7203", want: CacheTTLRecent},
+		{name: "open-ended range", path: "/equities/bars/daily?code=7203&from=20240101", want: CacheTTLRecent},
+		{name: "no query", path: "/equities/master", want: CacheTTLRecent},
+		{name: "calendar", path: "/markets/calendar?from=20240101&to=20241231", want: 14 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultCacheTTL(tt.path, now); got != tt.want {
+				t.Errorf("DefaultCacheTTL(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	// パラメータの順序が異なるだけのリクエストは同じキーになる
+	a := cacheKey(http.MethodGet, "/equities/bars/daily?code=7203&from=20240101&to=20240131")
+	b := cacheKey(http.MethodGet, "/equities/bars/daily?to=20240131&code=7203&from=20240101")
+	if a != b {
+		t.Errorf("cacheKey differs by parameter order: %q vs %q", a, b)
+	}
+
+	if got := cacheKey(http.MethodGet, "/test"); got != "GET:/test" {
+		t.Errorf("cacheKey() = %q, want %q", got, "GET:/test")
+	}
+	if cacheKey(http.MethodGet, "/test?code=7203") == cacheKey(http.MethodGet, "/test?code=9984") {
+		t.Error("different parameters must not share a cache key")
+	}
+}
+
+func TestClient_DoRequest_CacheStore(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "hello"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	type response struct {
+		Message string `json:"message"`
+	}
+
+	// 1つ目のクライアントでキャッシュを作成
+	store1, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	c1 := NewClient("test-api-key", WithCacheStore(store1))
+	c1.baseURL = server.URL
+	var resp1 response
+	if err := c1.DoRequest(context.Background(), http.MethodGet, "/equities/bars/daily?date=20200101", nil, &resp1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 別プロセスを想定した新しいクライアントでもキャッシュが使われる
+	store2, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	c2 := NewClient("test-api-key", WithCacheStore(store2))
+	c2.baseURL = server.URL
+	var resp2 response
+	if err := c2.DoRequest(context.Background(), http.MethodGet, "/equities/bars/daily?date=20200101", nil, &resp2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp2.Message != "hello" {
+		t.Errorf("Message = %q, want %q", resp2.Message, "hello")
+	}
+	if got := atomic.LoadInt64(&callCount); got != 1 {
+		t.Errorf("expected 1 call (persisted cache), got %d", got)
+	}
+	if c2.CacheSize() != 1 {
+		t.Errorf("CacheSize() = %d, want 1", c2.CacheSize())
+	}
+
+	c2.ClearCache()
+	if c2.CacheSize() != 0 {
+		t.Errorf("CacheSize() = %d after ClearCache, want 0", c2.CacheSize())
+	}
+}
+
+func TestClient_DoRequest_CacheTTLSkip(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// 負のTTLはキャッシュしない
+	skip := func(path string, now time.Time) time.Duration { return -1 }
+	c := NewClient("test-api-key", WithCache(), WithCacheTTL(skip))
+	c.baseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt64(&callCount); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+	if c.CacheSize() != 0 {
+		t.Errorf("CacheSize() = %d, want 0", c.CacheSize())
+	}
+}
+
+func TestWithCacheStore_DefaultTTL(t *testing.T) {
+	store := NewMemoryCache()
+
+	c := NewClient("test-api-key", WithCacheStore(store))
+	if c.cacheTTL == nil {
+		t.Error("expected DefaultCacheTTL to be set for WithCacheStore")
+	}
+
+	// WithCacheTTLは指定順序に関わらず優先される
+	custom := func(path string, now time.Time) time.Duration { return time.Hour }
+	for _, opts := range [][]ClientOption{
+		{WithCacheTTL(custom), WithCacheStore(store)},
+		{WithCacheStore(store), WithCacheTTL(custom)},
+	} {
+		c := NewClient("test-api-key", opts...)
+		if got := c.ttl("/test"); got != time.Hour {
+			t.Errorf("ttl() = %v, want 1h", got)
+		}
+	}
+}
@@ -4,14 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"sync"
 	"time"
-
-	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -23,10 +21,14 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	// Cache related fields
-	cache        map[string][]byte
+	cache        Cache
 	cacheEnabled bool
-	mu           sync.RWMutex
-	sf           singleflight.Group
+	cacheTTL     CacheTTLFunc
+	flights      flightGroup
+	// retry is nil unless WithRetry is specified
+	retry *RetryPolicy
+	// limiter is nil unless WithRateLimit is specified
+	limiter *RateLimiter
 }
 
 // ClientOption is a function type for configuring Client settings.
@@ -50,11 +52,17 @@ func DoRequestNoCache(ctx context.Context, c HTTPClient, method, path string, bo
 }
 
 // WithCache enables caching functionality.
-// Cache is only applied to GET requests.
-func WithCache() ClientOption {
+// Cache is only applied to GET requests. Responses are kept in memory for the
+// lifetime of the client unless WithCacheTTL or MemoryCacheOption limits are
+// specified:
+//
+//	client.WithCache(client.WithMaxBytes(512<<20), client.WithEntryTTL(time.Hour))
+//
+// Use WithCacheStore for a persistent cache.
+func WithCache(opts ...MemoryCacheOption) ClientOption {
 	return func(c *Client) {
 		c.cacheEnabled = true
-		c.cache = make(map[string][]byte)
+		c.cache = NewMemoryCache(opts...)
 	}
 }
 
@@ -86,54 +94,48 @@ func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
 }
 
 func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	cacheKey := method + ":" + path
-
 	if method == http.MethodGet && c.cacheEnabled {
+		key := cacheKey(method, path)
+
 		// Check cache first
-		c.mu.RLock()
-		cached, ok := c.cache[cacheKey]
-		c.mu.RUnlock()
-		if ok {
+		if cached, ok := c.cache.Get(key); ok {
 			return decodeResponse(cached, result)
 		}
 
-		// Use singleflight to deduplicate concurrent requests for the same key
-		ch := c.sf.DoChan(cacheKey, func() (interface{}, error) {
+		// Deduplicate concurrent requests for the same key
+		f := c.flights.join(ctx, key, func(ctx context.Context) ([]byte, error) {
 			// Check cache again (another goroutine may have cached it)
-			c.mu.RLock()
-			cached, ok := c.cache[cacheKey]
-			c.mu.RUnlock()
-			if ok {
+			if cached, ok := c.recheckCache(key); ok {
 				return cached, nil
 			}
 
-			// Detach the shared request from the caller's cancellation so that
-			// one caller canceling does not fail the flight for other waiters.
-			// The http.Client timeout still bounds the request duration.
-			data, err := c.doHTTPRequest(context.WithoutCancel(ctx), method, path, body)
+			// The flight context is detached from each caller and canceled
+			// when the last waiter gives up, which also stops retries.
+			data, err := c.doHTTPRequest(ctx, method, path, body)
 			if err != nil {
 				return nil, err
 			}
 
 			// Store in cache
-			c.mu.Lock()
-			c.cache[cacheKey] = data
-			c.mu.Unlock()
+			if ttl := c.ttl(path); ttl >= 0 {
+				c.cache.Set(key, data, ttl)
+			}
 
 			return data, nil
 		})
 
 		// Wait for the shared flight, but let each caller honor its own context.
-		// A canceled caller returns immediately; the flight keeps running and
-		// populates the cache for other callers.
+		// A canceled caller returns immediately; the flight keeps running for
+		// the remaining waiters and is canceled when none remain.
 		select {
 		case <-ctx.Done():
+			c.flights.leave(key, f)
 			return ctx.Err()
-		case res := <-ch:
-			if res.Err != nil {
-				return res.Err
+		case <-f.done:
+			if f.err != nil {
+				return f.err
 			}
-			return decodeResponse(res.Val.([]byte), result)
+			return decodeResponse(f.data, result)
 		}
 	}
 
@@ -146,8 +148,25 @@ func (c *Client) DoRequest(ctx context.Context, method, path string, body interf
 	return decodeResponse(respBody, result)
 }
 
+// recheckCache looks up key again after a miss. The lookup is not counted in
+// CacheStats when the cache supports it, since the miss was already counted.
+func (c *Client) recheckCache(key string) ([]byte, bool) {
+	if p, ok := c.cache.(cachePeeker); ok {
+		return p.peek(key)
+	}
+	return c.cache.Get(key)
+}
+
+// ttl returns the TTL for caching the response to path.
+func (c *Client) ttl(path string) time.Duration {
+	if c.cacheTTL == nil {
+		return NoExpiration
+	}
+	return c.cacheTTL(path, time.Now())
+}
+
 // DoRequestNoCache performs a request bypassing the session cache and the
-// singleflight deduplication, so every call reaches the server. Responses are
+// request deduplication, so every call reaches the server. Responses are
 // not stored in the cache. See NoCacheRequester for when to use this.
 func (c *Client) DoRequestNoCache(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	respBody, err := c.doHTTPRequest(ctx, method, path, body)
@@ -157,8 +176,37 @@ func (c *Client) DoRequestNoCache(ctx context.Context, method, path string, body
 	return decodeResponse(respBody, result)
 }
 
-// doHTTPRequest performs the actual HTTP request.
+// doHTTPRequest performs the HTTP request, retrying transient failures when a
+// retry policy is configured.
 func (c *Client) doHTTPRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	maxAttempts := c.retry.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		respBody, err := c.doHTTPRequestOnce(ctx, method, path, body)
+		if err == nil {
+			return respBody, nil
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErr.Attempts = attempt
+		}
+		if attempt >= maxAttempts || !isRetryable(ctx, err) {
+			return nil, err
+		}
+		if err := sleepContext(ctx, c.retry.wait(attempt, err)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doHTTPRequestOnce performs a single HTTP request.
+func (c *Client) doHTTPRequestOnce(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	url := c.baseURL + path
 
 	var reqBody io.Reader
@@ -182,7 +230,7 @@ func (c *Client) doHTTPRequest(ctx context.Context, method, path string, body in
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, &sendError{err: err}
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -192,7 +240,12 @@ func (c *Client) doHTTPRequest(ctx context.Context, method, path string, body in
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Attempts:   1,
+		}
 	}
 
 	return respBody, nil
@@ -209,22 +262,37 @@ func decodeResponse(data []byte, result interface{}) error {
 	return nil
 }
 
-// ClearCache clears the cache.
+// ClearCache clears the cache. It has no effect on a Cache that does not
+// implement Clear().
 func (c *Client) ClearCache() {
 	if !c.cacheEnabled {
 		return
 	}
-	c.mu.Lock()
-	c.cache = make(map[string][]byte)
-	c.mu.Unlock()
+	if cc, ok := c.cache.(cacheClearer); ok {
+		cc.Clear()
+	}
 }
 
-// CacheSize returns the number of cache entries.
+// CacheSize returns the number of cache entries. It returns 0 for a Cache
+// that does not implement Len() int.
 func (c *Client) CacheSize() int {
 	if !c.cacheEnabled {
 		return 0
 	}
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return len(c.cache)
+	if cl, ok := c.cache.(cacheLener); ok {
+		return cl.Len()
+	}
+	return 0
+}
+
+// CacheStats returns the cache counters. It returns zero counters when the
+// cache is disabled or does not implement Stats() CacheStats.
+func (c *Client) CacheStats() CacheStats {
+	if !c.cacheEnabled {
+		return CacheStats{}
+	}
+	if cs, ok := c.cache.(cacheStatser); ok {
+		return cs.Stats()
+	}
+	return CacheStats{}
 }
@@ -368,9 +368,9 @@ func TestClient_DoRequest_ConcurrentAccess(t *testing.T) {
 	}
 	wg.Wait()
 
-	// With singleflight, concurrent requests for the same key result in only 1 API call
+	// With request deduplication, concurrent requests for the same key result in only 1 API call
 	if atomic.LoadInt64(&callCount) != 1 {
-		t.Errorf("expected 1 call (singleflight), got %d", callCount)
+		t.Errorf("expected 1 call (deduplicated), got %d", callCount)
 	}
 	if c.CacheSize() != 1 {
 		t.Errorf("expected cache size 1, got %d", c.CacheSize())
@@ -387,6 +387,7 @@ func TestClient_DoRequest_ContextCancellation(t *testing.T) {
 		_ = json.NewEncoder(w).Encode(map[string]string{"message": "hello"})
 	}))
 	defer server.Close()
+	defer close(release)
 
 	c := NewClient("test-api-key", WithCache())
 	c.baseURL = server.URL
@@ -395,7 +396,8 @@ func TestClient_DoRequest_ContextCancellation(t *testing.T) {
 		Message string `json:"message"`
 	}
 
-	// Start a request and cancel its context while the request is in flight
+	// Start two requests for the same key and cancel the first while the
+	// shared request is in flight
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	errCh := make(chan error, 1)
@@ -403,15 +405,19 @@ func TestClient_DoRequest_ContextCancellation(t *testing.T) {
 		var resp response
 		errCh <- c.DoRequest(ctx, http.MethodGet, "/test", nil, &resp)
 	}()
+	waitForCalls(t, &callCount, 1)
 
-	// Wait until the request reaches the server
-	deadline := time.Now().Add(5 * time.Second)
-	for atomic.LoadInt64(&callCount) == 0 {
-		if time.Now().After(deadline) {
-			t.Fatal("request did not reach the server")
-		}
-		time.Sleep(time.Millisecond)
+	type result struct {
+		resp response
+		err  error
 	}
+	resCh := make(chan result, 1)
+	go func() {
+		var res result
+		res.err = c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &res.resp)
+		resCh <- res
+	}()
+	waitForWaiters(t, c, "/test", 2)
 	cancel()
 
 	// The canceled caller returns immediately with the context error
@@ -424,25 +430,96 @@ func TestClient_DoRequest_ContextCancellation(t *testing.T) {
 		t.Fatal("canceled caller did not return")
 	}
 
-	// The detached flight keeps running and populates the cache
-	close(release)
-	deadline = time.Now().Add(5 * time.Second)
-	for c.CacheSize() == 0 {
-		if time.Now().After(deadline) {
-			t.Fatal("flight did not populate the cache after cancellation")
+	// The flight keeps running for the remaining waiter
+	release <- struct{}{}
+	select {
+	case res := <-resCh:
+		if res.err != nil {
+			t.Fatalf("unexpected error: %v", res.err)
 		}
-		time.Sleep(time.Millisecond)
+		if res.resp.Message != "hello" {
+			t.Errorf("expected message 'hello', got '%s'", res.resp.Message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("remaining waiter did not return")
 	}
+	if got := atomic.LoadInt64(&callCount); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
 
-	// A subsequent request is served from the cache without a new API call
-	var resp response
-	if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &resp); err != nil {
-		t.Fatalf("unexpected error: %v", err)
+func TestClient_DoRequest_CancelLastWaiter(t *testing.T) {
+	var callCount int64
+	canceled := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		<-r.Context().Done()
+		canceled <- struct{}{}
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithCache(), WithRetry(fastRetryPolicy(5)))
+	c.baseURL = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		var resp map[string]string
+		errCh <- c.DoRequest(ctx, http.MethodGet, "/test", nil, &resp)
+	}()
+	waitForCalls(t, &callCount, 1)
+	cancel()
+
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
 	}
-	if resp.Message != "hello" {
-		t.Errorf("expected message 'hello', got '%s'", resp.Message)
+
+	// Once the last waiter is gone the shared request is canceled and not retried
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("flight was not canceled after the last waiter left")
 	}
+	time.Sleep(50 * time.Millisecond)
 	if got := atomic.LoadInt64(&callCount); got != 1 {
 		t.Errorf("expected 1 call, got %d", got)
 	}
+	if c.CacheSize() != 0 {
+		t.Error("canceled flight should not populate the cache")
+	}
+}
+
+// waitForCalls waits until the server has received n requests.
+func waitForCalls(t *testing.T, count *int64, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt64(count) < n {
+		if time.Now().After(deadline) {
+			t.Fatal("request did not reach the server")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForWaiters waits until n callers are waiting on the flight for path.
+func waitForWaiters(t *testing.T, c *Client, path string, n int) {
+	t.Helper()
+	key := cacheKey(http.MethodGet, path)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.flights.mu.Lock()
+		f := c.flights.flights[key]
+		got := 0
+		if f != nil {
+			got = f.waiters
+		}
+		c.flights.mu.Unlock()
+		if got >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d waiters, got %d", n, got)
+		}
+		time.Sleep(time.Millisecond)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // APIError is returned when the J-Quants API responds with a status other than
@@ -23,6 +24,12 @@ type APIError struct {
 	// Body is the raw response body. The API returns a JSON object such as
 	// {"message": "..."} for most errors, but this is not guaranteed.
 	Body string
+	// RetryAfter is the wait requested by the Retry-After response header, or
+	// zero when the header is absent.
+	RetryAfter time.Duration
+	// Attempts is the number of requests made before giving up. It is greater
+	// than 1 only when retries are enabled with WithRetry.
+	Attempts int
 }
 
 // Error implements the error interface. The format is fixed for backward
@@ -194,7 +194,7 @@ func TestClient_DoRequest_APIErrorWithCache(t *testing.T) {
 	c := NewClient("test-api-key", WithCache())
 	c.baseURL = server.URL
 
-	// キャッシュ有効時（singleflight経由）でも同じ型が返る
+	// キャッシュ有効時（重複排除経由）でも同じ型が返る
 	var resp struct{ Message string }
 	err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &resp)
 	if !IsAuthError(err) {
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxErrorBodySize limits how much of an error response from a download URL is
+// kept in APIError.Body. Object storage returns short XML documents for errors.
+const maxErrorBodySize = 4 << 10
+
+// URLFetcher is implemented by clients that can download an absolute URL
+// outside the API, such as the signed URL of a bulk file.
+type URLFetcher interface {
+	FetchURL(ctx context.Context, rawURL string, offset int64) (io.ReadCloser, error)
+}
+
+// FetchURL downloads rawURL using c when it implements URLFetcher, falling back
+// to http.DefaultClient otherwise. A positive offset requests the content
+// starting at that byte, so that an interrupted download can be resumed; if the
+// server ignores the range, the skipped bytes are discarded instead.
+//
+// Responses other than 200 and 206 are returned as *APIError. The caller must
+// close the returned body. The body returned by *Client and by the fallback is a
+// *URLBody, which also carries the response's content type and length.
+func FetchURL(ctx context.Context, c HTTPClient, rawURL string, offset int64) (io.ReadCloser, error) {
+	if f, ok := c.(URLFetcher); ok {
+		return f.FetchURL(ctx, rawURL, offset)
+	}
+	return fetchURL(ctx, http.DefaultClient, rawURL, offset)
+}
+
+// FetchURL implements URLFetcher. The request goes through the client's
+// transport but without the API key and without the request timeout, since a
+// download may take longer than any API call; cancel ctx to abort it. The rate
+// limiter and retry policy do not apply.
+func (c *Client) FetchURL(ctx context.Context, rawURL string, offset int64) (io.ReadCloser, error) {
+	hc := &http.Client{
+		Transport:     c.httpClient.Transport,
+		CheckRedirect: c.httpClient.CheckRedirect,
+		Jar:           c.httpClient.Jar,
+	}
+	return fetchURL(ctx, hc, rawURL, offset)
+}
+
+// URLBody is the body of a downloaded URL together with the response headers
+// needed to verify it.
+type URLBody struct {
+	io.ReadCloser
+
+	// ContentType is the Content-Type header of the response.
+	ContentType string
+
+	// ContentLength is the number of bytes remaining to be read from the body,
+	// that is the length of the content after offset, or -1 if unknown.
+	ContentLength int64
+}
+
+func fetchURL(ctx context.Context, hc *http.Client, rawURL string, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, &sendError{err: err}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		return newURLBody(resp, resp.ContentLength), nil
+	case resp.StatusCode == http.StatusOK:
+		length := resp.ContentLength
+		if offset > 0 {
+			if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+				_ = resp.Body.Close()
+				return nil, fmt.Errorf("failed to skip to offset %d: %w", offset, err)
+			}
+			if length >= 0 {
+				length -= offset
+			}
+		}
+		return newURLBody(resp, length), nil
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return nil, &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Attempts:   1,
+	}
+}
+
+func newURLBody(resp *http.Response, length int64) *URLBody {
+	return &URLBody{
+		ReadCloser:    resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: length,
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchURL_URLBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A server that ignores Range.
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = io.WriteString(w, "0123456789")
+	}))
+	defer server.Close()
+
+	rc, err := FetchURL(context.Background(), NewMockClient(), server.URL, 4)
+	if err != nil {
+		t.Fatalf("FetchURL() error = %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	body, ok := rc.(*URLBody)
+	if !ok {
+		t.Fatalf("FetchURL() returned %T, want *URLBody", rc)
+	}
+	if body.ContentType != "application/pdf" || body.ContentLength != 6 {
+		t.Errorf("ContentType = %q, ContentLength = %d", body.ContentType, body.ContentLength)
+	}
+	if b, _ := io.ReadAll(body); string(b) != "456789" {
+		t.Errorf("body = %q, want 456789", b)
+	}
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileCacheExt is the extension of cache entry files.
+const fileCacheExt = ".cache"
+
+// FileCache is a Cache that stores responses as files in a directory, so that
+// cached data survives process restarts.
+//
+// Each entry is a file named by the SHA-256 of the normalized request key. The
+// file starts with an 8-byte expiry (Unix nanoseconds, 0 for no expiration)
+// followed by the raw response body. When a size cap is set, the least
+// recently used files are evicted once the total size exceeds it.
+type FileCache struct {
+	dir      string
+	maxBytes int64
+
+	mu   sync.Mutex
+	size int64 // total size of entry files
+	now  func() time.Time
+}
+
+// FileCacheOption configures a FileCache.
+type FileCacheOption func(*FileCache)
+
+// WithFileCacheMaxBytes caps the total size of the cache files. Zero (the
+// default) means no cap.
+func WithFileCacheMaxBytes(n int64) FileCacheOption {
+	return func(f *FileCache) {
+		f.maxBytes = n
+	}
+}
+
+// NewFileCache creates a FileCache in dir, creating the directory if needed.
+// Entries left by a previous process are reused.
+func NewFileCache(dir string, opts ...FileCacheOption) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	f := &FileCache{
+		dir: dir,
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	entries, err := f.entries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	for _, e := range entries {
+		f.size += e.size
+	}
+	return f, nil
+}
+
+// Get implements Cache. A hit refreshes the entry's position for eviction.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	name := f.path(key)
+	raw, err := os.ReadFile(name)
+	if err != nil || len(raw) < 8 {
+		return nil, false
+	}
+
+	now := f.now()
+	if exp := int64(binary.BigEndian.Uint64(raw[:8])); exp != 0 && now.UnixNano() >= exp {
+		f.Delete(key)
+		return nil, false
+	}
+	_ = os.Chtimes(name, now, now)
+	return raw[8:], true
+}
+
+// Set implements Cache. The file is written to a temporary file and renamed
+// into place so that readers never see a partial entry.
+func (f *FileCache) Set(key string, data []byte, ttl time.Duration) {
+	var exp int64
+	if ttl > 0 {
+		exp = f.now().Add(ttl).UnixNano()
+	}
+	buf := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(buf[:8], uint64(exp))
+	copy(buf[8:], data)
+
+	if f.maxBytes > 0 && int64(len(buf)) > f.maxBytes {
+		return
+	}
+
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(buf); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := f.path(key)
+	var old int64
+	if info, err := os.Stat(name); err == nil {
+		old = info.Size()
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	now := f.now()
+	_ = os.Chtimes(name, now, now)
+	f.size += int64(len(buf)) - old
+	f.evict(name)
+}
+
+// Delete implements Cache.
+func (f *FileCache) Delete(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.remove(f.path(key))
+}
+
+// Clear removes all entries.
+func (f *FileCache) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.entries()
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		f.remove(e.path)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (f *FileCache) Len() int {
+	entries, err := f.entries()
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+// Size returns the total size of the cache files in bytes.
+func (f *FileCache) Size() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.size
+}
+
+// path returns the file path of the entry for key.
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// remove deletes an entry file and updates the total size. The caller must
+// hold f.mu.
+func (f *FileCache) remove(name string) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(name); err == nil {
+		f.size -= info.Size()
+	}
+}
+
+// evict removes the least recently used entries until the total size fits in
+// maxBytes. keep is the entry just written, which is evicted last. The caller
+// must hold f.mu.
+func (f *FileCache) evict(keep string) {
+	if f.maxBytes <= 0 || f.size <= f.maxBytes {
+		return
+	}
+	entries, err := f.entries()
+	if err != nil {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if (entries[i].path == keep) != (entries[j].path == keep) {
+			return entries[j].path == keep
+		}
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, e := range entries {
+		if f.size <= f.maxBytes {
+			return
+		}
+		f.remove(e.path)
+	}
+}
+
+type fileCacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// entries lists the entry files in the cache directory.
+func (f *FileCache) entries() ([]fileCacheEntry, error) {
+	dirEntries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []fileCacheEntry
+	for _, de := range dirEntries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), fileCacheExt) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		entries = append(entries, fileCacheEntry{
+			path:    filepath.Join(f.dir, de.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	return entries, nil
+}
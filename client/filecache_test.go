@@ -0,0 +1,138 @@
+package client
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestFileCache(t *testing.T, now *time.Time, opts ...FileCacheOption) *FileCache {
+	t.Helper()
+	f, err := NewFileCache(t.TempDir(), opts...)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+func TestFileCache_GetSetDelete(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := newTestFileCache(t, &now)
+
+	if _, ok := f.Get("GET:/test"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	f.Set("GET:/test", []byte(`{"message":"hello"}`), NoExpiration)
+	got, ok := f.Get("GET:/test")
+	if !ok || string(got) != `{"message":"hello"}` {
+		t.Fatalf("Get() = (%q, %v), want stored data", got, ok)
+	}
+	if f.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", f.Len())
+	}
+
+	// 上書きしてもエントリ数とサイズが正しく保たれる
+	f.Set("GET:/test", []byte(`{}`), NoExpiration)
+	if f.Size() != 8+2 {
+		t.Errorf("Size() = %d, want 10", f.Size())
+	}
+
+	f.Delete("GET:/test")
+	if _, ok := f.Get("GET:/test"); ok {
+		t.Error("expected miss after Delete")
+	}
+	if f.Len() != 0 || f.Size() != 0 {
+		t.Errorf("Len() = %d, Size() = %d after Delete, want 0, 0", f.Len(), f.Size())
+	}
+}
+
+func TestFileCache_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := newTestFileCache(t, &now)
+
+	f.Set("short", []byte("a"), time.Minute)
+	f.Set("forever", []byte("b"), NoExpiration)
+
+	now = now.Add(59 * time.Second)
+	if _, ok := f.Get("short"); !ok {
+		t.Error("expected hit before expiry")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := f.Get("short"); ok {
+		t.Error("expected miss after expiry")
+	}
+	if _, ok := f.Get("forever"); !ok {
+		t.Error("expected entry without expiry to remain")
+	}
+	if f.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after expired entry was removed", f.Len())
+	}
+}
+
+func TestFileCache_EvictLeastRecentlyUsed(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 1エントリは 8バイトのヘッダ + 10バイト = 18バイト。2エントリまで収まる
+	f := newTestFileCache(t, &now, WithFileCacheMaxBytes(40))
+	data := []byte("0123456789")
+
+	f.Set("a", data, NoExpiration)
+	now = now.Add(time.Second)
+	f.Set("b", data, NoExpiration)
+	now = now.Add(time.Second)
+
+	// aを参照して最近使用したことにする
+	if _, ok := f.Get("a"); !ok {
+		t.Fatal("expected hit for a")
+	}
+	now = now.Add(time.Second)
+
+	f.Set("c", data, NoExpiration)
+
+	if _, ok := f.Get("b"); ok {
+		t.Error("expected least recently used entry b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := f.Get(key); !ok {
+			t.Errorf("expected %s to remain", key)
+		}
+	}
+	if f.Size() > 40 {
+		t.Errorf("Size() = %d, want <= 40", f.Size())
+	}
+}
+
+func TestFileCache_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	f1, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	f1.Set("key", []byte("value"), NoExpiration)
+
+	// 一時ファイルなどキャッシュ以外のファイルは無視される
+	if err := os.WriteFile(dir+"/README", []byte("not a cache entry"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f2, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	if got, ok := f2.Get("key"); !ok || string(got) != "value" {
+		t.Errorf("Get() = (%q, %v), want (value, true)", got, ok)
+	}
+	if f2.Size() != f1.Size() {
+		t.Errorf("Size() = %d after reopen, want %d", f2.Size(), f1.Size())
+	}
+
+	f2.Clear()
+	if f2.Len() != 0 {
+		t.Errorf("Len() = %d after Clear, want 0", f2.Len())
+	}
+	if _, err := os.Stat(dir + "/README"); err != nil {
+		t.Errorf("Clear removed a non-cache file: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent cached requests for the same key.
+// Unlike singleflight, a flight is canceled once every caller waiting on it
+// has given up, so retries do not outlive their callers.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request shared by one or more waiting callers.
+type flight struct {
+	done    chan struct{}
+	data    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// join returns the in-flight request for key, starting fn in a new flight if
+// there is none. The flight runs with a context detached from ctx so that one
+// caller canceling does not fail it for the others. Callers that stop waiting
+// before the flight is done must call leave.
+func (g *flightGroup) join(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) *flight {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if f, ok := g.flights[key]; ok {
+		f.waiters++
+		return f
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	f := &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+	g.flights[key] = f
+	go func() {
+		defer cancel()
+		data, err := fn(flightCtx)
+		g.mu.Lock()
+		g.forget(key, f)
+		g.mu.Unlock()
+		f.data, f.err = data, err
+		close(f.done)
+	}()
+	return f
+}
+
+// leave removes a waiter from f. The flight is canceled when no waiters
+// remain; a later request for the same key starts a new flight.
+func (g *flightGroup) leave(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f.waiters--
+	if f.waiters == 0 {
+		f.cancel()
+		g.forget(key, f)
+	}
+}
+
+// forget removes f from the group if it is still the flight for key.
+// The caller must hold g.mu.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-memory Cache with least recently used eviction.
+//
+// Without options it keeps every entry, which matches the session cache of
+// WithCache. Set limits with the MemoryCacheOption functions to run it in a
+// long-lived process. A MemoryCache is safe for concurrent use.
+type MemoryCache struct {
+	maxBytes   int64
+	maxEntries int
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List // front is the most recently used
+	entries map[string]*list.Element
+	size    int64
+	stats   CacheStats
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	data    []byte
+	expires time.Time // zero for no expiration
+}
+
+// MemoryCacheOption configures a MemoryCache.
+type MemoryCacheOption func(*MemoryCache)
+
+// WithMaxBytes caps the total size of the cached response bodies. Zero (the
+// default) means no cap.
+func WithMaxBytes(n int64) MemoryCacheOption {
+	return func(m *MemoryCache) {
+		m.maxBytes = n
+	}
+}
+
+// WithMaxEntries caps the number of cached responses. Zero (the default) means
+// no cap.
+func WithMaxEntries(n int) MemoryCacheOption {
+	return func(m *MemoryCache) {
+		m.maxEntries = n
+	}
+}
+
+// WithEntryTTL expires every entry after d at the latest, even when the
+// client's CacheTTLFunc allows it to live longer. Zero (the default) means no
+// limit.
+func WithEntryTTL(d time.Duration) MemoryCacheOption {
+	return func(m *MemoryCache) {
+		m.ttl = d
+	}
+}
+
+// NewMemoryCache creates a MemoryCache.
+func NewMemoryCache(opts ...MemoryCacheOption) *MemoryCache {
+	m := &MemoryCache{
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.lookup(key)
+	if ok {
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+	return data, ok
+}
+
+// peek is Get without updating the counters. The client uses it to re-check
+// the cache inside a shared flight so that one lookup is counted once.
+func (m *MemoryCache) peek(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lookup(key)
+}
+
+// lookup returns a live entry and marks it as recently used. Expired entries
+// are removed. The caller must hold m.mu.
+func (m *MemoryCache) lookup(key string) ([]byte, bool) {
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*memoryEntry)
+	if !e.expires.IsZero() && !m.now().Before(e.expires) {
+		m.remove(el)
+		return nil, false
+	}
+	m.lru.MoveToFront(el)
+	return e.data, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, data []byte, ttl time.Duration) {
+	if m.maxBytes > 0 && int64(len(data)) > m.maxBytes {
+		return
+	}
+	if m.ttl > 0 && (ttl <= 0 || ttl > m.ttl) {
+		ttl = m.ttl
+	}
+	e := &memoryEntry{key: key, data: data}
+	if ttl > 0 {
+		e.expires = m.now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	m.entries[key] = m.lru.PushFront(e)
+	m.size += int64(len(data))
+
+	for (m.maxBytes > 0 && m.size > m.maxBytes) || (m.maxEntries > 0 && m.lru.Len() > m.maxEntries) {
+		m.remove(m.lru.Back())
+		m.stats.Evictions++
+	}
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+}
+
+// Clear removes all entries. The counters are kept.
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lru.Init()
+	m.entries = make(map[string]*list.Element)
+	m.size = 0
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Size returns the total size of the cached response bodies in bytes.
+func (m *MemoryCache) Size() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.size
+}
+
+// Stats returns the hit, miss and eviction counters.
+func (m *MemoryCache) Stats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// remove deletes an entry. The caller must hold m.mu.
+func (m *MemoryCache) remove(el *list.Element) {
+	e := m.lru.Remove(el).(*memoryEntry)
+	delete(m.entries, e.key)
+	m.size -= int64(len(e.data))
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryCache()
+	m.now = func() time.Time { return now }
+
+	m.Set("forever", []byte("a"), NoExpiration)
+	m.Set("short", []byte("b"), time.Minute)
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get("short"); ok {
+		t.Error("expected expired entry to be missing")
+	}
+	if got, ok := m.Get("forever"); !ok || string(got) != "a" {
+		t.Errorf("Get(forever) = (%q, %v), want (a, true)", got, ok)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after expired entry was removed", m.Len())
+	}
+}
+
+func TestMemoryCache_EntryTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryCache(WithEntryTTL(time.Hour))
+	m.now = func() time.Time { return now }
+
+	m.Set("forever", []byte("a"), NoExpiration) // 上限の1時間で失効する
+	m.Set("short", []byte("b"), time.Minute)    // 短いTTLはそのまま使われる
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get("short"); ok {
+		t.Error("expected short entry to expire after its own TTL")
+	}
+	if _, ok := m.Get("forever"); !ok {
+		t.Error("expected entry to remain before the entry TTL")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := m.Get("forever"); ok {
+		t.Error("expected entry to expire after the entry TTL")
+	}
+}
+
+func TestMemoryCache_EvictByEntries(t *testing.T) {
+	m := NewMemoryCache(WithMaxEntries(2))
+
+	m.Set("a", []byte("1"), NoExpiration)
+	m.Set("b", []byte("2"), NoExpiration)
+	m.Get("a") // aを最近使用したことにする
+	m.Set("c", []byte("3"), NoExpiration)
+
+	if _, ok := m.Get("b"); ok {
+		t.Error("expected least recently used entry b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := m.Get(key); !ok {
+			t.Errorf("expected %s to remain", key)
+		}
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+	if got := m.Stats().Evictions; got != 1 {
+		t.Errorf("Evictions = %d, want 1", got)
+	}
+}
+
+func TestMemoryCache_EvictByBytes(t *testing.T) {
+	m := NewMemoryCache(WithMaxBytes(10))
+
+	m.Set("a", []byte("12345"), NoExpiration)
+	m.Set("b", []byte("12345"), NoExpiration)
+	if m.Size() != 10 {
+		t.Fatalf("Size() = %d, want 10", m.Size())
+	}
+
+	// 上書きでサイズが増えた分、古いエントリが追い出される
+	m.Set("b", []byte("1234567"), NoExpiration)
+	if _, ok := m.Get("a"); ok {
+		t.Error("expected a to be evicted")
+	}
+	if m.Size() != 7 {
+		t.Errorf("Size() = %d, want 7", m.Size())
+	}
+
+	// 上限を超える単一エントリは保存しない
+	m.Set("huge", make([]byte, 11), NoExpiration)
+	if _, ok := m.Get("huge"); ok {
+		t.Error("expected oversized entry not to be stored")
+	}
+	if _, ok := m.Get("b"); !ok {
+		t.Error("expected b to remain when an oversized entry is rejected")
+	}
+}
+
+func TestMemoryCache_Stats(t *testing.T) {
+	m := NewMemoryCache()
+	m.Get("a")
+	m.Set("a", []byte("1"), NoExpiration)
+	m.Get("a")
+	m.Get("a")
+
+	want := CacheStats{Hits: 2, Misses: 1}
+	if got := m.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// Clearはカウンタを維持する
+	m.Clear()
+	if got := m.Stats(); got != want {
+		t.Errorf("Stats() after Clear = %+v, want %+v", got, want)
+	}
+	if m.Len() != 0 || m.Size() != 0 {
+		t.Errorf("Len() = %d, Size() = %d after Clear, want 0, 0", m.Len(), m.Size())
+	}
+}
+
+func TestClient_CacheStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"path": r.URL.Path})
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithCache(WithMaxEntries(1)))
+	c.baseURL = server.URL
+
+	for _, path := range []string{"/a", "/a", "/b", "/a"} {
+		if err := c.DoRequest(context.Background(), http.MethodGet, path, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// /a: miss, hit / /b: miss（/aを追い出す） / /a: miss（/bを追い出す）
+	// 共有リクエスト内の再確認は数えない
+	want := CacheStats{Hits: 1, Misses: 3, Evictions: 2}
+	if got := c.CacheStats(); got != want {
+		t.Errorf("CacheStats() = %+v, want %+v", got, want)
+	}
+	if c.CacheSize() != 1 {
+		t.Errorf("CacheSize() = %d, want 1", c.CacheSize())
+	}
+}
+
+func TestClient_CacheStats_Disabled(t *testing.T) {
+	c := NewClient("test-api-key")
+	if got := c.CacheStats(); got != (CacheStats{}) {
+		t.Errorf("CacheStats() = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Rate limits (requests per minute) of each J-Quants API plan, for use as
+// reqPerMinute in WithRateLimit and NewRateLimiter.
+const (
+	RateLimitFree     = 5   // Free plan
+	RateLimitLight    = 60  // Light plan
+	RateLimitStandard = 120 // Standard plan
+	RateLimitPremium  = 500 // Premium plan
+)
+
+// RateLimiter is a token bucket that paces requests to a fixed rate.
+//
+// Tokens are handed out in the order Wait is called, so goroutines sharing a
+// limiter are served fairly (first come, first served) regardless of how many
+// are waiting. A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // time to refill one token
+	burst    float64
+	tokens   float64 // negative while callers are queued for future tokens
+	last     time.Time
+	now      func() time.Time
+}
+
+// NewRateLimiter creates a limiter that allows reqPerMinute requests per minute
+// on average and up to burst requests at once. reqPerMinute and burst values
+// less than 1 are treated as 1. The bucket starts full.
+func NewRateLimiter(reqPerMinute, burst int) *RateLimiter {
+	if reqPerMinute < 1 {
+		reqPerMinute = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: time.Minute / time.Duration(reqPerMinute),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. A caller that gives
+// up because ctx is done returns its reserved token to the bucket.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes one token, possibly from the future, and returns how long the
+// caller must wait before using it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// cancel returns a token reserved by a caller that stopped waiting.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// refill adds the tokens accumulated since the last update. The caller must
+// hold l.mu.
+func (l *RateLimiter) refill() {
+	now := l.now()
+	if !l.last.IsZero() {
+		if elapsed := now.Sub(l.last); elapsed > 0 {
+			l.tokens += float64(elapsed) / float64(l.interval)
+			if l.tokens > l.burst {
+				l.tokens = l.burst
+			}
+		}
+	}
+	l.last = now
+}
+
+// WithRateLimit paces every API request made by the client with a token bucket
+// of reqPerMinute requests per minute and the given burst size. Use the
+// RateLimit* constants for the contracted plan's limit:
+//
+//	client.NewClient(apiKey, client.WithRateLimit(client.RateLimitLight, 1))
+//
+// The limit applies to DoRequest and DoRequestNoCache across all services
+// sharing the client, including each retry attempt. Cache hits do not consume
+// tokens. A reqPerMinute of zero or less disables the limit.
+func WithRateLimit(reqPerMinute, burst int) ClientOption {
+	return func(c *Client) {
+		if reqPerMinute <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = NewRateLimiter(reqPerMinute, burst)
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRateLimiter は時刻を固定したRateLimiterを作成する
+func newTestRateLimiter(reqPerMinute, burst int, now *time.Time) *RateLimiter {
+	l := NewRateLimiter(reqPerMinute, burst)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	l := newTestRateLimiter(60, 2, &now) // 1秒に1トークン、バースト2
+
+	// バースト分は待たずに通る
+	for i := 0; i < 2; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("reserve() #%d = %v, want 0", i+1, d)
+		}
+	}
+	// 以降は到着順に1秒ずつ後ろへ並ぶ
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("reserve() #3 = %v, want 1s", d)
+	}
+	if d := l.reserve(); d != 2*time.Second {
+		t.Errorf("reserve() #4 = %v, want 2s", d)
+	}
+
+	// 時間が経過すると補充される（上限はバースト）
+	now = now.Add(time.Minute)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", d)
+	}
+	if d := l.reserve(); d != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", d)
+	}
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("reserve() beyond burst = %v, want 1s", d)
+	}
+}
+
+func TestNewRateLimiter_InvalidRate(t *testing.T) {
+	// 0以下は1分に1リクエストとして扱う（ゼロ除算や負の間隔にならない）
+	for _, rpm := range []int{0, -10} {
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		l := newTestRateLimiter(rpm, 1, &now)
+		if d := l.reserve(); d != 0 {
+			t.Errorf("NewRateLimiter(%d): reserve() #1 = %v, want 0", rpm, d)
+		}
+		if d := l.reserve(); d != time.Minute {
+			t.Errorf("NewRateLimiter(%d): reserve() #2 = %v, want 1m", rpm, d)
+		}
+	}
+}
+
+func TestRateLimiter_Wait_ContextCanceled(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	l := newTestRateLimiter(1, 1, &now) // 1分に1トークン
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Wait did not return on context cancellation (took %v)", elapsed)
+	}
+
+	// 諦めた呼び出し元の予約は返却され、次の呼び出し元が先頭になる
+	if d := l.reserve(); d != time.Minute {
+		t.Errorf("reserve() after cancel = %v, want 1m", d)
+	}
+}
+
+func TestRateLimiter_Wait_Fairness(t *testing.T) {
+	l := NewRateLimiter(6000, 1) // 10msに1トークン
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		// 予約順を確定させるため、前の呼び出し元が予約してから次を開始する
+		reserved := make(chan struct{})
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			d := l.reserve()
+			close(reserved)
+			time.Sleep(d)
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+		}(i)
+		<-reserved
+	}
+	wg.Wait()
+
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("served order = %v, want ascending", order)
+		}
+	}
+}
+
+func TestClient_DoRequest_RateLimit(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithRateLimit(1200, 1)) // 50msに1リクエスト
+	c.baseURL = server.URL
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 1件目は即時、残り4件は50ms間隔
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("5 requests finished in %v, want at least 200ms", elapsed)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 5 {
+		t.Errorf("expected 5 calls, got %d", got)
+	}
+}
+
+func TestClient_DoRequest_RateLimitSkipsCacheHit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithCache(), WithRateLimit(1, 1))
+	c.baseURL = server.URL
+
+	if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// キャッシュヒットはトークンを消費しないため待たされない
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.DoRequest(ctx, http.MethodGet, "/test", nil, nil); err != nil {
+		t.Fatalf("cache hit was rate limited: %v", err)
+	}
+}
+
+func TestWithRateLimit_Disabled(t *testing.T) {
+	c := NewClient("test-api-key", WithRateLimit(0, 1))
+	if c.limiter != nil {
+		t.Error("expected limiter to be nil for reqPerMinute <= 0")
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of transient failures.
+//
+// A request is retried when the API responds with 429 or 5xx, or when the
+// request fails before a response is received (connection reset, timeout and
+// so on). Authentication errors (401, 403) and other 4xx responses are never
+// retried. The wait between attempts grows exponentially with full jitter and
+// is overridden by the Retry-After header when the API sends one.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values less than 1 are treated as 1 (no retry).
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including waits requested by
+	// Retry-After. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier is the growth factor of the backoff per attempt. Values less
+	// than 1 are treated as 2.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy returns a policy suited to batch jobs: up to 5 attempts,
+// starting at 1 second and capped at 5 minutes so that a 429 lockout (about
+// five minutes) can be waited out.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+		Multiplier:     2,
+	}
+}
+
+// WithRetry enables automatic retries with the given policy.
+// Retries apply to DoRequest and DoRequestNoCache, including requests shared
+// through the cache's request deduplication.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		p := policy
+		c.retry = &p
+	}
+}
+
+// maxAttempts returns the effective number of attempts.
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the wait before the given retry (1-based) with full jitter.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
+
+// wait returns the wait before the given retry (1-based), preferring the
+// server's Retry-After over the computed backoff.
+func (p *RetryPolicy) wait(retry int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxBackoff > 0 && apiErr.RetryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return apiErr.RetryAfter
+	}
+	return p.backoff(retry)
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated.
+// ctx is the context of the attempt: errors caused by its cancellation are not
+// retried.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if _, ok := StatusCode(err); ok {
+		return IsRateLimitExceeded(err) || IsServerError(err)
+	}
+	var sendErr *sendError
+	return errors.As(err, &sendErr)
+}
+
+// sendError marks a transport failure that happened before a response was
+// received. It keeps the historical "failed to send request" message.
+type sendError struct {
+	err error
+}
+
+func (e *sendError) Error() string { return "failed to send request: " + e.err.Error() }
+
+func (e *sendError) Unwrap() error { return e.err }
+
+// parseRetryAfter parses a Retry-After header value, which is either a number
+// of seconds or an HTTP date. It returns 0 when the value is absent or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy はテスト用に待機時間を短くしたリトライポリシー
+func fastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestClient_DoRequest_RetryTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var callCount int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt64(&callCount, 1) < 3 {
+					w.WriteHeader(status)
+					return
+				}
+				_, _ = w.Write([]byte(`{"message":"hello"}`))
+			}))
+			defer server.Close()
+
+			c := NewClient("test-api-key", WithRetry(fastRetryPolicy(5)))
+			c.baseURL = server.URL
+
+			var resp struct{ Message string }
+			if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Message != "hello" {
+				t.Errorf("Message = %q, want %q", resp.Message, "hello")
+			}
+			if got := atomic.LoadInt64(&callCount); got != 3 {
+				t.Errorf("expected 3 calls, got %d", got)
+			}
+		})
+	}
+}
+
+func TestClient_DoRequest_RetryExhausted(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithRetry(fastRetryPolicy(3)))
+	c.baseURL = server.URL
+
+	err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want 502", apiErr.StatusCode)
+	}
+	// 最終エラーには試行回数が記録される
+	if apiErr.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", apiErr.Attempts)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestClient_DoRequest_NoRetryForNonTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, 210} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var callCount int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt64(&callCount, 1)
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			c := NewClient("test-api-key", WithRetry(fastRetryPolicy(5)))
+			c.baseURL = server.URL
+
+			err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, nil)
+			if code, ok := StatusCode(err); !ok || code != status {
+				t.Fatalf("StatusCode() = (%d, %v), want (%d, true)", code, ok, status)
+			}
+			if got := atomic.LoadInt64(&callCount); got != 1 {
+				t.Errorf("expected 1 call (not retried), got %d", got)
+			}
+		})
+	}
+}
+
+func TestClient_DoRequest_RetryTransportError(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&callCount, 1) == 1 {
+			// レスポンスを返さずに接続を切断する
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"hello"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithRetry(fastRetryPolicy(3)))
+	c.baseURL = server.URL
+
+	var resp struct{ Message string }
+	if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestClient_DoRequest_RetryWithCache(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"hello"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-api-key", WithCache(), WithRetry(fastRetryPolicy(3)))
+	c.baseURL = server.URL
+
+	// 重複排除された同時リクエストでもリトライ後の結果が共有される
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resp struct{ Message string }
+			if err := c.DoRequest(context.Background(), http.MethodGet, "/test", nil, &resp); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if resp.Message != "hello" {
+				t.Errorf("Message = %q, want %q", resp.Message, "hello")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&callCount); got != 2 {
+		t.Errorf("expected 2 calls (1 failure + 1 retry), got %d", got)
+	}
+	if c.CacheSize() != 1 {
+		t.Errorf("expected cache size 1, got %d", c.CacheSize())
+	}
+}
+
+func TestClient_DoRequest_RetryStopsOnContextCancel(t *testing.T) {
+	var callCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&callCount, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	c := NewClient("test-api-key", WithRetry(policy))
+	c.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.DoRequest(ctx, http.MethodGet, "/test", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry did not stop on context cancellation (took %v)", elapsed)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	t.Run("retry-after takes precedence", func(t *testing.T) {
+		err := &APIError{StatusCode: 429, RetryAfter: 500 * time.Millisecond}
+		if got := p.wait(1, err); got != 500*time.Millisecond {
+			t.Errorf("wait() = %v, want 500ms", got)
+		}
+	})
+
+	t.Run("retry-after is capped by MaxBackoff", func(t *testing.T) {
+		err := &APIError{StatusCode: 429, RetryAfter: time.Minute}
+		if got := p.wait(1, err); got != time.Second {
+			t.Errorf("wait() = %v, want 1s", got)
+		}
+	})
+
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		err := &APIError{StatusCode: 503}
+		for retry, upper := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+			for i := 0; i < 100; i++ {
+				if got := p.wait(retry, err); got < 0 || got > upper {
+					t.Fatalf("wait(%d) = %v, want within [0, %v]", retry, got, upper)
+				}
+			}
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "http date", value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second},
+		{name: "past http date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "empty", value: "", want: 0},
+		{name: "negative", value: "-1", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package jquants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CursorStore は差分取得用のカーソルを保存するストアです。
+// プロセスを再起動しても前回の続きから取得できるよう、Watchなどの差分取得処理がカーソルの保存に使用します。
+// keyは取得対象ごとに呼び出し側が決める文字列です。
+type CursorStore interface {
+	// LoadCursor はkeyに対応するカーソルを返します。保存されていない場合は空文字を返します。
+	LoadCursor(ctx context.Context, key string) (string, error)
+	// SaveCursor はkeyに対応するカーソルを保存します。
+	SaveCursor(ctx context.Context, key, cursor string) error
+}
+
+// MemoryCursorStore はカーソルをメモリに保持するCursorStoreです。プロセスの終了とともに失われます。
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCursorStore は新しいMemoryCursorStoreを作成します。
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]string{}}
+}
+
+// LoadCursor はCursorStoreを実装します。
+func (s *MemoryCursorStore) LoadCursor(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[key], nil
+}
+
+// SaveCursor はCursorStoreを実装します。
+func (s *MemoryCursorStore) SaveCursor(_ context.Context, key, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[key] = cursor
+	return nil
+}
+
+// FileCursorStore はカーソルをJSONファイルに保存するCursorStoreです。
+// 保存のたびにファイル全体を一時ファイルから置き換えるため、書き込み中に中断してもファイルは壊れません。
+type FileCursorStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCursorStore はpathのファイルにカーソルを保存するFileCursorStoreを作成します。
+// ファイルは最初の保存時に作成されます。
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// LoadCursor はCursorStoreを実装します。
+func (s *FileCursorStore) LoadCursor(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return cursors[key], nil
+}
+
+// SaveCursor はCursorStoreを実装します。
+func (s *FileCursorStore) SaveCursor(_ context.Context, key, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[key] = cursor
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cursors: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create cursor directory: %w", err)
+		}
+	}
+	_, err = writeFileAtomic(s.path, func(w io.Writer) (int64, error) {
+		n, err := w.Write(append(data, '\n'))
+		return int64(n), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write cursors: %w", err)
+	}
+	return nil
+}
+
+func (s *FileCursorStore) read() (map[string]string, error) {
+	cursors := map[string]string{}
+	data, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read cursors: %w", err)
+	default:
+		if err := json.Unmarshal(data, &cursors); err != nil {
+			return nil, fmt.Errorf("failed to parse cursors: %w", err)
+		}
+	}
+	if cursors == nil {
+		cursors = map[string]string{}
+	}
+	return cursors, nil
+}
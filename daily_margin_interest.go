@@ -3,6 +3,7 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -21,11 +22,11 @@ func NewDailyMarginInterestService(c client.HTTPClient) *DailyMarginInterestServ
 
 // DailyMarginInterestParams は日々公表信用取引残高のリクエストパラメータです。
 type DailyMarginInterestParams struct {
-	Code          string // 銘柄コード（codeまたはdateのいずれかが必須）
-	Date          string // 公表日（YYYYMMDD または YYYY-MM-DD）（codeまたはdateのいずれかが必須）
-	From          string // 期間の開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 期間の終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（codeまたはdateのいずれかが必須）
+	Date          types.Date // 公表日（codeまたはdateのいずれかが必須）
+	From          types.Date // 期間の開始日
+	To            types.Date // 期間の終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // DailyMarginInterestResponse は日々公表信用取引残高のレスポンスです。
@@ -96,32 +97,23 @@ const (
 // GetDailyMarginInterest は日々公表信用取引残高を取得します。
 func (s *DailyMarginInterestService) GetDailyMarginInterest(ctx context.Context, params DailyMarginInterestParams) (*DailyMarginInterestResponse, error) {
 	// codeまたはdateのいずれかが必須
-	if params.Code == "" && params.Date == "" {
+	if params.Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either code or date parameter is required")
 	}
 
 	path := "/markets/margin-alert"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp DailyMarginInterestResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -131,89 +123,41 @@ func (s *DailyMarginInterestService) GetDailyMarginInterest(ctx context.Context,
 	return &resp, nil
 }
 
-// GetDailyMarginInterestByCode は指定銘柄の日々公表信用取引残高を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *DailyMarginInterestService) GetDailyMarginInterestByCode(ctx context.Context, code string) ([]DailyMarginInterest, error) {
-	var allData []DailyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := DailyMarginInterestParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllDailyMarginInterest は指定条件の日々公表信用取引残高を1件ずつ返すイテレータです。
+func (s *DailyMarginInterestService) AllDailyMarginInterest(ctx context.Context, params DailyMarginInterestParams) iter.Seq2[DailyMarginInterest, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]DailyMarginInterest, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetDailyMarginInterest(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetDailyMarginInterestByCode は指定銘柄の日々公表信用取引残高を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *DailyMarginInterestService) GetDailyMarginInterestByCode(ctx context.Context, code string) ([]DailyMarginInterest, error) {
+	return collectAll(s.AllDailyMarginInterest(ctx, DailyMarginInterestParams{
+		Code: code,
+	}))
 }
 
 // GetDailyMarginInterestByDate は指定日の全銘柄の日々公表信用取引残高を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *DailyMarginInterestService) GetDailyMarginInterestByDate(ctx context.Context, date string) ([]DailyMarginInterest, error) {
-	var allData []DailyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := DailyMarginInterestParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDailyMarginInterest(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *DailyMarginInterestService) GetDailyMarginInterestByDate(ctx context.Context, date types.Date) ([]DailyMarginInterest, error) {
+	return collectAll(s.AllDailyMarginInterest(ctx, DailyMarginInterestParams{
+		Date: date,
+	}))
 }
 
 // GetDailyMarginInterestByCodeAndDateRange は指定銘柄・期間の日々公表信用取引残高を取得します。
-func (s *DailyMarginInterestService) GetDailyMarginInterestByCodeAndDateRange(ctx context.Context, code, from, to string) ([]DailyMarginInterest, error) {
-	var allData []DailyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := DailyMarginInterestParams{
-			Code:          code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDailyMarginInterest(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *DailyMarginInterestService) GetDailyMarginInterestByCodeAndDateRange(ctx context.Context, code string, from, to types.Date) ([]DailyMarginInterest, error) {
+	return collectAll(s.AllDailyMarginInterest(ctx, DailyMarginInterestParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetShortOutChgValue は前日比売合計信用残高を数値で取得します。
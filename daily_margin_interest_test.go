@@ -20,8 +20,8 @@ func TestDailyMarginInterestService_GetDailyMarginInterest(t *testing.T) {
 			name: "with code and date range",
 			params: DailyMarginInterestParams{
 				Code: "13260",
-				From: "20240101",
-				To:   "20240331",
+				From: types.MustParseDate("20240101"),
+				To:   types.MustParseDate("20240331"),
 			},
 			wantPath: "/markets/margin-alert?code=13260&from=20240101&to=20240331",
 		},
@@ -35,7 +35,7 @@ func TestDailyMarginInterestService_GetDailyMarginInterest(t *testing.T) {
 		{
 			name: "with date only",
 			params: DailyMarginInterestParams{
-				Date: "20240208",
+				Date: types.MustParseDate("20240208"),
 			},
 			wantPath: "/markets/margin-alert?date=20240208",
 		},
@@ -43,14 +43,14 @@ func TestDailyMarginInterestService_GetDailyMarginInterest(t *testing.T) {
 			name: "with code and date",
 			params: DailyMarginInterestParams{
 				Code: "13260",
-				Date: "20240208",
+				Date: types.MustParseDate("20240208"),
 			},
 			wantPath: "/markets/margin-alert?code=13260&date=20240208",
 		},
 		{
 			name: "with pagination key",
 			params: DailyMarginInterestParams{
-				Date:          "20240208",
+				Date:          types.MustParseDate("20240208"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/markets/margin-alert?date=20240208&pagination_key=key123",
@@ -235,7 +235,7 @@ func TestDailyMarginInterestService_GetDailyMarginInterestByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/margin-alert?date=20240208", mockResponse)
 
 	// Execute
-	data, err := service.GetDailyMarginInterestByDate(context.Background(), "20240208")
+	data, err := service.GetDailyMarginInterestByDate(context.Background(), types.MustParseDate("20240208"))
 
 	// Verify
 	if err != nil {
@@ -279,7 +279,7 @@ func TestDailyMarginInterestService_GetDailyMarginInterestByCodeAndDateRange(t *
 	mockClient.SetResponse("GET", "/markets/margin-alert?code=13260&from=20240201&to=20240208", mockResponse)
 
 	// Execute
-	data, err := service.GetDailyMarginInterestByCodeAndDateRange(context.Background(), "13260", "20240201", "20240208")
+	data, err := service.GetDailyMarginInterestByCodeAndDateRange(context.Background(), "13260", types.MustParseDate("20240201"), types.MustParseDate("20240208"))
 
 	// Verify
 	if err != nil {
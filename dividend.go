@@ -3,6 +3,7 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -24,11 +25,11 @@ func NewDividendService(c client.HTTPClient) *DividendService {
 
 // DividendParams は配当情報のリクエストパラメータです。
 type DividendParams struct {
-	Code          string // 銘柄コード（codeまたはdateのいずれかが必須）
-	Date          string // 通知日付（YYYYMMDD または YYYY-MM-DD）（codeまたはdateのいずれかが必須）
-	From          string // 期間の開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 期間の終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（codeまたはdateのいずれかが必須）
+	Date          types.Date // 通知日付（codeまたはdateのいずれかが必須）
+	From          types.Date // 期間の開始日
+	To            types.Date // 期間の終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // DividendResponse は配当情報のレスポンスです。
@@ -84,32 +85,23 @@ type Dividend struct {
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *DividendService) GetDividend(ctx context.Context, params DividendParams) (*DividendResponse, error) {
 	// codeまたはdateのいずれかが必須
-	if params.Code == "" && params.Date == "" {
+	if params.Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either code or date parameter is required")
 	}
 
 	path := "/fins/dividend"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp DividendResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -119,36 +111,27 @@ func (s *DividendService) GetDividend(ctx context.Context, params DividendParams
 	return &resp, nil
 }
 
+// AllDividends は指定条件の配当金情報を1件ずつ返すイテレータです。
+func (s *DividendService) AllDividends(ctx context.Context, params DividendParams) iter.Seq2[Dividend, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Dividend, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetDividend(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetDividendByCode は指定銘柄の配当情報を取得します。
 // ページネーションを使用して全データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *DividendService) GetDividendByCode(ctx context.Context, code string) ([]Dividend, error) {
-	var allData []Dividend
-	paginationKey := ""
-
-	for {
-		params := DividendParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDividend(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllDividends(ctx, DividendParams{
+		Code: code,
+	}))
 }
 
 // GetDividendByDate は指定日の全銘柄配当情報を取得します。
@@ -156,61 +139,19 @@ func (s *DividendService) GetDividendByCode(ctx context.Context, code string) ([
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *DividendService) GetDividendByDate(ctx context.Context, date string) ([]Dividend, error) {
-	var allData []Dividend
-	paginationKey := ""
-
-	for {
-		params := DividendParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDividend(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *DividendService) GetDividendByDate(ctx context.Context, date types.Date) ([]Dividend, error) {
+	return collectAll(s.AllDividends(ctx, DividendParams{
+		Date: date,
+	}))
 }
 
 // GetDividendByCodeAndDateRange は指定銘柄・期間の配当情報を取得します。
-func (s *DividendService) GetDividendByCodeAndDateRange(ctx context.Context, code, from, to string) ([]Dividend, error) {
-	var allData []Dividend
-	paginationKey := ""
-
-	for {
-		params := DividendParams{
-			Code:          code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDividend(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *DividendService) GetDividendByCodeAndDateRange(ctx context.Context, code string, from, to types.Date) ([]Dividend, error) {
+	return collectAll(s.AllDividends(ctx, DividendParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // IsNew は新規通知かを判定します。
@@ -3,8 +3,10 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // EarningsDateService は決算発表予定日を取得するサービスです。
@@ -22,10 +24,10 @@ func NewEarningsDateService(c client.HTTPClient) *EarningsDateService {
 // EarningsDateParams は決算発表予定日のリクエストパラメータです。
 // Code、Date、ScheduledDateのいずれか1つの指定が必須で、同時指定はできません。
 type EarningsDateParams struct {
-	Code          string // 銘柄コード（4桁または5桁）
-	Date          string // 公表日（YYYYMMDD または YYYY-MM-DD）
-	ScheduledDate string // 決算発表予定日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（4桁または5桁）
+	Date          types.Date // 公表日
+	ScheduledDate types.Date // 決算発表予定日
+	PaginationKey string     // ページネーションキー
 }
 
 // EarningsDateResponse は決算発表予定日のレスポンスです。
@@ -39,13 +41,13 @@ type EarningsDateResponse struct {
 // 予定日が変更された場合は以前のレコードが残ったまま新たなレコードが追加されるため、
 // 銘柄コード指定時は変更履歴を含む全レコードが返されます。
 type EarningsDate struct {
-	PubDate  string `json:"PubDate"`  // 公表日（YYYY-MM-DD形式）。この予定日が公表・変更された日
-	SchDate  string `json:"SchDate"`  // 決算発表予定日（YYYY-MM-DD形式）。未定の場合は空文字
-	FQName   string `json:"FQName"`   // 決算区分（1Q / 2Q / 3Q / FY）
-	FYE      string `json:"FYE"`      // 決算期末（MMDD形式）
-	Code     string `json:"Code"`     // 銘柄コード（5桁）
-	CoName   string `json:"CoName"`   // 会社名（PubDate時点）
-	CoNameEn string `json:"CoNameEn"` // 会社名（英語、PubDate時点）
+	PubDate  string     `json:"PubDate"`  // 公表日（YYYY-MM-DD形式）。この予定日が公表・変更された日
+	SchDate  types.Date `json:"SchDate"`  // 決算発表予定日。未定の場合はゼロ値
+	FQName   string     `json:"FQName"`   // 決算区分（1Q / 2Q / 3Q / FY）
+	FYE      string     `json:"FYE"`      // 決算期末（MMDD形式）
+	Code     string     `json:"Code"`     // 銘柄コード（5桁）
+	CoName   string     `json:"CoName"`   // 会社名（PubDate時点）
+	CoNameEn string     `json:"CoNameEn"` // 会社名（英語、PubDate時点）
 }
 
 // GetEarningsDates は指定された条件で決算発表予定日を取得します。
@@ -57,8 +59,8 @@ type EarningsDate struct {
 // - PaginationKey: ページネーション用キー
 func (s *EarningsDateService) GetEarningsDates(ctx context.Context, params EarningsDateParams) (*EarningsDateResponse, error) {
 	specified := 0
-	for _, v := range []string{params.Code, params.Date, params.ScheduledDate} {
-		if v != "" {
+	for _, ok := range []bool{params.Code != "", !params.Date.IsZero(), !params.ScheduledDate.IsZero()} {
+		if ok {
 			specified++
 		}
 	}
@@ -68,23 +70,16 @@ func (s *EarningsDateService) GetEarningsDates(ctx context.Context, params Earni
 
 	path := "/fins/earnings-date"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.ScheduledDate != "" {
-		query += fmt.Sprintf("scheduled_date=%s&", params.ScheduledDate)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("scheduled_date", params.ScheduledDate)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp EarningsDateResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -94,6 +89,18 @@ func (s *EarningsDateService) GetEarningsDates(ctx context.Context, params Earni
 	return &resp, nil
 }
 
+// AllEarningsDates は指定条件の決算発表予定日を1件ずつ返すイテレータです。
+func (s *EarningsDateService) AllEarningsDates(ctx context.Context, params EarningsDateParams) iter.Seq2[EarningsDate, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]EarningsDate, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetEarningsDates(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetEarningsDatesByCode は指定銘柄の決算発表予定日の公表履歴を取得します。
 // ページネーションを使用して全データを取得します。
 func (s *EarningsDateService) GetEarningsDatesByCode(ctx context.Context, code string) ([]EarningsDate, error) {
@@ -102,42 +109,22 @@ func (s *EarningsDateService) GetEarningsDatesByCode(ctx context.Context, code s
 
 // GetEarningsDatesByDate は指定日に公表・変更された全銘柄の決算発表予定日を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *EarningsDateService) GetEarningsDatesByDate(ctx context.Context, date string) ([]EarningsDate, error) {
+func (s *EarningsDateService) GetEarningsDatesByDate(ctx context.Context, date types.Date) ([]EarningsDate, error) {
 	return s.getAllEarningsDates(ctx, EarningsDateParams{Date: date})
 }
 
 // GetEarningsDatesByScheduledDate は指定日を現在有効な決算発表予定日とする全銘柄を取得します。
 // 予定日がその後変更された銘柄は、変更前の予定日ではヒットしません。
 // ページネーションを使用して全データを取得します。
-func (s *EarningsDateService) GetEarningsDatesByScheduledDate(ctx context.Context, scheduledDate string) ([]EarningsDate, error) {
+func (s *EarningsDateService) GetEarningsDatesByScheduledDate(ctx context.Context, scheduledDate types.Date) ([]EarningsDate, error) {
 	return s.getAllEarningsDates(ctx, EarningsDateParams{ScheduledDate: scheduledDate})
 }
 
 func (s *EarningsDateService) getAllEarningsDates(ctx context.Context, params EarningsDateParams) ([]EarningsDate, error) {
-	var allEarningsDates []EarningsDate
-	paginationKey := ""
-
-	for {
-		params.PaginationKey = paginationKey
-
-		resp, err := s.GetEarningsDates(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allEarningsDates = append(allEarningsDates, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allEarningsDates, nil
+	return collectAll(s.AllEarningsDates(ctx, params))
 }
 
 // IsUndetermined は決算発表予定日が未定かどうかを判定します。
 func (e *EarningsDate) IsUndetermined() bool {
-	return e.SchDate == ""
+	return e.SchDate.IsZero()
 }
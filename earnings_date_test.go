@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestEarningsDateService_GetEarningsDates(t *testing.T) {
@@ -22,17 +24,17 @@ func TestEarningsDateService_GetEarningsDates(t *testing.T) {
 		},
 		{
 			name:     "with date",
-			params:   EarningsDateParams{Date: "20250620"},
+			params:   EarningsDateParams{Date: types.MustParseDate("20250620")},
 			wantPath: "/fins/earnings-date?date=20250620",
 		},
 		{
 			name:     "with date in hyphen format",
-			params:   EarningsDateParams{Date: "2025-06-20"},
-			wantPath: "/fins/earnings-date?date=2025-06-20",
+			params:   EarningsDateParams{Date: types.MustParseDate("2025-06-20")},
+			wantPath: "/fins/earnings-date?date=20250620",
 		},
 		{
 			name:     "with scheduled date",
-			params:   EarningsDateParams{ScheduledDate: "20250805"},
+			params:   EarningsDateParams{ScheduledDate: types.MustParseDate("20250805")},
 			wantPath: "/fins/earnings-date?scheduled_date=20250805",
 		},
 		{
@@ -51,7 +53,7 @@ func TestEarningsDateService_GetEarningsDates(t *testing.T) {
 				Data: []EarningsDate{
 					{
 						PubDate:  "2025-06-03",
-						SchDate:  "2025-07-30",
+						SchDate:  types.MustParseDate("2025-07-30"),
 						FQName:   "1Q",
 						FYE:      "0331",
 						Code:     "86970",
@@ -92,11 +94,11 @@ func TestEarningsDateService_GetEarningsDates_ParamConflict(t *testing.T) {
 		},
 		{
 			name:   "code and date",
-			params: EarningsDateParams{Code: "86970", Date: "20250620"},
+			params: EarningsDateParams{Code: "86970", Date: types.MustParseDate("20250620")},
 		},
 		{
 			name:   "date and scheduled date",
-			params: EarningsDateParams{Date: "20250620", ScheduledDate: "20250805"},
+			params: EarningsDateParams{Date: types.MustParseDate("20250620"), ScheduledDate: types.MustParseDate("20250805")},
 		},
 	}
 
@@ -122,14 +124,14 @@ func TestEarningsDateService_GetEarningsDatesByCode(t *testing.T) {
 	// 1ページ目: pagination_keyあり
 	mockClient.SetResponse("GET", "/fins/earnings-date?code=86970", EarningsDateResponse{
 		Data: []EarningsDate{
-			{PubDate: "2025-06-03", SchDate: "2025-07-30", FQName: "1Q", Code: "86970"},
+			{PubDate: "2025-06-03", SchDate: types.MustParseDate("2025-07-30"), FQName: "1Q", Code: "86970"},
 		},
 		PaginationKey: "key123",
 	})
 	// 2ページ目: pagination_keyなし
 	mockClient.SetResponse("GET", "/fins/earnings-date?code=86970&pagination_key=key123", EarningsDateResponse{
 		Data: []EarningsDate{
-			{PubDate: "2025-07-01", SchDate: "2025-08-05", FQName: "1Q", Code: "86970"},
+			{PubDate: "2025-07-01", SchDate: types.MustParseDate("2025-08-05"), FQName: "1Q", Code: "86970"},
 		},
 	})
 
@@ -141,7 +143,7 @@ func TestEarningsDateService_GetEarningsDatesByCode(t *testing.T) {
 	if len(dates) != 2 {
 		t.Fatalf("GetEarningsDatesByCode() returned %d items, want 2", len(dates))
 	}
-	if dates[1].SchDate != "2025-08-05" {
+	if dates[1].SchDate.String() != "2025-08-05" {
 		t.Errorf("GetEarningsDatesByCode() SchDate = %v, want 2025-08-05", dates[1].SchDate)
 	}
 	if mockClient.RequestCount != 2 {
@@ -153,13 +155,13 @@ func TestEarningsDateService_GetEarningsDatesByScheduledDate(t *testing.T) {
 	mockClient := client.NewMockClient()
 	service := NewEarningsDateService(mockClient)
 
-	mockClient.SetResponse("GET", "/fins/earnings-date?scheduled_date=2025-08-05", EarningsDateResponse{
+	mockClient.SetResponse("GET", "/fins/earnings-date?scheduled_date=20250805", EarningsDateResponse{
 		Data: []EarningsDate{
-			{PubDate: "2025-07-01", SchDate: "2025-08-05", FQName: "1Q", Code: "86970"},
+			{PubDate: "2025-07-01", SchDate: types.MustParseDate("2025-08-05"), FQName: "1Q", Code: "86970"},
 		},
 	})
 
-	dates, err := service.GetEarningsDatesByScheduledDate(context.Background(), "2025-08-05")
+	dates, err := service.GetEarningsDatesByScheduledDate(context.Background(), types.MustParseDate("2025-08-05"))
 	if err != nil {
 		t.Fatalf("GetEarningsDatesByScheduledDate() error = %v", err)
 	}
@@ -216,4 +218,10 @@ func TestEarningsDate_IsUndetermined(t *testing.T) {
 	if !resp.Data[1].IsUndetermined() {
 		t.Error("IsUndetermined() = false, want true")
 	}
+	if got := resp.Data[0].SchDate; got != types.NewDate(2025, time.July, 30) {
+		t.Errorf("SchDate = %v, want 2025-07-30", got)
+	}
+	if got := resp.Data[1].SchDate; !got.IsZero() {
+		t.Errorf("SchDate = %v, want zero for undetermined", got)
+	}
 }
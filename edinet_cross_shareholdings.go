@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -24,10 +25,10 @@ func NewEdinetCrossShareholdingsService(c client.HTTPClient) *EdinetCrossShareho
 // すべて任意ですが、edinet_codeとcodeの同時指定はできません。
 // すべて省略した場合はAPI実行日に提出された全有報のデータ一覧が返ります。
 type EdinetCrossShareholdingsParams struct {
-	EdinetCode    string // EDINETコード（例: E03814）（codeとの同時指定は不可）
-	Code          string // 4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
-	Date          string // 提出日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	EdinetCode    string     // EDINETコード（例: E03814）（codeとの同時指定は不可）
+	Code          string     // 4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
+	Date          types.Date // 提出日
+	PaginationKey string     // ページネーションキー
 }
 
 // EdinetCrossShareholdingsResponse は政策保有株式のレスポンスです。
@@ -308,23 +309,16 @@ func (s *EdinetCrossShareholdingsService) GetCrossShareholdings(ctx context.Cont
 
 	path := "/edinet/cross-shareholdings"
 
-	query := "?"
-	if params.EdinetCode != "" {
-		query += fmt.Sprintf("edinet_code=%s&", params.EdinetCode)
-	}
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("edinet_code", params.EdinetCode)
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp EdinetCrossShareholdingsResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -334,91 +328,40 @@ func (s *EdinetCrossShareholdingsService) GetCrossShareholdings(ctx context.Cont
 	return &resp, nil
 }
 
-// GetCrossShareholdingsByCode は指定銘柄の政策保有株式を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *EdinetCrossShareholdingsService) GetCrossShareholdingsByCode(ctx context.Context, code string) ([]EdinetCrossShareholdingDoc, error) {
-	var allData []EdinetCrossShareholdingDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetCrossShareholdingsParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllCrossShareholdings は指定条件の政策保有株式を1件ずつ返すイテレータです。
+func (s *EdinetCrossShareholdingsService) AllCrossShareholdings(ctx context.Context, params EdinetCrossShareholdingsParams) iter.Seq2[EdinetCrossShareholdingDoc, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]EdinetCrossShareholdingDoc, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetCrossShareholdings(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetCrossShareholdingsByCode は指定銘柄の政策保有株式を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *EdinetCrossShareholdingsService) GetCrossShareholdingsByCode(ctx context.Context, code string) ([]EdinetCrossShareholdingDoc, error) {
+	return collectAll(s.AllCrossShareholdings(ctx, EdinetCrossShareholdingsParams{
+		Code: code,
+	}))
 }
 
 // GetCrossShareholdingsByEdinetCode は指定EDINETコードの政策保有株式を取得します。
 // ページネーションを使用して全データを取得します。
 func (s *EdinetCrossShareholdingsService) GetCrossShareholdingsByEdinetCode(ctx context.Context, edinetCode string) ([]EdinetCrossShareholdingDoc, error) {
-	var allData []EdinetCrossShareholdingDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetCrossShareholdingsParams{
-			EdinetCode:    edinetCode,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetCrossShareholdings(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllCrossShareholdings(ctx, EdinetCrossShareholdingsParams{
+		EdinetCode: edinetCode,
+	}))
 }
 
 // GetCrossShareholdingsByDate は指定提出日の全有報の政策保有株式を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *EdinetCrossShareholdingsService) GetCrossShareholdingsByDate(ctx context.Context, date string) ([]EdinetCrossShareholdingDoc, error) {
-	var allData []EdinetCrossShareholdingDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetCrossShareholdingsParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetCrossShareholdings(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *EdinetCrossShareholdingsService) GetCrossShareholdingsByDate(ctx context.Context, date types.Date) ([]EdinetCrossShareholdingDoc, error) {
+	return collectAll(s.AllCrossShareholdings(ctx, EdinetCrossShareholdingsParams{
+		Date: date,
+	}))
 }
 
 // HasReport は提出会社の保有ブロックが存在するかを判定します。
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestEdinetCrossShareholdingsService_GetCrossShareholdings(t *testing.T) {
@@ -33,22 +34,22 @@ func TestEdinetCrossShareholdingsService_GetCrossShareholdings(t *testing.T) {
 		{
 			name: "with date",
 			params: EdinetCrossShareholdingsParams{
-				Date: "20250620",
+				Date: types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/cross-shareholdings?date=20250620",
 		},
 		{
 			name: "with date in hyphen format",
 			params: EdinetCrossShareholdingsParams{
-				Date: "2025-06-20",
+				Date: types.MustParseDate("2025-06-20"),
 			},
-			wantPath: "/edinet/cross-shareholdings?date=2025-06-20",
+			wantPath: "/edinet/cross-shareholdings?date=20250620",
 		},
 		{
 			name: "with code and date",
 			params: EdinetCrossShareholdingsParams{
 				Code: "86970",
-				Date: "20250620",
+				Date: types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/cross-shareholdings?code=86970&date=20250620",
 		},
@@ -56,7 +57,7 @@ func TestEdinetCrossShareholdingsService_GetCrossShareholdings(t *testing.T) {
 			name: "with edinet code and date",
 			params: EdinetCrossShareholdingsParams{
 				EdinetCode: "E03814",
-				Date:       "20250620",
+				Date:       types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/cross-shareholdings?edinet_code=E03814&date=20250620",
 		},
@@ -544,7 +545,7 @@ func TestEdinetCrossShareholdingsService_GetCrossShareholdingsByDate(t *testing.
 	mockClient.SetResponse("GET", "/edinet/cross-shareholdings?date=20250620", mockResponse)
 
 	// Execute
-	data, err := service.GetCrossShareholdingsByDate(context.Background(), "20250620")
+	data, err := service.GetCrossShareholdingsByDate(context.Background(), types.MustParseDate("20250620"))
 
 	// Verify
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -34,10 +35,10 @@ const (
 // すべて任意ですが、edinet_codeとcodeの同時指定はできません。
 // すべて省略した場合はAPI実行日に提出された全書類のデータ一覧が返ります。
 type EdinetLargeVolumeShareholdersParams struct {
-	EdinetCode    string // 発行者のEDINETコード（例: E03814）（codeとの同時指定は不可）
-	Code          string // 発行者の4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
-	Date          string // 提出日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	EdinetCode    string     // 発行者のEDINETコード（例: E03814）（codeとの同時指定は不可）
+	Code          string     // 発行者の4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
+	Date          types.Date // 提出日
+	PaginationKey string     // ページネーションキー
 }
 
 // EdinetLargeVolumeShareholdersResponse は大量保有報告書のレスポンスです。
@@ -106,17 +107,17 @@ type EdinetLargeVolumeShareholderHolder struct {
 
 // EdinetLargeVolumeShareholderAcqDisp は最近60日間の取得又は処分の状況1件分を表します。
 type EdinetLargeVolumeShareholderAcqDisp struct {
-	Date        string   `json:"Date"`        // 年月日（YYYY-MM-DD形式）
-	SecType     string   `json:"SecType"`     // 株券等の種類（例: 普通株式）
-	Shs         float64  `json:"Shs"`         // 数量（株）
-	Ratio       *float64 `json:"Ratio"`       // 割合（%表記。他の保有割合と異なり小数表現ではない）
-	Mkt         string   `json:"Mkt"`         // 市場内外取引の別（書類記載の生値）
-	MktCode     string   `json:"MktCode"`     // 市場内外取引コード（1=市場内、2=市場外）
-	TxnType     string   `json:"TxnType"`     // 取得又は処分の別（書類記載の生値）
-	TxnTypeCode string   `json:"TxnTypeCode"` // 取得又は処分コード（1=取得、2=処分）
-	Cptty       string   `json:"Cptty"`       // 譲渡の相手方（短期大量譲渡変更の書類でのみ記載）
-	Price       *float64 `json:"Price"`       // 単価（円）
-	PriceRaw    string   `json:"PriceRaw"`    // 単価を数値化できない場合の生値
+	Date        types.Date `json:"Date"`        // 年月日
+	SecType     string     `json:"SecType"`     // 株券等の種類（例: 普通株式）
+	Shs         float64    `json:"Shs"`         // 数量（株）
+	Ratio       *float64   `json:"Ratio"`       // 割合（%表記。他の保有割合と異なり小数表現ではない）
+	Mkt         string     `json:"Mkt"`         // 市場内外取引の別（書類記載の生値）
+	MktCode     string     `json:"MktCode"`     // 市場内外取引コード（1=市場内、2=市場外）
+	TxnType     string     `json:"TxnType"`     // 取得又は処分の別（書類記載の生値）
+	TxnTypeCode string     `json:"TxnTypeCode"` // 取得又は処分コード（1=取得、2=処分）
+	Cptty       string     `json:"Cptty"`       // 譲渡の相手方（短期大量譲渡変更の書類でのみ記載）
+	Price       *float64   `json:"Price"`       // 単価（円）
+	PriceRaw    string     `json:"PriceRaw"`    // 単価を数値化できない場合の生値
 }
 
 // EdinetLargeVolumeShareholderBorrowing は借入金の内訳1件分を表します。
@@ -189,7 +190,7 @@ type RawEdinetLargeVolumeShareholderHolder struct {
 
 // RawEdinetLargeVolumeShareholderAcqDisp is used for unmarshaling JSON response with mixed types
 type RawEdinetLargeVolumeShareholderAcqDisp struct {
-	Date        string                `json:"Date"`
+	Date        types.Date            `json:"Date"`
 	SecType     string                `json:"SecType"`
 	Shs         types.NullableFloat64 `json:"Shs"`
 	Ratio       types.NullableFloat64 `json:"Ratio"`
@@ -340,23 +341,16 @@ func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholders(ctx co
 
 	path := "/edinet/large-volume-shareholders"
 
-	query := "?"
-	if params.EdinetCode != "" {
-		query += fmt.Sprintf("edinet_code=%s&", params.EdinetCode)
-	}
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("edinet_code", params.EdinetCode)
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp EdinetLargeVolumeShareholdersResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -366,91 +360,40 @@ func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholders(ctx co
 	return &resp, nil
 }
 
-// GetLargeVolumeShareholdersByCode は指定銘柄の大量保有報告書を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholdersByCode(ctx context.Context, code string) ([]EdinetLargeVolumeShareholderDoc, error) {
-	var allData []EdinetLargeVolumeShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetLargeVolumeShareholdersParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllLargeVolumeShareholders は指定条件の大量保有報告書を1件ずつ返すイテレータです。
+func (s *EdinetLargeVolumeShareholdersService) AllLargeVolumeShareholders(ctx context.Context, params EdinetLargeVolumeShareholdersParams) iter.Seq2[EdinetLargeVolumeShareholderDoc, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]EdinetLargeVolumeShareholderDoc, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetLargeVolumeShareholders(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetLargeVolumeShareholdersByCode は指定銘柄の大量保有報告書を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholdersByCode(ctx context.Context, code string) ([]EdinetLargeVolumeShareholderDoc, error) {
+	return collectAll(s.AllLargeVolumeShareholders(ctx, EdinetLargeVolumeShareholdersParams{
+		Code: code,
+	}))
 }
 
 // GetLargeVolumeShareholdersByEdinetCode は指定EDINETコードの発行者に係る大量保有報告書を取得します。
 // ページネーションを使用して全データを取得します。
 func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholdersByEdinetCode(ctx context.Context, edinetCode string) ([]EdinetLargeVolumeShareholderDoc, error) {
-	var allData []EdinetLargeVolumeShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetLargeVolumeShareholdersParams{
-			EdinetCode:    edinetCode,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetLargeVolumeShareholders(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllLargeVolumeShareholders(ctx, EdinetLargeVolumeShareholdersParams{
+		EdinetCode: edinetCode,
+	}))
 }
 
 // GetLargeVolumeShareholdersByDate は指定提出日の全書類の大量保有報告書を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholdersByDate(ctx context.Context, date string) ([]EdinetLargeVolumeShareholderDoc, error) {
-	var allData []EdinetLargeVolumeShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetLargeVolumeShareholdersParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetLargeVolumeShareholders(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *EdinetLargeVolumeShareholdersService) GetLargeVolumeShareholdersByDate(ctx context.Context, date types.Date) ([]EdinetLargeVolumeShareholderDoc, error) {
+	return collectAll(s.AllLargeVolumeShareholders(ctx, EdinetLargeVolumeShareholdersParams{
+		Date: date,
+	}))
 }
 
 // IsChangeReport は変更報告書（変更報告書、短期大量譲渡、特例対象株券等の変更報告書）かを判定します。
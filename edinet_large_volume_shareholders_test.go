@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestEdinetLargeVolumeShareholdersService_GetLargeVolumeShareholders(t *testing.T) {
@@ -33,22 +34,22 @@ func TestEdinetLargeVolumeShareholdersService_GetLargeVolumeShareholders(t *test
 		{
 			name: "with date",
 			params: EdinetLargeVolumeShareholdersParams{
-				Date: "20250707",
+				Date: types.MustParseDate("20250707"),
 			},
 			wantPath: "/edinet/large-volume-shareholders?date=20250707",
 		},
 		{
 			name: "with date in hyphen format",
 			params: EdinetLargeVolumeShareholdersParams{
-				Date: "2025-07-07",
+				Date: types.MustParseDate("2025-07-07"),
 			},
-			wantPath: "/edinet/large-volume-shareholders?date=2025-07-07",
+			wantPath: "/edinet/large-volume-shareholders?date=20250707",
 		},
 		{
 			name: "with code and date",
 			params: EdinetLargeVolumeShareholdersParams{
 				Code: "86970",
-				Date: "20250707",
+				Date: types.MustParseDate("20250707"),
 			},
 			wantPath: "/edinet/large-volume-shareholders?code=86970&date=20250707",
 		},
@@ -56,7 +57,7 @@ func TestEdinetLargeVolumeShareholdersService_GetLargeVolumeShareholders(t *test
 			name: "with edinet code and date",
 			params: EdinetLargeVolumeShareholdersParams{
 				EdinetCode: "E03814",
-				Date:       "20250707",
+				Date:       types.MustParseDate("20250707"),
 			},
 			wantPath: "/edinet/large-volume-shareholders?edinet_code=E03814&date=20250707",
 		},
@@ -550,7 +551,7 @@ func TestEdinetLargeVolumeShareholdersService_GetLargeVolumeShareholdersByDate(t
 	mockClient.SetResponse("GET", "/edinet/large-volume-shareholders?date=20250707", mockResponse)
 
 	// Execute
-	data, err := service.GetLargeVolumeShareholdersByDate(context.Background(), "20250707")
+	data, err := service.GetLargeVolumeShareholdersByDate(context.Background(), types.MustParseDate("20250707"))
 
 	// Verify
 	if err != nil {
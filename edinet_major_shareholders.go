@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -24,10 +25,10 @@ func NewEdinetMajorShareholdersService(c client.HTTPClient) *EdinetMajorSharehol
 // すべて任意ですが、edinet_codeとcodeの同時指定はできません。
 // すべて省略した場合はAPI実行日に提出された全有報のデータ一覧が返ります。
 type EdinetMajorShareholdersParams struct {
-	EdinetCode    string // EDINETコード（例: E03814）（codeとの同時指定は不可）
-	Code          string // 4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
-	Date          string // 提出日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	EdinetCode    string     // EDINETコード（例: E03814）（codeとの同時指定は不可）
+	Code          string     // 4桁もしくは5桁の銘柄コード（edinet_codeとの同時指定は不可）
+	Date          types.Date // 提出日
+	PaginationKey string     // ページネーションキー
 }
 
 // EdinetMajorShareholdersResponse は大株主状況のレスポンスです。
@@ -154,23 +155,16 @@ func (s *EdinetMajorShareholdersService) GetMajorShareholders(ctx context.Contex
 
 	path := "/edinet/major-shareholders"
 
-	query := "?"
-	if params.EdinetCode != "" {
-		query += fmt.Sprintf("edinet_code=%s&", params.EdinetCode)
-	}
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("edinet_code", params.EdinetCode)
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp EdinetMajorShareholdersResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -180,91 +174,40 @@ func (s *EdinetMajorShareholdersService) GetMajorShareholders(ctx context.Contex
 	return &resp, nil
 }
 
-// GetMajorShareholdersByCode は指定銘柄の大株主状況を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *EdinetMajorShareholdersService) GetMajorShareholdersByCode(ctx context.Context, code string) ([]EdinetMajorShareholderDoc, error) {
-	var allData []EdinetMajorShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetMajorShareholdersParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllMajorShareholders は指定条件の大株主状況を1件ずつ返すイテレータです。
+func (s *EdinetMajorShareholdersService) AllMajorShareholders(ctx context.Context, params EdinetMajorShareholdersParams) iter.Seq2[EdinetMajorShareholderDoc, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]EdinetMajorShareholderDoc, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetMajorShareholders(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetMajorShareholdersByCode は指定銘柄の大株主状況を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *EdinetMajorShareholdersService) GetMajorShareholdersByCode(ctx context.Context, code string) ([]EdinetMajorShareholderDoc, error) {
+	return collectAll(s.AllMajorShareholders(ctx, EdinetMajorShareholdersParams{
+		Code: code,
+	}))
 }
 
 // GetMajorShareholdersByEdinetCode は指定EDINETコードの大株主状況を取得します。
 // ページネーションを使用して全データを取得します。
 func (s *EdinetMajorShareholdersService) GetMajorShareholdersByEdinetCode(ctx context.Context, edinetCode string) ([]EdinetMajorShareholderDoc, error) {
-	var allData []EdinetMajorShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetMajorShareholdersParams{
-			EdinetCode:    edinetCode,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetMajorShareholders(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllMajorShareholders(ctx, EdinetMajorShareholdersParams{
+		EdinetCode: edinetCode,
+	}))
 }
 
 // GetMajorShareholdersByDate は指定提出日の全有報の大株主状況を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *EdinetMajorShareholdersService) GetMajorShareholdersByDate(ctx context.Context, date string) ([]EdinetMajorShareholderDoc, error) {
-	var allData []EdinetMajorShareholderDoc
-	paginationKey := ""
-
-	for {
-		params := EdinetMajorShareholdersParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetMajorShareholders(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *EdinetMajorShareholdersService) GetMajorShareholdersByDate(ctx context.Context, date types.Date) ([]EdinetMajorShareholderDoc, error) {
+	return collectAll(s.AllMajorShareholders(ctx, EdinetMajorShareholdersParams{
+		Date: date,
+	}))
 }
 
 // GetShareholdingPercentage は所有割合をパーセント表記で取得します。
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestEdinetMajorShareholdersService_GetMajorShareholders(t *testing.T) {
@@ -33,22 +34,22 @@ func TestEdinetMajorShareholdersService_GetMajorShareholders(t *testing.T) {
 		{
 			name: "with date",
 			params: EdinetMajorShareholdersParams{
-				Date: "20250620",
+				Date: types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/major-shareholders?date=20250620",
 		},
 		{
 			name: "with date in hyphen format",
 			params: EdinetMajorShareholdersParams{
-				Date: "2025-06-20",
+				Date: types.MustParseDate("2025-06-20"),
 			},
-			wantPath: "/edinet/major-shareholders?date=2025-06-20",
+			wantPath: "/edinet/major-shareholders?date=20250620",
 		},
 		{
 			name: "with code and date",
 			params: EdinetMajorShareholdersParams{
 				Code: "86970",
-				Date: "20250620",
+				Date: types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/major-shareholders?code=86970&date=20250620",
 		},
@@ -56,7 +57,7 @@ func TestEdinetMajorShareholdersService_GetMajorShareholders(t *testing.T) {
 			name: "with edinet code and date",
 			params: EdinetMajorShareholdersParams{
 				EdinetCode: "E03814",
-				Date:       "20250620",
+				Date:       types.MustParseDate("20250620"),
 			},
 			wantPath: "/edinet/major-shareholders?edinet_code=E03814&date=20250620",
 		},
@@ -395,7 +396,7 @@ func TestEdinetMajorShareholdersService_GetMajorShareholdersByDate(t *testing.T)
 	mockClient.SetResponse("GET", "/edinet/major-shareholders?date=20250620", mockResponse)
 
 	// Execute
-	data, err := service.GetMajorShareholdersByDate(context.Background(), "20250620")
+	data, err := service.GetMajorShareholdersByDate(context.Background(), types.MustParseDate("20250620"))
 
 	// Verify
 	if err != nil {
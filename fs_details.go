@@ -3,8 +3,10 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // FSDetailsService は財務諸表(BS/PL/CF)詳細情報を取得するサービスです。
@@ -23,10 +25,10 @@ func NewFSDetailsService(c client.HTTPClient) *FSDetailsService {
 
 // FSDetailsParams は財務諸表詳細情報のリクエストパラメータです。
 type FSDetailsParams struct {
-	Code          string // 銘柄コード（codeまたはdateのいずれかが必須）
-	Date          string // 開示日（YYYYMMDD または YYYY-MM-DD）（codeまたはdateのいずれかが必須）
-	Cursor        string // 差分取得用カーソル。前回レスポンスのcursorを指定すると前回リクエスト以降のデータを取得（pagination_keyと同時指定不可）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（codeまたはdateのいずれかが必須）
+	Date          types.Date // 開示日（codeまたはdateのいずれかが必須）
+	Cursor        string     // 差分取得用カーソル。前回レスポンスのcursorを指定すると前回リクエスト以降のデータを取得（pagination_keyと同時指定不可）
+	PaginationKey string     // ページネーションキー
 }
 
 // FSDetailsResponse は財務諸表詳細情報のレスポンスです。
@@ -43,11 +45,11 @@ type FSDetailsResponse struct {
 // 注意: このデータはプレミアムプラン専用APIで取得されます。
 type FSDetail struct {
 	// 基本情報
-	DiscDate string `json:"DiscDate"` // 開示日（YYYY-MM-DD形式）
-	DiscTime string `json:"DiscTime"` // 開示時刻（HH:MM:SS形式）
-	Code     string `json:"Code"`     // 銘柄コード（5桁）
-	DiscNo   string `json:"DiscNo"`   // 開示番号（昇順ソートのキー）
-	DocType  string `json:"DocType"`  // 開示書類種別
+	DiscDate types.Date `json:"DiscDate"` // 開示日
+	DiscTime string     `json:"DiscTime"` // 開示時刻（HH:MM:SS形式）
+	Code     string     `json:"Code"`     // 銘柄コード（5桁）
+	DiscNo   string     `json:"DiscNo"`   // 開示番号（昇順ソートのキー）
+	DocType  string     `json:"DocType"`  // 開示書類種別
 
 	// 財務諸表データ
 	FS map[string]string `json:"FS"` // 財務諸表の各種項目（冗長ラベル（英語）とその値のマップ）
@@ -58,8 +60,14 @@ type FSDetail struct {
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *FSDetailsService) GetFSDetails(ctx context.Context, params FSDetailsParams) (*FSDetailsResponse, error) {
+	// cursorによる差分取得はポーリング用途のため、キャッシュを経由しない
+	return s.getFSDetails(ctx, params, params.Cursor != "")
+}
+
+// getFSDetails はGetFSDetailsの本体です。noCacheがtrueのときはキャッシュを経由せずに取得します。
+func (s *FSDetailsService) getFSDetails(ctx context.Context, params FSDetailsParams, noCache bool) (*FSDetailsResponse, error) {
 	// codeまたはdateのいずれかが必須
-	if params.Code == "" && params.Date == "" {
+	if params.Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either code or date parameter is required")
 	}
 	if params.Cursor != "" && params.PaginationKey != "" {
@@ -68,28 +76,20 @@ func (s *FSDetailsService) GetFSDetails(ctx context.Context, params FSDetailsPar
 
 	path := "/fins/details"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.Cursor != "" {
-		query += fmt.Sprintf("cursor=%s&", params.Cursor)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.set("cursor", params.Cursor)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp FSDetailsResponse
 	var err error
-	if params.Cursor != "" {
-		// cursorによる差分取得はポーリング用途のため、キャッシュを経由しない
+	if noCache {
 		err = client.DoRequestNoCache(ctx, s.client, "GET", path, nil, &resp)
 	} else {
 		err = s.client.DoRequest(ctx, "GET", path, nil, &resp)
@@ -101,69 +101,53 @@ func (s *FSDetailsService) GetFSDetails(ctx context.Context, params FSDetailsPar
 	return &resp, nil
 }
 
-// GetFSDetailsByCode は指定銘柄の財務諸表詳細情報を取得します。
-// ページネーションを使用して全データを取得します。
-//
-// 注意: このAPIはプレミアムプラン専用です。
-// スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *FSDetailsService) GetFSDetailsByCode(ctx context.Context, code string) ([]FSDetail, error) {
-	var allData []FSDetail
-	paginationKey := ""
+// AllFSDetails は指定条件の財務諸表詳細情報を1件ずつ返すイテレータです。
+// cursorを指定した場合、2ページ目以降はpagination_keyのみで取得します。
+func (s *FSDetailsService) AllFSDetails(ctx context.Context, params FSDetailsParams) iter.Seq2[FSDetail, error] {
+	return s.allFSDetails(ctx, params, params.Cursor != "", nil)
+}
 
-	for {
-		params := FSDetailsParams{
-			Code:          code,
-			PaginationKey: paginationKey,
+// allFSDetails はAllFSDetailsの本体です。noCacheがtrueのときは全ページをキャッシュを経由せずに取得し、
+// cursorがnilでなければ応答に含まれる最後の差分取得用カーソルを設定します。
+func (s *FSDetailsService) allFSDetails(ctx context.Context, params FSDetailsParams, noCache bool, cursor *string) iter.Seq2[FSDetail, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]FSDetail, string, error) {
+		params.PaginationKey = paginationKey
+		if paginationKey != "" {
+			// cursorとpagination_keyは同時指定できないため、2ページ目以降はpagination_keyのみで取得する
+			params.Cursor = ""
 		}
-
-		resp, err := s.GetFSDetails(ctx, params)
+		resp, err := s.getFSDetails(ctx, params, noCache)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+		if cursor != nil && resp.Cursor != "" {
+			*cursor = resp.Cursor
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allData, nil
+// GetFSDetailsByCode は指定銘柄の財務諸表詳細情報を取得します。
+// ページネーションを使用して全データを取得します。
+//
+// 注意: このAPIはプレミアムプラン専用です。
+// スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
+func (s *FSDetailsService) GetFSDetailsByCode(ctx context.Context, code string) ([]FSDetail, error) {
+	return collectAll(s.AllFSDetails(ctx, FSDetailsParams{
+		Code: code,
+	}))
 }
 
 // GetFSDetailsByDate は指定日の全銘柄財務諸表詳細情報を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *FSDetailsService) GetFSDetailsByDate(ctx context.Context, date string) ([]FSDetail, error) {
-	var allData []FSDetail
-	paginationKey := ""
-
-	for {
-		params := FSDetailsParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetFSDetails(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *FSDetailsService) GetFSDetailsByDate(ctx context.Context, date types.Date) ([]FSDetail, error) {
+	return collectAll(s.AllFSDetails(ctx, FSDetailsParams{
+		Date: date,
+	}))
 }
 
 // GetFSDetailsByCodeAndDate は指定銘柄の指定開示日の財務諸表詳細情報を取得します。
-func (s *FSDetailsService) GetFSDetailsByCodeAndDate(ctx context.Context, code, date string) ([]FSDetail, error) {
+func (s *FSDetailsService) GetFSDetailsByCodeAndDate(ctx context.Context, code string, date types.Date) ([]FSDetail, error) {
 	resp, err := s.GetFSDetails(ctx, FSDetailsParams{
 		Code: code,
 		Date: date,
@@ -0,0 +1,277 @@
+package jquants
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FSLineItem は会計基準によらない財務諸表の標準項目です。
+type FSLineItem string
+
+// 財務諸表の標準項目の定義
+const (
+	// 貸借対照表
+	FSTotalAssets                FSLineItem = "TotalAssets"                // 資産合計
+	FSCurrentAssets              FSLineItem = "CurrentAssets"              // 流動資産
+	FSNonCurrentAssets           FSLineItem = "NonCurrentAssets"           // 非流動資産（固定資産）
+	FSCashAndEquivalents         FSLineItem = "CashAndEquivalents"         // 現金及び現金同等物
+	FSGoodwill                   FSLineItem = "Goodwill"                   // のれん
+	FSPropertyPlantAndEquipment  FSLineItem = "PropertyPlantAndEquipment"  // 有形固定資産
+	FSTotalLiabilities           FSLineItem = "TotalLiabilities"           // 負債合計
+	FSCurrentLiabilities         FSLineItem = "CurrentLiabilities"         // 流動負債
+	FSNonCurrentLiabilities      FSLineItem = "NonCurrentLiabilities"      // 非流動負債（固定負債）
+	FSTotalEquity                FSLineItem = "TotalEquity"                // 資本合計（純資産合計）
+	FSEquityAttributableToOwners FSLineItem = "EquityAttributableToOwners" // 親会社の所有者に帰属する持分（IFRS）
+	FSShareholdersEquity         FSLineItem = "ShareholdersEquity"         // 株主資本（日本基準のみ）
+	FSShareCapital               FSLineItem = "ShareCapital"               // 資本金
+	FSRetainedEarnings           FSLineItem = "RetainedEarnings"           // 利益剰余金
+
+	// 損益計算書
+	FSRevenue                    FSLineItem = "Revenue"                    // 売上収益（売上高）
+	FSCostOfSales                FSLineItem = "CostOfSales"                // 売上原価
+	FSGrossProfit                FSLineItem = "GrossProfit"                // 売上総利益
+	FSSellingGeneralAdmin        FSLineItem = "SellingGeneralAdmin"        // 販売費及び一般管理費
+	FSOperatingProfit            FSLineItem = "OperatingProfit"            // 営業利益
+	FSOrdinaryProfit             FSLineItem = "OrdinaryProfit"             // 経常利益（日本基準のみ）
+	FSProfitBeforeTax            FSLineItem = "ProfitBeforeTax"            // 税引前利益
+	FSIncomeTax                  FSLineItem = "IncomeTax"                  // 法人所得税費用
+	FSProfit                     FSLineItem = "Profit"                     // 当期利益
+	FSProfitAttributableToOwners FSLineItem = "ProfitAttributableToOwners" // 親会社の所有者に帰属する当期利益
+	FSBasicEPS                   FSLineItem = "BasicEPS"                   // 基本的1株当たり当期利益
+
+	// キャッシュ・フロー計算書
+	FSOperatingCashFlow  FSLineItem = "OperatingCashFlow"  // 営業活動によるキャッシュ・フロー
+	FSInvestingCashFlow  FSLineItem = "InvestingCashFlow"  // 投資活動によるキャッシュ・フロー
+	FSFinancingCashFlow  FSLineItem = "FinancingCashFlow"  // 財務活動によるキャッシュ・フロー
+	FSDepreciation       FSLineItem = "Depreciation"       // 減価償却費
+	FSCapitalExpenditure FSLineItem = "CapitalExpenditure" // 有形固定資産の取得による支出
+)
+
+// BalanceSheet は貸借対照表の標準項目です。FSに対応する項目がない場合はnilです。
+type BalanceSheet struct {
+	TotalAssets                *float64 // 資産合計
+	CurrentAssets              *float64 // 流動資産
+	NonCurrentAssets           *float64 // 非流動資産（固定資産）
+	CashAndEquivalents         *float64 // 現金及び現金同等物
+	Goodwill                   *float64 // のれん
+	PropertyPlantAndEquipment  *float64 // 有形固定資産
+	TotalLiabilities           *float64 // 負債合計
+	CurrentLiabilities         *float64 // 流動負債
+	NonCurrentLiabilities      *float64 // 非流動負債（固定負債）
+	TotalEquity                *float64 // 資本合計（純資産合計）
+	EquityAttributableToOwners *float64 // 親会社の所有者に帰属する持分（IFRS）
+	ShareholdersEquity         *float64 // 株主資本（日本基準のみ）
+	ShareCapital               *float64 // 資本金
+	RetainedEarnings           *float64 // 利益剰余金
+}
+
+// IncomeStatement は損益計算書の標準項目です。FSに対応する項目がない場合はnilです。
+type IncomeStatement struct {
+	Revenue                    *float64 // 売上収益（売上高）
+	CostOfSales                *float64 // 売上原価
+	GrossProfit                *float64 // 売上総利益
+	SellingGeneralAdmin        *float64 // 販売費及び一般管理費
+	OperatingProfit            *float64 // 営業利益
+	OrdinaryProfit             *float64 // 経常利益（日本基準のみ）
+	ProfitBeforeTax            *float64 // 税引前利益
+	IncomeTax                  *float64 // 法人所得税費用
+	Profit                     *float64 // 当期利益
+	ProfitAttributableToOwners *float64 // 親会社の所有者に帰属する当期利益
+	BasicEPS                   *float64 // 基本的1株当たり当期利益
+}
+
+// CashFlowStatement はキャッシュ・フロー計算書の標準項目です。FSに対応する項目がない場合はnilです。
+type CashFlowStatement struct {
+	OperatingCashFlow  *float64 // 営業活動によるキャッシュ・フロー
+	InvestingCashFlow  *float64 // 投資活動によるキャッシュ・フロー
+	FinancingCashFlow  *float64 // 財務活動によるキャッシュ・フロー
+	Depreciation       *float64 // 減価償却費
+	CapitalExpenditure *float64 // 有形固定資産の取得による支出
+}
+
+// FinancialStatements はFSDetail.FSを標準項目に対応付けた財務諸表です。
+type FinancialStatements struct {
+	AccountingStandard string            // 会計基準（"IFRS"、"JapaneseGAAP" など。FSの Accounting standards, DEI の値）
+	BalanceSheet       BalanceSheet      // 貸借対照表
+	IncomeStatement    IncomeStatement   // 損益計算書
+	CashFlow           CashFlowStatement // キャッシュ・フロー計算書
+	Unmapped           []string          // 標準項目に対応付けられなかったFSのキー（DEIを除く。昇順）
+}
+
+// FSLabelMap は標準項目ごとのFSのキー（EDINETタクソノミの冗長ラベル（英語））の対応表です。
+// 先に書いたキーを優先します。IFRSのラベルは末尾に " (IFRS)" が付きます。
+type FSLabelMap map[FSLineItem][]string
+
+// fsLabels は組み込みの対応表です。変更しないでください。
+//
+// 日本基準の株主資本（Shareholders' equity）は、その他の包括利益累計額を含まないため
+// IFRSの親会社の所有者に帰属する持分とは範囲が異なります。別の標準項目（FSShareholdersEquity）に対応付けます。
+var fsLabels = FSLabelMap{
+	FSTotalAssets:                {"Assets (IFRS)", "Assets", "Total assets"},
+	FSCurrentAssets:              {"Current assets (IFRS)", "Current assets"},
+	FSNonCurrentAssets:           {"Non-current assets (IFRS)", "Non-current assets"},
+	FSCashAndEquivalents:         {"Cash and cash equivalents (IFRS)", "Cash and cash equivalents"},
+	FSGoodwill:                   {"Goodwill (IFRS)", "Goodwill"},
+	FSPropertyPlantAndEquipment:  {"Property, plant and equipment (IFRS)", "Property, plant and equipment"},
+	FSTotalLiabilities:           {"Liabilities (IFRS)", "Liabilities", "Total liabilities"},
+	FSCurrentLiabilities:         {"Current liabilities (IFRS)", "Current liabilities"},
+	FSNonCurrentLiabilities:      {"Non-current liabilities (IFRS)", "Non-current liabilities"},
+	FSTotalEquity:                {"Equity (IFRS)", "Net assets", "Total net assets"},
+	FSEquityAttributableToOwners: {"Equity attributable to owners of parent (IFRS)"},
+	FSShareholdersEquity:         {"Shareholders' equity"},
+	FSShareCapital:               {"Share capital (IFRS)", "Share capital", "Capital stock"},
+	FSRetainedEarnings:           {"Retained earnings (IFRS)", "Retained earnings"},
+
+	FSRevenue:                    {"Revenue (IFRS)", "Revenue - 2 (IFRS)", "Net sales (IFRS)", "Net sales", "Operating revenue"},
+	FSCostOfSales:                {"Cost of sales (IFRS)", "Cost of sales"},
+	FSGrossProfit:                {"Gross profit (IFRS)", "Gross profit"},
+	FSSellingGeneralAdmin:        {"Selling, general and administrative expenses (IFRS)", "Selling, general and administrative expenses"},
+	FSOperatingProfit:            {"Operating profit (loss) (IFRS)", "Operating profit (loss)"},
+	FSOrdinaryProfit:             {"Ordinary profit (loss)"},
+	FSProfitBeforeTax:            {"Profit (loss) before tax (IFRS)", "Profit (loss) before income taxes"},
+	FSIncomeTax:                  {"Income tax expense (IFRS)", "Income taxes"},
+	FSProfit:                     {"Profit (loss) (IFRS)", "Profit (loss)"},
+	FSProfitAttributableToOwners: {"Profit (loss) attributable to owners of parent (IFRS)", "Profit (loss) attributable to owners of parent"},
+	FSBasicEPS:                   {"Basic earnings (loss) per share (IFRS)", "Basic earnings (loss) per share"},
+
+	FSOperatingCashFlow:  {"Net cash provided by (used in) operating activities (IFRS)", "Net cash provided by (used in) operating activities"},
+	FSInvestingCashFlow:  {"Net cash provided by (used in) investing activities (IFRS)", "Net cash provided by (used in) investing activities"},
+	FSFinancingCashFlow:  {"Net cash provided by (used in) financing activities (IFRS)", "Net cash provided by (used in) financing activities"},
+	FSDepreciation:       {"Depreciation and amortisation expense (IFRS)", "Depreciation and amortization (IFRS)", "Depreciation"},
+	FSCapitalExpenditure: {"Purchase of property, plant and equipment (IFRS)", "Purchase of property, plant and equipment"},
+}
+
+// fsFields は標準項目に対応するFinancialStatementsのフィールドです。
+var fsFields = map[FSLineItem]func(*FinancialStatements) **float64{
+	FSTotalAssets:                func(s *FinancialStatements) **float64 { return &s.BalanceSheet.TotalAssets },
+	FSCurrentAssets:              func(s *FinancialStatements) **float64 { return &s.BalanceSheet.CurrentAssets },
+	FSNonCurrentAssets:           func(s *FinancialStatements) **float64 { return &s.BalanceSheet.NonCurrentAssets },
+	FSCashAndEquivalents:         func(s *FinancialStatements) **float64 { return &s.BalanceSheet.CashAndEquivalents },
+	FSGoodwill:                   func(s *FinancialStatements) **float64 { return &s.BalanceSheet.Goodwill },
+	FSPropertyPlantAndEquipment:  func(s *FinancialStatements) **float64 { return &s.BalanceSheet.PropertyPlantAndEquipment },
+	FSTotalLiabilities:           func(s *FinancialStatements) **float64 { return &s.BalanceSheet.TotalLiabilities },
+	FSCurrentLiabilities:         func(s *FinancialStatements) **float64 { return &s.BalanceSheet.CurrentLiabilities },
+	FSNonCurrentLiabilities:      func(s *FinancialStatements) **float64 { return &s.BalanceSheet.NonCurrentLiabilities },
+	FSTotalEquity:                func(s *FinancialStatements) **float64 { return &s.BalanceSheet.TotalEquity },
+	FSEquityAttributableToOwners: func(s *FinancialStatements) **float64 { return &s.BalanceSheet.EquityAttributableToOwners },
+	FSShareholdersEquity:         func(s *FinancialStatements) **float64 { return &s.BalanceSheet.ShareholdersEquity },
+	FSShareCapital:               func(s *FinancialStatements) **float64 { return &s.BalanceSheet.ShareCapital },
+	FSRetainedEarnings:           func(s *FinancialStatements) **float64 { return &s.BalanceSheet.RetainedEarnings },
+
+	FSRevenue:                    func(s *FinancialStatements) **float64 { return &s.IncomeStatement.Revenue },
+	FSCostOfSales:                func(s *FinancialStatements) **float64 { return &s.IncomeStatement.CostOfSales },
+	FSGrossProfit:                func(s *FinancialStatements) **float64 { return &s.IncomeStatement.GrossProfit },
+	FSSellingGeneralAdmin:        func(s *FinancialStatements) **float64 { return &s.IncomeStatement.SellingGeneralAdmin },
+	FSOperatingProfit:            func(s *FinancialStatements) **float64 { return &s.IncomeStatement.OperatingProfit },
+	FSOrdinaryProfit:             func(s *FinancialStatements) **float64 { return &s.IncomeStatement.OrdinaryProfit },
+	FSProfitBeforeTax:            func(s *FinancialStatements) **float64 { return &s.IncomeStatement.ProfitBeforeTax },
+	FSIncomeTax:                  func(s *FinancialStatements) **float64 { return &s.IncomeStatement.IncomeTax },
+	FSProfit:                     func(s *FinancialStatements) **float64 { return &s.IncomeStatement.Profit },
+	FSProfitAttributableToOwners: func(s *FinancialStatements) **float64 { return &s.IncomeStatement.ProfitAttributableToOwners },
+	FSBasicEPS:                   func(s *FinancialStatements) **float64 { return &s.IncomeStatement.BasicEPS },
+
+	FSOperatingCashFlow:  func(s *FinancialStatements) **float64 { return &s.CashFlow.OperatingCashFlow },
+	FSInvestingCashFlow:  func(s *FinancialStatements) **float64 { return &s.CashFlow.InvestingCashFlow },
+	FSFinancingCashFlow:  func(s *FinancialStatements) **float64 { return &s.CashFlow.FinancingCashFlow },
+	FSDepreciation:       func(s *FinancialStatements) **float64 { return &s.CashFlow.Depreciation },
+	FSCapitalExpenditure: func(s *FinancialStatements) **float64 { return &s.CashFlow.CapitalExpenditure },
+}
+
+// DefaultFSLabels は組み込みの対応表の複製を返します。
+// 組み込みの対応表にないラベルの企業がある場合は、Unmappedの内容を確認して複製にAddで追加し、WithFSLabelsで指定してください。
+func DefaultFSLabels() FSLabelMap {
+	m := make(FSLabelMap, len(fsLabels))
+	for item, labels := range fsLabels {
+		m[item] = slices.Clone(labels)
+	}
+	return m
+}
+
+// Add は標準項目（定義済みのFSLineItem）に対応するFSのキーを追加します。追加したキーは既存のキーより後に使用されます。
+func (m FSLabelMap) Add(item FSLineItem, label string) {
+	if !slices.Contains(m[item], label) {
+		m[item] = append(m[item], label)
+	}
+}
+
+// FSLabels は組み込みの対応表で標準項目に対応するFSのキーを優先順に返します。
+func FSLabels(item FSLineItem) []string {
+	return slices.Clone(fsLabels[item])
+}
+
+// NormalizeOption はNormalizeの動作を設定するオプションです。
+type NormalizeOption func(*normalizeConfig)
+
+type normalizeConfig struct {
+	labels FSLabelMap
+}
+
+// WithFSLabels は組み込みの対応表の代わりに使用する対応表を設定します。
+func WithFSLabels(labels FSLabelMap) NormalizeOption {
+	return func(c *normalizeConfig) {
+		c.labels = labels
+	}
+}
+
+// Normalize はFSを会計基準によらない標準項目（貸借対照表・損益計算書・キャッシュ・フロー計算書）に対応付けます。
+// 値が空や数値でない項目はnilです。対応表にないキー（DEIを除く）はUnmappedに含めます。
+func (d *FSDetail) Normalize(opts ...NormalizeOption) *FinancialStatements {
+	cfg := &normalizeConfig{labels: fsLabels}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	fs := &FinancialStatements{AccountingStandard: d.FS["Accounting standards, DEI"]}
+
+	mapped := map[string]bool{}
+	for item, labels := range cfg.labels {
+		field, ok := fsFields[item]
+		if !ok {
+			continue
+		}
+		for _, label := range labels {
+			mapped[label] = true
+			if *field(fs) != nil {
+				continue
+			}
+			if v, ok := parseFSValue(d.FS[label]); ok {
+				*field(fs) = &v
+			}
+		}
+	}
+
+	for key := range d.FS {
+		if !mapped[key] && !strings.HasSuffix(key, ", DEI") {
+			fs.Unmapped = append(fs.Unmapped, key)
+		}
+	}
+	slices.Sort(fs.Unmapped)
+	return fs
+}
+
+// Value は標準項目の値を返します。値がない場合や未定義の項目の場合はnilを返します。
+func (s *FinancialStatements) Value(item FSLineItem) *float64 {
+	field, ok := fsFields[item]
+	if !ok {
+		return nil
+	}
+	return *field(s)
+}
+
+// parseFSValue はFSの値を数値に変換します。空文字や "-" などの数値でない値はfalseを返します。
+func parseFSValue(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	return v, err == nil
+}
+
+// CountUnmappedFSKeys は複数の財務諸表詳細で標準項目に対応付けられなかったキーの出現回数を返します。
+// 対応表に追加すべきラベルを調べる際に使用します。
+func CountUnmappedFSKeys(details []FSDetail, opts ...NormalizeOption) map[string]int {
+	counts := map[string]int{}
+	for i := range details {
+		for _, key := range details[i].Normalize(opts...).Unmapped {
+			counts[key]++
+		}
+	}
+	return counts
+}
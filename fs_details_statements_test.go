@@ -0,0 +1,100 @@
+package jquants
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFSDetail_Normalize(t *testing.T) {
+	ifrs := FSDetail{FS: map[string]string{
+		"Accounting standards, DEI":                             "IFRS",
+		"Current period end date, DEI":                          "2024-03-31",
+		"Revenue - 2 (IFRS)":                                    "45095325000000",
+		"Operating profit (loss) (IFRS)":                        "5352934000000",
+		"Profit (loss) attributable to owners of parent (IFRS)": "4944933000000",
+		"Assets (IFRS)":                                         "90114296000000",
+		"Equity attributable to owners of parent (IFRS)":        "34220991000000",
+		"Cash and cash equivalents (IFRS)":                      "9412060000000",
+		"Basic earnings (loss) per share (IFRS)":                "365.94",
+		"Goodwill (IFRS)":                                       "",
+		"Other components of equity (IFRS)":                     "1000",
+	}}
+	jgaap := FSDetail{FS: map[string]string{
+		"Accounting standards, DEI":                      "JapaneseGAAP",
+		"Net sales":                                      "1000000",
+		"Operating profit (loss)":                        "100000",
+		"Ordinary profit (loss)":                         "110000",
+		"Profit (loss) attributable to owners of parent": "70000",
+		"Assets":               "5000000",
+		"Net assets":           "2000000",
+		"Shareholders' equity": "1800000",
+		"Net cash provided by (used in) operating activities": "-30000",
+		"Provision for bonuses":                               "500",
+	}}
+
+	fs := ifrs.Normalize()
+	if fs.AccountingStandard != "IFRS" {
+		t.Errorf("AccountingStandard = %q", fs.AccountingStandard)
+	}
+	approx(t, "IFRS Revenue", fs.IncomeStatement.Revenue, 45095325000000)
+	approx(t, "IFRS OperatingProfit", fs.IncomeStatement.OperatingProfit, 5352934000000)
+	approx(t, "IFRS BasicEPS", fs.IncomeStatement.BasicEPS, 365.94)
+	approx(t, "IFRS TotalAssets", fs.BalanceSheet.TotalAssets, 90114296000000)
+	approx(t, "IFRS Cash", fs.Value(FSCashAndEquivalents), 9412060000000)
+	if fs.BalanceSheet.Goodwill != nil || fs.IncomeStatement.OrdinaryProfit != nil {
+		t.Error("empty and missing values should be nil")
+	}
+	if !slices.Equal(fs.Unmapped, []string{"Other components of equity (IFRS)"}) {
+		t.Errorf("IFRS Unmapped = %v", fs.Unmapped)
+	}
+
+	fs = jgaap.Normalize()
+	approx(t, "JGAAP Revenue", fs.IncomeStatement.Revenue, 1000000)
+	approx(t, "JGAAP OrdinaryProfit", fs.IncomeStatement.OrdinaryProfit, 110000)
+	approx(t, "JGAAP ProfitAttributableToOwners", fs.IncomeStatement.ProfitAttributableToOwners, 70000)
+	approx(t, "JGAAP TotalEquity", fs.BalanceSheet.TotalEquity, 2000000)
+	approx(t, "JGAAP ShareholdersEquity", fs.BalanceSheet.ShareholdersEquity, 1800000)
+	// 株主資本はその他の包括利益累計額を含まないため、親会社の所有者に帰属する持分には対応付けない
+	if fs.BalanceSheet.EquityAttributableToOwners != nil {
+		t.Errorf("JGAAP EquityAttributableToOwners = %v, want nil", *fs.BalanceSheet.EquityAttributableToOwners)
+	}
+	approx(t, "JGAAP OperatingCashFlow", fs.CashFlow.OperatingCashFlow, -30000)
+	if !slices.Equal(fs.Unmapped, []string{"Provision for bonuses"}) {
+		t.Errorf("JGAAP Unmapped = %v", fs.Unmapped)
+	}
+
+	counts := CountUnmappedFSKeys([]FSDetail{ifrs, jgaap, jgaap})
+	if counts["Provision for bonuses"] != 2 || counts["Other components of equity (IFRS)"] != 1 || len(counts) != 2 {
+		t.Errorf("CountUnmappedFSKeys() = %v", counts)
+	}
+	if (&FinancialStatements{}).Value("Unknown") != nil {
+		t.Error("Value(Unknown) should be nil")
+	}
+}
+
+func TestFSDetail_Normalize_WithFSLabels(t *testing.T) {
+	d := FSDetail{FS: map[string]string{"Selling, general and administrative expenses - 2": "300"}}
+	if fs := d.Normalize(); fs.IncomeStatement.SellingGeneralAdmin != nil || len(fs.Unmapped) != 1 {
+		t.Fatalf("default labels: %+v", fs)
+	}
+
+	labels := DefaultFSLabels()
+	labels.Add(FSSellingGeneralAdmin, "Selling, general and administrative expenses - 2")
+	labels.Add(FSSellingGeneralAdmin, "Selling, general and administrative expenses - 2")
+	if got, want := labels[FSSellingGeneralAdmin], FSLabels(FSSellingGeneralAdmin); len(got) != len(want)+1 {
+		t.Errorf("labels = %v, want %v and the added label", got, want)
+	}
+	fs := d.Normalize(WithFSLabels(labels))
+	approx(t, "SellingGeneralAdmin", fs.IncomeStatement.SellingGeneralAdmin, 300)
+	if len(fs.Unmapped) != 0 {
+		t.Errorf("Unmapped = %v", fs.Unmapped)
+	}
+	if counts := CountUnmappedFSKeys([]FSDetail{d}, WithFSLabels(labels)); len(counts) != 0 {
+		t.Errorf("CountUnmappedFSKeys() = %v", counts)
+	}
+
+	// 複製への追加は組み込みの対応表に影響しない
+	if fs := d.Normalize(); fs.IncomeStatement.SellingGeneralAdmin != nil {
+		t.Error("Add should not modify the default labels")
+	}
+}
@@ -0,0 +1,192 @@
+package jquants
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/utahta/jquants/types"
+)
+
+// 最後に同期した開示日を保存するキー
+const fsDetailsSyncDateKey = "fins/details/date"
+
+// FSDetailStore は差分同期した財務諸表詳細情報とカーソルを保存するストアです。
+type FSDetailStore interface {
+	CursorStore
+	// PutFSDetails は財務諸表詳細情報を保存します。同じDiscNoのレコードが既にある場合は置き換えます。
+	// 中断後の再開時には保存済みのレコードが再度渡されることがあるため、冪等に実装してください。
+	PutFSDetails(ctx context.Context, details []FSDetail) error
+}
+
+// FSDetailsSyncResult はSyncで取得した差分の概要です。
+type FSDetailsSyncResult struct {
+	Dates   []string // 取得した開示日（YYYY-MM-DD形式、古い順）
+	Records int      // 保存したレコード数
+	DiscNos []string // 追加・更新された開示番号（昇順）
+	Codes   []string // 追加・更新された銘柄コード（昇順）
+}
+
+// FSDetailsSyncOption はSyncの動作を設定するオプションです。
+type FSDetailsSyncOption func(*fsDetailsSyncConfig)
+
+type fsDetailsSyncConfig struct {
+	startDate types.Date
+	now       func() time.Time
+}
+
+// WithFSDetailsSyncStartDate は初回の同期で取得を開始する開示日を設定します。
+// 指定しない場合は当日（日本時間）から取得します。前回の同期日が保存されている場合は使用しません。
+func WithFSDetailsSyncStartDate(d types.Date) FSDetailsSyncOption {
+	return func(c *fsDetailsSyncConfig) {
+		c.startDate = d
+	}
+}
+
+// Sync は前回の同期以降に追加された財務諸表詳細情報を取得し、storeへ保存します。
+//
+// 開示日ごとに差分取得用カーソル（cursor）を使用し、カーソルは "fins/details/YYYY-MM-DD"、
+// 最後に同期した開示日は "fins/details/date" のキーでstoreへ保存します。
+// 前回の同期日から当日（日本時間）までを1日ずつ取得するため、日付をまたいだ場合も前回の同期日の残りを取りこぼしません。
+//
+// レコードを保存してからカーソルを保存するため、途中で停止した場合は再実行時に同じレコードを再度取得します
+// （少なくとも1回の保存を保証し、重複はPutFSDetailsで吸収します）。
+// エラーの場合も、それまでに同期した開示日の結果を返します。
+func (s *FSDetailsService) Sync(ctx context.Context, store FSDetailStore, opts ...FSDetailsSyncOption) (*FSDetailsSyncResult, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store parameter is required")
+	}
+	cfg := fsDetailsSyncConfig{now: time.Now}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	today := types.DateOf(cfg.now())
+	start := today
+	last, err := store.LoadCursor(ctx, fsDetailsSyncDateKey)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case last != "":
+		if start, err = types.ParseDate(last); err != nil {
+			return nil, fmt.Errorf("invalid sync date %q: %w", last, err)
+		}
+	case !cfg.startDate.IsZero():
+		start = cfg.startDate
+	}
+	if start.After(today) {
+		start = today
+	}
+
+	state := &fsDetailsSync{service: s, store: store, discNos: map[string]struct{}{}, codes: map[string]struct{}{}}
+	for d := start; !d.After(today); d = d.AddDays(1) {
+		if err := state.date(ctx, d, last); err != nil {
+			return state.result(), err
+		}
+	}
+	return state.result(), nil
+}
+
+// fsDetailsSync はSync1回分の状態（同期した開示日、変更された開示番号・銘柄コード）を保持します。
+type fsDetailsSync struct {
+	service *FSDetailsService
+	store   FSDetailStore
+
+	dates   []string
+	records int
+	discNos map[string]struct{}
+	codes   map[string]struct{}
+}
+
+// date は開示日dのレコードをカーソル以降について取得して保存し、カーソルと同期日を保存します。
+// lastは保存済みの同期日です。
+func (s *fsDetailsSync) date(ctx context.Context, d types.Date, last string) error {
+	key := "fins/details/" + d.String()
+	cursor, err := s.store.LoadCursor(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	params := FSDetailsParams{Date: d, Cursor: cursor}
+	var details []FSDetail
+	next := cursor
+	// 同じパスを繰り返し取得するため、キャッシュを経由せずに最新の一覧を取得する
+	for detail, err := range s.service.allFSDetails(ctx, params, true, &next) {
+		if err != nil {
+			return err
+		}
+		details = append(details, detail)
+	}
+
+	if len(details) > 0 {
+		if err := s.store.PutFSDetails(ctx, details); err != nil {
+			return fmt.Errorf("failed to put fs details: %w", err)
+		}
+	}
+	if next != cursor {
+		if err := s.store.SaveCursor(ctx, key, next); err != nil {
+			return err
+		}
+	}
+	if d.String() != last {
+		if err := s.store.SaveCursor(ctx, fsDetailsSyncDateKey, d.String()); err != nil {
+			return err
+		}
+	}
+
+	s.dates = append(s.dates, d.String())
+	s.records += len(details)
+	for _, detail := range details {
+		s.discNos[detail.DiscNo] = struct{}{}
+		s.codes[detail.Code] = struct{}{}
+	}
+	return nil
+}
+
+func (s *fsDetailsSync) result() *FSDetailsSyncResult {
+	return &FSDetailsSyncResult{
+		Dates:   s.dates,
+		Records: s.records,
+		DiscNos: slices.Sorted(maps.Keys(s.discNos)),
+		Codes:   slices.Sorted(maps.Keys(s.codes)),
+	}
+}
+
+// MemoryFSDetailStore は財務諸表詳細情報とカーソルをメモリに保持するFSDetailStoreです。
+// プロセスの終了とともに失われます。
+type MemoryFSDetailStore struct {
+	*MemoryCursorStore
+
+	mu      sync.Mutex
+	details map[string]FSDetail
+}
+
+// NewMemoryFSDetailStore は新しいMemoryFSDetailStoreを作成します。
+func NewMemoryFSDetailStore() *MemoryFSDetailStore {
+	return &MemoryFSDetailStore{MemoryCursorStore: NewMemoryCursorStore(), details: map[string]FSDetail{}}
+}
+
+// PutFSDetails はFSDetailStoreを実装します。
+func (s *MemoryFSDetailStore) PutFSDetails(_ context.Context, details []FSDetail) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range details {
+		s.details[d.DiscNo] = d
+	}
+	return nil
+}
+
+// FSDetails は保存済みの財務諸表詳細情報を開示番号の昇順で返します。
+func (s *MemoryFSDetailStore) FSDetails() []FSDetail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	details := make([]FSDetail, 0, len(s.details))
+	for _, discNo := range slices.Sorted(maps.Keys(s.details)) {
+		details = append(details, s.details[discNo])
+	}
+	return details
+}
@@ -0,0 +1,157 @@
+package jquants
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants/types"
+)
+
+// failingFSDetailStore はPutFSDetailsでエラーを返すFSDetailStoreです。
+type failingFSDetailStore struct {
+	*MemoryFSDetailStore
+	err error
+}
+
+func (s *failingFSDetailStore) PutFSDetails(ctx context.Context, details []FSDetail) error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.MemoryFSDetailStore.PutFSDetails(ctx, details)
+}
+
+func fsDetail(discNo, code string) FSDetail {
+	return FSDetail{DiscNo: discNo, Code: code, FS: map[string]string{}}
+}
+
+func TestFSDetailsService_Sync(t *testing.T) {
+	c := &scriptClient{fn: func(path string) (interface{}, error) {
+		switch path {
+		case "/fins/details?date=20250106":
+			return FSDetailsResponse{Data: []FSDetail{fsDetail("A", "72030")}, PaginationKey: "p1"}, nil
+		case "/fins/details?date=20250106&pagination_key=p1":
+			return FSDetailsResponse{Data: []FSDetail{fsDetail("B", "86970")}}, nil
+		case "/fins/details?date=20250107":
+			return FSDetailsResponse{Data: []FSDetail{fsDetail("C", "72030")}, Cursor: "c1"}, nil
+		case "/fins/details?date=20250107&cursor=c1":
+			// 前回取得済みのCが再度含まれても冪等に保存する
+			return FSDetailsResponse{Data: []FSDetail{fsDetail("C", "72030"), fsDetail("D", "99840")}, Cursor: "c2"}, nil
+		case "/fins/details?date=20250108":
+			return FSDetailsResponse{Cursor: "c3"}, nil
+		}
+		return nil, errors.New("unexpected path: " + path)
+	}}
+	service := NewFSDetailsService(c)
+	store := NewMemoryFSDetailStore()
+	at := func(day int) FSDetailsSyncOption {
+		return func(c *fsDetailsSyncConfig) {
+			c.now = func() time.Time { return time.Date(2025, 1, day, 18, 0, 0, 0, types.JST) }
+		}
+	}
+
+	res, err := service.Sync(context.Background(), store, at(7), WithFSDetailsSyncStartDate(types.NewDate(2025, 1, 6)))
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(res.Dates, []string{"2025-01-06", "2025-01-07"}) || res.Records != 3 {
+		t.Errorf("result = %+v", res)
+	}
+	if !slices.Equal(res.DiscNos, []string{"A", "B", "C"}) || !slices.Equal(res.Codes, []string{"72030", "86970"}) {
+		t.Errorf("DiscNos = %v, Codes = %v", res.DiscNos, res.Codes)
+	}
+	if cursor, _ := store.LoadCursor(context.Background(), "fins/details/2025-01-07"); cursor != "c1" {
+		t.Errorf("saved cursor = %q, want c1", cursor)
+	}
+
+	// 翌日は前回の同期日の続きをカーソルで取得してから当日分を取得する
+	c.paths = nil
+	res, err = service.Sync(context.Background(), store, at(8), WithFSDetailsSyncStartDate(types.NewDate(2025, 1, 1)))
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	want := []string{"/fins/details?date=20250107&cursor=c1", "/fins/details?date=20250108"}
+	if !slices.Equal(c.paths, want) {
+		t.Errorf("paths = %v, want %v", c.paths, want)
+	}
+	if !slices.Equal(res.DiscNos, []string{"C", "D"}) || !slices.Equal(res.Codes, []string{"72030", "99840"}) {
+		t.Errorf("DiscNos = %v, Codes = %v", res.DiscNos, res.Codes)
+	}
+	if got := store.FSDetails(); len(got) != 4 || got[3].DiscNo != "D" {
+		t.Errorf("stored = %+v", got)
+	}
+	if date, _ := store.LoadCursor(context.Background(), fsDetailsSyncDateKey); date != "2025-01-08" {
+		t.Errorf("sync date = %q", date)
+	}
+}
+
+func TestFSDetailsService_Sync_CachingClient(t *testing.T) {
+	published := false
+	sc := &scriptClient{fn: func(path string) (interface{}, error) {
+		// 開示が出るまではcursorが付かないため、次のSyncでも同じパスを取得する
+		if !published {
+			return FSDetailsResponse{}, nil
+		}
+		return FSDetailsResponse{Data: []FSDetail{fsDetail("A", "72030")}, Cursor: "c1"}, nil
+	}}
+	service := NewFSDetailsService(newCachingClient(sc))
+	store := NewMemoryFSDetailStore()
+	now := func(c *fsDetailsSyncConfig) {
+		c.now = func() time.Time { return time.Date(2025, 1, 6, 18, 0, 0, 0, types.JST) }
+	}
+
+	if _, err := service.Sync(context.Background(), store, now); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	published = true
+	res, err := service.Sync(context.Background(), store, now)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(res.DiscNos, []string{"A"}) {
+		t.Errorf("DiscNos = %v, want [A]", res.DiscNos)
+	}
+}
+
+func TestFSDetailsService_Sync_Resume(t *testing.T) {
+	c := &scriptClient{fn: func(path string) (interface{}, error) {
+		if strings.Contains(path, "cursor=") {
+			return nil, errors.New("unexpected cursor: " + path)
+		}
+		return FSDetailsResponse{Data: []FSDetail{fsDetail("A", "72030")}, Cursor: "c1"}, nil
+	}}
+	service := NewFSDetailsService(c)
+	store := &failingFSDetailStore{MemoryFSDetailStore: NewMemoryFSDetailStore(), err: errors.New("disk full")}
+	now := func(c *fsDetailsSyncConfig) {
+		c.now = func() time.Time { return time.Date(2025, 1, 6, 18, 0, 0, 0, types.JST) }
+	}
+
+	// 保存に失敗した場合はカーソルを進めない
+	res, err := service.Sync(context.Background(), store, now)
+	if err == nil || !errors.Is(err, store.err) {
+		t.Fatalf("Sync() error = %v, want disk full", err)
+	}
+	if len(res.Dates) != 0 || len(res.DiscNos) != 0 {
+		t.Errorf("result = %+v", res)
+	}
+	if cursor, _ := store.LoadCursor(context.Background(), "fins/details/2025-01-06"); cursor != "" {
+		t.Errorf("saved cursor = %q, want empty", cursor)
+	}
+
+	// 再実行すると同じ範囲を取得し直す
+	store.err = nil
+	res, err = service.Sync(context.Background(), store, now)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(res.DiscNos, []string{"A"}) || len(store.FSDetails()) != 1 {
+		t.Errorf("result = %+v", res)
+	}
+
+	if _, err := service.Sync(context.Background(), nil); err == nil {
+		t.Error("Sync(nil) expected error")
+	}
+}
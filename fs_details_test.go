@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestFSDetailsService_GetFSDetails(t *testing.T) {
@@ -19,7 +20,7 @@ func TestFSDetailsService_GetFSDetails(t *testing.T) {
 			name: "with code and date",
 			params: FSDetailsParams{
 				Code: "86970",
-				Date: "20230130",
+				Date: types.MustParseDate("20230130"),
 			},
 			wantPath: "/fins/details?code=86970&date=20230130",
 		},
@@ -33,9 +34,9 @@ func TestFSDetailsService_GetFSDetails(t *testing.T) {
 		{
 			name: "with date only",
 			params: FSDetailsParams{
-				Date: "2023-01-30",
+				Date: types.MustParseDate("2023-01-30"),
 			},
-			wantPath: "/fins/details?date=2023-01-30",
+			wantPath: "/fins/details?date=20230130",
 		},
 		{
 			name: "with pagination key",
@@ -48,10 +49,10 @@ func TestFSDetailsService_GetFSDetails(t *testing.T) {
 		{
 			name: "with date and cursor",
 			params: FSDetailsParams{
-				Date:   "2023-01-30",
+				Date:   types.MustParseDate("2023-01-30"),
 				Cursor: "cur123",
 			},
-			wantPath: "/fins/details?date=2023-01-30&cursor=cur123",
+			wantPath: "/fins/details?date=20230130&cursor=cur123",
 		},
 		{
 			name:     "with no required parameters",
@@ -62,7 +63,7 @@ func TestFSDetailsService_GetFSDetails(t *testing.T) {
 		{
 			name: "with cursor and pagination key",
 			params: FSDetailsParams{
-				Date:          "2023-01-30",
+				Date:          types.MustParseDate("2023-01-30"),
 				Cursor:        "cur123",
 				PaginationKey: "key123",
 			},
@@ -81,7 +82,7 @@ func TestFSDetailsService_GetFSDetails(t *testing.T) {
 			mockResponse := FSDetailsResponse{
 				Data: []FSDetail{
 					{
-						DiscDate: "2023-01-30",
+						DiscDate: types.MustParseDate("2023-01-30"),
 						DiscTime: "12:00:00",
 						Code:     "86970",
 						DiscNo:   "20230127594871",
@@ -170,7 +171,7 @@ func TestFSDetailsService_GetFSDetailsByCode(t *testing.T) {
 	mockResponse1 := FSDetailsResponse{
 		Data: []FSDetail{
 			{
-				DiscDate: "2023-01-30",
+				DiscDate: types.MustParseDate("2023-01-30"),
 				Code:     "86970",
 				DocType:  "3QFinancialStatements_Consolidated_IFRS",
 				FS: map[string]string{
@@ -178,7 +179,7 @@ func TestFSDetailsService_GetFSDetailsByCode(t *testing.T) {
 				},
 			},
 			{
-				DiscDate: "2022-10-31",
+				DiscDate: types.MustParseDate("2022-10-31"),
 				Code:     "86970",
 				DocType:  "2QFinancialStatements_Consolidated_IFRS",
 				FS: map[string]string{
@@ -193,7 +194,7 @@ func TestFSDetailsService_GetFSDetailsByCode(t *testing.T) {
 	mockResponse2 := FSDetailsResponse{
 		Data: []FSDetail{
 			{
-				DiscDate: "2022-07-29",
+				DiscDate: types.MustParseDate("2022-07-29"),
 				Code:     "86970",
 				DocType:  "1QFinancialStatements_Consolidated_IFRS",
 				FS: map[string]string{
@@ -233,11 +234,11 @@ func TestFSDetailsService_GetFSDetailsByDate(t *testing.T) {
 	mockResponse := FSDetailsResponse{
 		Data: []FSDetail{
 			{
-				DiscDate: "2023-01-30",
+				DiscDate: types.MustParseDate("2023-01-30"),
 				Code:     "86970",
 			},
 			{
-				DiscDate: "2023-01-30",
+				DiscDate: types.MustParseDate("2023-01-30"),
 				Code:     "13010",
 			},
 		},
@@ -246,7 +247,7 @@ func TestFSDetailsService_GetFSDetailsByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/fins/details?date=20230130", mockResponse)
 
 	// Execute
-	data, err := service.GetFSDetailsByDate(context.Background(), "20230130")
+	data, err := service.GetFSDetailsByDate(context.Background(), types.MustParseDate("20230130"))
 
 	// Verify
 	if err != nil {
@@ -256,7 +257,7 @@ func TestFSDetailsService_GetFSDetailsByDate(t *testing.T) {
 		t.Errorf("GetFSDetailsByDate() returned %d items, want 2", len(data))
 	}
 	for _, item := range data {
-		if item.DiscDate != "2023-01-30" {
+		if item.DiscDate.String() != "2023-01-30" {
 			t.Errorf("GetFSDetailsByDate() returned date %v, want 2023-01-30", item.DiscDate)
 		}
 	}
@@ -271,7 +272,7 @@ func TestFSDetailsService_GetFSDetailsByCodeAndDate(t *testing.T) {
 	mockResponse := FSDetailsResponse{
 		Data: []FSDetail{
 			{
-				DiscDate: "2023-01-30",
+				DiscDate: types.MustParseDate("2023-01-30"),
 				Code:     "86970",
 				DocType:  "3QFinancialStatements_Consolidated_IFRS",
 				FS: map[string]string{
@@ -284,7 +285,7 @@ func TestFSDetailsService_GetFSDetailsByCodeAndDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/fins/details?code=86970&date=20230130", mockResponse)
 
 	// Execute
-	data, err := service.GetFSDetailsByCodeAndDate(context.Background(), "86970", "20230130")
+	data, err := service.GetFSDetailsByCodeAndDate(context.Background(), "86970", types.MustParseDate("20230130"))
 
 	// Verify
 	if err != nil {
@@ -296,7 +297,7 @@ func TestFSDetailsService_GetFSDetailsByCodeAndDate(t *testing.T) {
 	if data[0].Code != "86970" {
 		t.Errorf("GetFSDetailsByCodeAndDate() returned code %v, want 86970", data[0].Code)
 	}
-	if data[0].DiscDate != "2023-01-30" {
+	if data[0].DiscDate.String() != "2023-01-30" {
 		t.Errorf("GetFSDetailsByCodeAndDate() returned date %v, want 2023-01-30", data[0].DiscDate)
 	}
 }
@@ -648,7 +649,7 @@ func TestFSDetailsService_GetFSDetails_CursorSkipsCache(t *testing.T) {
 	mockClient.SetResponse("GET", "/fins/details?date=20230130", FSDetailsResponse{})
 
 	// cursorによるポーリングはキャッシュをバイパスすること
-	if _, err := service.GetFSDetails(context.Background(), FSDetailsParams{Date: "20230130", Cursor: "cur123"}); err != nil {
+	if _, err := service.GetFSDetails(context.Background(), FSDetailsParams{Date: types.MustParseDate("20230130"), Cursor: "cur123"}); err != nil {
 		t.Fatalf("GetFSDetails() error = %v", err)
 	}
 	if !mockClient.LastSkipCache {
@@ -656,10 +657,35 @@ func TestFSDetailsService_GetFSDetails_CursorSkipsCache(t *testing.T) {
 	}
 
 	// cursorなしは通常どおりキャッシュ対象
-	if _, err := service.GetFSDetails(context.Background(), FSDetailsParams{Date: "20230130"}); err != nil {
+	if _, err := service.GetFSDetails(context.Background(), FSDetailsParams{Date: types.MustParseDate("20230130")}); err != nil {
 		t.Fatalf("GetFSDetails() error = %v", err)
 	}
 	if mockClient.LastSkipCache {
 		t.Error("GetFSDetails() without cursor should use the session cache")
 	}
 }
+
+func TestFSDetailsService_AllFSDetails_CursorPagination(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewFSDetailsService(mockClient)
+	mockClient.SetResponse("GET", "/fins/details?date=20230130&cursor=cur123", FSDetailsResponse{
+		Data:          []FSDetail{{DiscNo: "A"}},
+		PaginationKey: "p1",
+	})
+	mockClient.SetResponse("GET", "/fins/details?date=20230130&pagination_key=p1", FSDetailsResponse{
+		Data:   []FSDetail{{DiscNo: "B"}},
+		Cursor: "cur456",
+	})
+
+	// 2ページ目はcursorを外してpagination_keyのみで取得すること
+	got, err := collectAll(service.AllFSDetails(context.Background(), FSDetailsParams{Date: types.MustParseDate("20230130"), Cursor: "cur123"}))
+	if err != nil {
+		t.Fatalf("AllFSDetails() error = %v", err)
+	}
+	if len(got) != 2 || got[0].DiscNo != "A" || got[1].DiscNo != "B" {
+		t.Errorf("AllFSDetails() = %+v, want [A B]", got)
+	}
+	if mockClient.LastPath != "/fins/details?date=20230130&pagination_key=p1" {
+		t.Errorf("last path = %q", mockClient.LastPath)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -25,10 +26,10 @@ func NewFuturesService(c client.HTTPClient) *FuturesService {
 
 // FuturesParams は先物四本値のリクエストパラメータです。
 type FuturesParams struct {
-	Date          string // 取引日（必須）（YYYYMMDD または YYYY-MM-DD）
-	Category      string // 商品区分の指定（TOPIXF, NK225F等）
-	ContractFlag  string // 中心限月フラグの指定（1: 中心限月のみ）
-	PaginationKey string // ページネーションキー
+	Date          types.Date // 取引日（必須）
+	Category      string     // 商品区分の指定（TOPIXF, NK225F等）
+	ContractFlag  string     // 中心限月フラグの指定（1: 中心限月のみ）
+	PaginationKey string     // ページネーションキー
 }
 
 // FuturesResponse は先物四本値のレスポンスです。
@@ -43,11 +44,11 @@ type FuturesResponse struct {
 // 注意: このデータはプレミアムプラン専用APIで取得されます。
 type Futures struct {
 	// 基本情報
-	Code         string `json:"Code"`         // 銘柄コード
-	ProdCat      string `json:"ProdCat"`      // 先物商品区分
-	Date         string `json:"Date"`         // 取引日（YYYY-MM-DD形式）
-	CM           string `json:"CM"`           // 限月（YYYY-MM形式）
-	EmMrgnTrgDiv string `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 清算価格算出時）
+	Code         string     `json:"Code"`         // 銘柄コード
+	ProdCat      string     `json:"ProdCat"`      // 先物商品区分
+	Date         types.Date `json:"Date"`         // 取引日
+	CM           string     `json:"CM"`           // 限月（YYYY-MM形式）
+	EmMrgnTrgDiv string     `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 清算価格算出時）
 
 	// 日通し四本値
 	O float64 `json:"O"` // 日通し始値
@@ -90,7 +91,7 @@ type Futures struct {
 type RawFutures struct {
 	Code         string                `json:"Code"`
 	ProdCat      string                `json:"ProdCat"`
-	Date         string                `json:"Date"`
+	Date         types.Date            `json:"Date"`
 	CM           string                `json:"CM"`
 	EmMrgnTrgDiv string                `json:"EmMrgnTrgDiv"`
 	O            float64               `json:"O"`
@@ -184,24 +185,22 @@ func (r *FuturesResponse) UnmarshalJSON(data []byte) error {
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *FuturesService) GetFutures(ctx context.Context, params FuturesParams) (*FuturesResponse, error) {
 	// dateは必須パラメータ
-	if params.Date == "" {
+	if params.Date.IsZero() {
 		return nil, fmt.Errorf("date parameter is required")
 	}
 
 	path := "/derivatives/bars/daily/futures"
 
-	query := fmt.Sprintf("?date=%s", params.Date)
-	if params.Category != "" {
-		query += fmt.Sprintf("&category=%s", params.Category)
-	}
-	if params.ContractFlag != "" {
-		query += fmt.Sprintf("&contract_flag=%s", params.ContractFlag)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("&pagination_key=%s", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setDate("date", params.Date)
+	query.set("category", params.Category)
+	query.set("contract_flag", params.ContractFlag)
+	query.set("pagination_key", params.PaginationKey)
 
-	path += query
+	if err := query.err(); err != nil {
+		return nil, err
+	}
+	path += query.encode()
 
 	var resp FuturesResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -211,98 +210,49 @@ func (s *FuturesService) GetFutures(ctx context.Context, params FuturesParams) (
 	return &resp, nil
 }
 
+// AllFutures は指定条件の先物四本値を1件ずつ返すイテレータです。
+func (s *FuturesService) AllFutures(ctx context.Context, params FuturesParams) iter.Seq2[Futures, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Futures, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetFutures(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetFuturesByDate は指定日の全先物データを取得します。
 // ページネーションを使用して全データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *FuturesService) GetFuturesByDate(ctx context.Context, date string) ([]Futures, error) {
-	var allData []Futures
-	paginationKey := ""
-
-	for {
-		params := FuturesParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetFutures(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *FuturesService) GetFuturesByDate(ctx context.Context, date types.Date) ([]Futures, error) {
+	return collectAll(s.AllFutures(ctx, FuturesParams{
+		Date: date,
+	}))
 }
 
 // GetFuturesByCategory は指定日・商品カテゴリの先物データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *FuturesService) GetFuturesByCategory(ctx context.Context, date, category string) ([]Futures, error) {
-	var allData []Futures
-	paginationKey := ""
-
-	for {
-		params := FuturesParams{
-			Date:          date,
-			Category:      category,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetFutures(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *FuturesService) GetFuturesByCategory(ctx context.Context, date types.Date, category string) ([]Futures, error) {
+	return collectAll(s.AllFutures(ctx, FuturesParams{
+		Date:     date,
+		Category: category,
+	}))
 }
 
 // GetCentralContractMonthFutures は中心限月の先物データのみを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *FuturesService) GetCentralContractMonthFutures(ctx context.Context, date string) ([]Futures, error) {
-	var allData []Futures
-	paginationKey := ""
-
-	for {
-		params := FuturesParams{
-			Date:          date,
-			ContractFlag:  "1",
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetFutures(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *FuturesService) GetCentralContractMonthFutures(ctx context.Context, date types.Date) ([]Futures, error) {
+	return collectAll(s.AllFutures(ctx, FuturesParams{
+		Date:         date,
+		ContractFlag: "1",
+	}))
 }
 
 // Helper methods for Futures
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestFuturesService_GetFutures(t *testing.T) {
@@ -18,7 +19,7 @@ func TestFuturesService_GetFutures(t *testing.T) {
 		{
 			name: "with all parameters",
 			params: FuturesParams{
-				Date:          "20240723",
+				Date:          types.MustParseDate("20240723"),
 				Category:      "TOPIXF",
 				ContractFlag:  "1",
 				PaginationKey: "key123",
@@ -28,7 +29,7 @@ func TestFuturesService_GetFutures(t *testing.T) {
 		{
 			name: "with date and category",
 			params: FuturesParams{
-				Date:     "20240723",
+				Date:     types.MustParseDate("20240723"),
 				Category: "NK225F",
 			},
 			wantPath: "/derivatives/bars/daily/futures?date=20240723&category=NK225F",
@@ -36,14 +37,14 @@ func TestFuturesService_GetFutures(t *testing.T) {
 		{
 			name: "with date only",
 			params: FuturesParams{
-				Date: "2024-07-23",
+				Date: types.MustParseDate("2024-07-23"),
 			},
-			wantPath: "/derivatives/bars/daily/futures?date=2024-07-23",
+			wantPath: "/derivatives/bars/daily/futures?date=20240723",
 		},
 		{
 			name: "with date and central contract flag",
 			params: FuturesParams{
-				Date:         "20240723",
+				Date:         types.MustParseDate("20240723"),
 				ContractFlag: "1",
 			},
 			wantPath: "/derivatives/bars/daily/futures?date=20240723&contract_flag=1",
@@ -68,7 +69,7 @@ func TestFuturesService_GetFutures(t *testing.T) {
 					{
 						Code:         "169090005",
 						ProdCat:      "TOPIXF",
-						Date:         "2024-07-23",
+						Date:         types.MustParseDate("2024-07-23"),
 						CM:           "2024-09",
 						EmMrgnTrgDiv: "002",
 						O:            2825.5,
@@ -159,13 +160,13 @@ func TestFuturesService_GetFuturesByDate(t *testing.T) {
 			{
 				Code:    "169090005",
 				ProdCat: "TOPIXF",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-09",
 			},
 			{
 				Code:    "169120005",
 				ProdCat: "TOPIXF",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-12",
 			},
 		},
@@ -178,7 +179,7 @@ func TestFuturesService_GetFuturesByDate(t *testing.T) {
 			{
 				Code:    "167090018",
 				ProdCat: "NK225F",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-09",
 			},
 		},
@@ -189,7 +190,7 @@ func TestFuturesService_GetFuturesByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/futures?date=20240723&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	data, err := service.GetFuturesByDate(context.Background(), "20240723")
+	data, err := service.GetFuturesByDate(context.Background(), types.MustParseDate("20240723"))
 
 	// Verify
 	if err != nil {
@@ -199,7 +200,7 @@ func TestFuturesService_GetFuturesByDate(t *testing.T) {
 		t.Errorf("GetFuturesByDate() returned %d items, want 3", len(data))
 	}
 	for _, item := range data {
-		if item.Date != "2024-07-23" {
+		if item.Date.String() != "2024-07-23" {
 			t.Errorf("GetFuturesByDate() returned date %v, want 2024-07-23", item.Date)
 		}
 	}
@@ -216,13 +217,13 @@ func TestFuturesService_GetFuturesByCategory(t *testing.T) {
 			{
 				Code:    "167090018",
 				ProdCat: "NK225F",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-09",
 			},
 			{
 				Code:    "167120018",
 				ProdCat: "NK225F",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-12",
 			},
 		},
@@ -231,7 +232,7 @@ func TestFuturesService_GetFuturesByCategory(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/futures?date=20240723&category=NK225F", mockResponse)
 
 	// Execute
-	data, err := service.GetFuturesByCategory(context.Background(), "20240723", "NK225F")
+	data, err := service.GetFuturesByCategory(context.Background(), types.MustParseDate("20240723"), "NK225F")
 
 	// Verify
 	if err != nil {
@@ -258,14 +259,14 @@ func TestFuturesService_GetCentralContractMonthFutures(t *testing.T) {
 			{
 				Code:    "167090018",
 				ProdCat: "NK225F",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-09",
 				CCMFlag: stringPtr("1"),
 			},
 			{
 				Code:    "169090005",
 				ProdCat: "TOPIXF",
-				Date:    "2024-07-23",
+				Date:    types.MustParseDate("2024-07-23"),
 				CM:      "2024-09",
 				CCMFlag: stringPtr("1"),
 			},
@@ -275,7 +276,7 @@ func TestFuturesService_GetCentralContractMonthFutures(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/futures?date=20240723&contract_flag=1", mockResponse)
 
 	// Execute
-	data, err := service.GetCentralContractMonthFutures(context.Background(), "20240723")
+	data, err := service.GetCentralContractMonthFutures(context.Background(), types.MustParseDate("20240723"))
 
 	// Verify
 	if err != nil {
@@ -541,7 +542,7 @@ func TestFuturesService_GetFutures_Error(t *testing.T) {
 	mockClient.SetError("GET", "/derivatives/bars/daily/futures?date=20240723", fmt.Errorf("unauthorized"))
 
 	// Execute
-	_, err := service.GetFutures(context.Background(), FuturesParams{Date: "20240723"})
+	_, err := service.GetFutures(context.Background(), FuturesParams{Date: types.MustParseDate("20240723")})
 
 	// Verify
 	if err == nil {
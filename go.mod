@@ -2,7 +2,6 @@ module github.com/utahta/jquants
 
 go 1.24.0
 
-require (
-	golang.org/x/net v0.41.0 // indirect
-	golang.org/x/sync v0.19.0 // indirect
-)
+require golang.org/x/sync v0.19.0
+
+require golang.org/x/net v0.41.0 // indirect
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -23,14 +24,14 @@ func NewIndexOptionService(c client.HTTPClient) *IndexOptionService {
 // J-Quants API /derivatives/bars/daily/options/225 エンドポイントのレスポンスデータ。
 type IndexOption struct {
 	// 基本情報
-	Date         string  `json:"Date"`         // 取引日（YYYY-MM-DD形式）
-	Code         string  `json:"Code"`         // 銘柄コード
-	CM           string  `json:"CM"`           // 限月（YYYY-MM形式）
-	Strike       float64 `json:"Strike"`       // 権利行使価格
-	PCDiv        string  `json:"PCDiv"`        // プットコール区分（1: プット、2: コール）
-	LTD          string  `json:"LTD"`          // 取引最終年月日（YYYY-MM-DD形式）
-	SQD          string  `json:"SQD"`          // SQ日（YYYY-MM-DD形式）
-	EmMrgnTrgDiv string  `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 通常時）
+	Date         types.Date `json:"Date"`         // 取引日
+	Code         string     `json:"Code"`         // 銘柄コード
+	CM           string     `json:"CM"`           // 限月（YYYY-MM形式）
+	Strike       float64    `json:"Strike"`       // 権利行使価格
+	PCDiv        string     `json:"PCDiv"`        // プットコール区分（1: プット、2: コール）
+	LTD          string     `json:"LTD"`          // 取引最終年月日（YYYY-MM-DD形式）
+	SQD          string     `json:"SQD"`          // SQ日（YYYY-MM-DD形式）
+	EmMrgnTrgDiv string     `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 通常時）
 
 	// 日通し四本値
 	O float64 `json:"O"` // 日通し始値
@@ -68,7 +69,7 @@ type IndexOption struct {
 // RawIndexOption is used for unmarshaling JSON response with mixed types
 type RawIndexOption struct {
 	// 基本情報
-	Date         string                `json:"Date"`
+	Date         types.Date            `json:"Date"`
 	Code         string                `json:"Code"`
 	CM           string                `json:"CM"`
 	Strike       types.NullableFloat64 `json:"Strike"`
@@ -118,8 +119,8 @@ type IndexOptionResponse struct {
 
 // IndexOptionParams は日経225オプションのリクエストパラメータです。
 type IndexOptionParams struct {
-	Date          string // 取引日（YYYYMMDD または YYYY-MM-DD）（必須）
-	PaginationKey string // ページネーションキー
+	Date          types.Date // 取引日（必須）
+	PaginationKey string     // ページネーションキー
 }
 
 // プットコール区分定数
@@ -203,18 +204,20 @@ func (r *IndexOptionResponse) UnmarshalJSON(data []byte) error {
 
 // GetIndexOptions は指定日の日経225オプションデータを取得します。
 func (s *IndexOptionService) GetIndexOptions(ctx context.Context, params IndexOptionParams) (*IndexOptionResponse, error) {
-	if params.Date == "" {
+	if params.Date.IsZero() {
 		return nil, fmt.Errorf("date parameter is required")
 	}
 
 	path := "/derivatives/bars/daily/options/225"
 
-	query := fmt.Sprintf("?date=%s", params.Date)
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("&pagination_key=%s", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setDate("date", params.Date)
+	query.set("pagination_key", params.PaginationKey)
 
-	path += query
+	if err := query.err(); err != nil {
+		return nil, err
+	}
+	path += query.encode()
 
 	var resp IndexOptionResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -224,37 +227,28 @@ func (s *IndexOptionService) GetIndexOptions(ctx context.Context, params IndexOp
 	return &resp, nil
 }
 
-// GetIndexOptionsByDate は指定日の全日経225オプションデータを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *IndexOptionService) GetIndexOptionsByDate(ctx context.Context, date string) ([]IndexOption, error) {
-	var allOptions []IndexOption
-	paginationKey := ""
-
-	for {
-		params := IndexOptionParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
+// AllIndexOptions は指定条件の日経225オプション四本値を1件ずつ返すイテレータです。
+func (s *IndexOptionService) AllIndexOptions(ctx context.Context, params IndexOptionParams) iter.Seq2[IndexOption, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]IndexOption, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetIndexOptions(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allOptions = append(allOptions, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allOptions, nil
+// GetIndexOptionsByDate は指定日の全日経225オプションデータを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *IndexOptionService) GetIndexOptionsByDate(ctx context.Context, date types.Date) ([]IndexOption, error) {
+	return collectAll(s.AllIndexOptions(ctx, IndexOptionParams{
+		Date: date,
+	}))
 }
 
 // GetCallOptions は指定日のコールオプションを取得します。
-func (s *IndexOptionService) GetCallOptions(ctx context.Context, date string) ([]IndexOption, error) {
+func (s *IndexOptionService) GetCallOptions(ctx context.Context, date types.Date) ([]IndexOption, error) {
 	options, err := s.GetIndexOptionsByDate(ctx, date)
 	if err != nil {
 		return nil, err
@@ -271,7 +265,7 @@ func (s *IndexOptionService) GetCallOptions(ctx context.Context, date string) ([
 }
 
 // GetPutOptions は指定日のプットオプションを取得します。
-func (s *IndexOptionService) GetPutOptions(ctx context.Context, date string) ([]IndexOption, error) {
+func (s *IndexOptionService) GetPutOptions(ctx context.Context, date types.Date) ([]IndexOption, error) {
 	options, err := s.GetIndexOptionsByDate(ctx, date)
 	if err != nil {
 		return nil, err
@@ -288,7 +282,7 @@ func (s *IndexOptionService) GetPutOptions(ctx context.Context, date string) ([]
 }
 
 // GetOptionChain は指定日のオプションチェーン（全ての権利行使価格）を取得します。
-func (s *IndexOptionService) GetOptionChain(ctx context.Context, date string) ([]IndexOption, error) {
+func (s *IndexOptionService) GetOptionChain(ctx context.Context, date types.Date) ([]IndexOption, error) {
 	return s.GetIndexOptionsByDate(ctx, date)
 }
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestIndexOptionService_GetIndexOptions(t *testing.T) {
@@ -17,7 +18,7 @@ func TestIndexOptionService_GetIndexOptions(t *testing.T) {
 		{
 			name: "with date and pagination key",
 			params: IndexOptionParams{
-				Date:          "20230322",
+				Date:          types.MustParseDate("20230322"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/derivatives/bars/daily/options/225?date=20230322&pagination_key=key123",
@@ -25,7 +26,7 @@ func TestIndexOptionService_GetIndexOptions(t *testing.T) {
 		{
 			name: "with date only",
 			params: IndexOptionParams{
-				Date: "20230322",
+				Date: types.MustParseDate("20230322"),
 			},
 			wantPath: "/derivatives/bars/daily/options/225?date=20230322",
 		},
@@ -41,7 +42,7 @@ func TestIndexOptionService_GetIndexOptions(t *testing.T) {
 			mockResponse := IndexOptionResponse{
 				Data: []IndexOption{
 					{
-						Date:         "2023-03-22",
+						Date:         types.MustParseDate("2023-03-22"),
 						Code:         "130060018",
 						CM:           "2025-06",
 						Strike:       20000.0,
@@ -124,14 +125,14 @@ func TestIndexOptionService_GetIndexOptionsByDate(t *testing.T) {
 	mockResponse1 := IndexOptionResponse{
 		Data: []IndexOption{
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060018",
 				PCDiv:        "1",
 				Strike:       20000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060019",
 				PCDiv:        "2",
 				Strike:       21000.0,
@@ -145,7 +146,7 @@ func TestIndexOptionService_GetIndexOptionsByDate(t *testing.T) {
 	mockResponse2 := IndexOptionResponse{
 		Data: []IndexOption{
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060020",
 				PCDiv:        "1",
 				Strike:       22000.0,
@@ -159,7 +160,7 @@ func TestIndexOptionService_GetIndexOptionsByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options/225?date=20230322&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	options, err := service.GetIndexOptionsByDate(context.Background(), "20230322")
+	options, err := service.GetIndexOptionsByDate(context.Background(), types.MustParseDate("20230322"))
 
 	// Verify
 	if err != nil {
@@ -179,21 +180,21 @@ func TestIndexOptionService_GetCallOptions(t *testing.T) {
 	mockResponse := IndexOptionResponse{
 		Data: []IndexOption{
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060018",
 				PCDiv:        "1", // Put
 				Strike:       20000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060019",
 				PCDiv:        "2", // Call
 				Strike:       21000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060020",
 				PCDiv:        "2", // Call
 				Strike:       22000.0,
@@ -205,7 +206,7 @@ func TestIndexOptionService_GetCallOptions(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options/225?date=20230322", mockResponse)
 
 	// Execute
-	callOptions, err := service.GetCallOptions(context.Background(), "20230322")
+	callOptions, err := service.GetCallOptions(context.Background(), types.MustParseDate("20230322"))
 
 	// Verify
 	if err != nil {
@@ -231,21 +232,21 @@ func TestIndexOptionService_GetPutOptions(t *testing.T) {
 	mockResponse := IndexOptionResponse{
 		Data: []IndexOption{
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060018",
 				PCDiv:        "1", // Put
 				Strike:       20000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060019",
 				PCDiv:        "2", // Call
 				Strike:       21000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060020",
 				PCDiv:        "1", // Put
 				Strike:       22000.0,
@@ -257,7 +258,7 @@ func TestIndexOptionService_GetPutOptions(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options/225?date=20230322", mockResponse)
 
 	// Execute
-	putOptions, err := service.GetPutOptions(context.Background(), "20230322")
+	putOptions, err := service.GetPutOptions(context.Background(), types.MustParseDate("20230322"))
 
 	// Verify
 	if err != nil {
@@ -283,14 +284,14 @@ func TestIndexOptionService_GetOptionChain(t *testing.T) {
 	mockResponse := IndexOptionResponse{
 		Data: []IndexOption{
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060018",
 				PCDiv:        "1",
 				Strike:       20000.0,
 				EmMrgnTrgDiv: "002",
 			},
 			{
-				Date:         "2023-03-22",
+				Date:         types.MustParseDate("2023-03-22"),
 				Code:         "130060019",
 				PCDiv:        "2",
 				Strike:       20000.0,
@@ -302,7 +303,7 @@ func TestIndexOptionService_GetOptionChain(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options/225?date=20230322", mockResponse)
 
 	// Execute
-	options, err := service.GetOptionChain(context.Background(), "20230322")
+	options, err := service.GetOptionChain(context.Background(), types.MustParseDate("20230322"))
 
 	// Verify
 	if err != nil {
@@ -450,7 +451,7 @@ func TestIndexOptionService_GetIndexOptions_Error(t *testing.T) {
 	mockClient.SetError("GET", "/derivatives/bars/daily/options/225?date=20230322", fmt.Errorf("unauthorized"))
 
 	// Execute
-	_, err := service.GetIndexOptions(context.Background(), IndexOptionParams{Date: "20230322"})
+	_, err := service.GetIndexOptions(context.Background(), IndexOptionParams{Date: types.MustParseDate("20230322")})
 
 	// Verify
 	if err == nil {
@@ -0,0 +1,109 @@
+// Package indicators は日次株価・株価分足からSMA・EMA・RSI・MACD・ボリンジャーバンド・ATR・VWAP・ボラティリティを求めます。
+//
+// 各指標は1本ずつ値を受け取るUpdateで逐次計算するため、配信中の分足にもそのまま使用できます。
+// 時系列全体に対してはApplyで入力と同じ長さの結果を求められます。
+//
+// 売買が成立しなかった本（終値がnil）は指標の計算に含めず、その本の結果はnilです。
+// 移動平均などの期間は売買が成立したn本で数えます。高値・安値がnilで終値がある本は、高値・安値に終値を使用します。
+//
+//	quotes, _ := jq.Quotes.GetDailyQuotesByCode(ctx, "7203")
+//	series := indicators.FromDailyQuotes(quotes, bars.Adjusted)
+//	rsi := indicators.Apply(series, indicators.NewRSI(14))
+//
+//	// 分足を1本ずつ反映する
+//	vwap := indicators.NewVWAP()
+//	v, ok := vwap.Update(indicators.FromMinuteQuote(mq))
+package indicators
+
+import (
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/bars"
+	"github.com/utahta/jquants/types"
+)
+
+// OHLCV は指標の計算に使用する1本分の四本値・出来高・売買代金です。売買が成立しなかった本の値はnilです。
+type OHLCV struct {
+	Date types.Date // 日付。VWAPは日付が変わるとリセットします
+	Time string     // 分足の時刻（HH:mm形式）。日足は空文字
+
+	O  *float64 // 始値
+	H  *float64 // 高値
+	L  *float64 // 安値
+	C  *float64 // 終値
+	Vo *float64 // 出来高
+	Va *float64 // 売買代金
+}
+
+// traded は売買が成立した本かどうかを返します。
+func (b *OHLCV) traded() bool {
+	return b.C != nil
+}
+
+// high は高値を返します。高値がない場合は終値を返します。
+func (b *OHLCV) high() float64 {
+	return or(b.H, *b.C)
+}
+
+// low は安値を返します。安値がない場合は終値を返します。
+func (b *OHLCV) low() float64 {
+	return or(b.L, *b.C)
+}
+
+// FromDailyQuotes は日次株価をfieldで指定した値（調整前または調整済み）に変換します。売買代金は常にVaを使用します。
+func FromDailyQuotes(quotes []jquants.DailyQuote, field bars.PriceField) []OHLCV {
+	series := make([]OHLCV, len(quotes))
+	for i, q := range quotes {
+		series[i] = OHLCV{Date: q.Date, O: q.O, H: q.H, L: q.L, C: q.C, Vo: q.Vo, Va: q.Va}
+		if field == bars.Adjusted {
+			series[i].O, series[i].H, series[i].L, series[i].C, series[i].Vo = q.AdjO, q.AdjH, q.AdjL, q.AdjC, q.AdjVo
+		}
+	}
+	return series
+}
+
+// FromMinuteQuote は株価分足を変換します。
+func FromMinuteQuote(q jquants.MinuteQuote) OHLCV {
+	return OHLCV{Date: q.Date, Time: q.Time, O: &q.O, H: &q.H, L: &q.L, C: &q.C, Vo: &q.Vo, Va: &q.Va}
+}
+
+// FromMinuteQuotes は株価分足を変換します。
+func FromMinuteQuotes(quotes []jquants.MinuteQuote) []OHLCV {
+	series := make([]OHLCV, len(quotes))
+	for i, q := range quotes {
+		series[i] = FromMinuteQuote(q)
+	}
+	return series
+}
+
+// FromBars はbarsパッケージで集計した足を変換します。Dateには期間の最初の営業日を使用します。
+func FromBars(bs []bars.Bar) []OHLCV {
+	series := make([]OHLCV, len(bs))
+	for i, b := range bs {
+		series[i] = OHLCV{Date: b.Start, Time: b.Time, O: &b.O, H: &b.H, L: &b.L, C: &b.C, Vo: &b.Vo, Va: &b.Va}
+	}
+	return series
+}
+
+// Updater は1本ずつ値を受け取って逐次計算する指標です。
+// Updateは値がまだ求められない場合、またはbの売買が成立していない場合にokにfalseを返します。
+type Updater[T any] interface {
+	Update(b OHLCV) (value T, ok bool)
+}
+
+// Apply はseriesの各本を順にindへ反映し、seriesと同じ長さの結果を返します。値が求められない本はnilです。
+func Apply[T any](series []OHLCV, ind Updater[T]) []*T {
+	out := make([]*T, len(series))
+	for i, b := range series {
+		if v, ok := ind.Update(b); ok {
+			out[i] = &v
+		}
+	}
+	return out
+}
+
+func or(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
@@ -0,0 +1,134 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/bars"
+	"github.com/utahta/jquants/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// testSeries は終値が 10, 11, (売買不成立), 12, 13, 12 の日足です。高値・安値は終値±1です。
+func testSeries() []OHLCV {
+	var quotes []jquants.DailyQuote
+	for i, c := range []float64{10, 11, 0, 12, 13, 12} {
+		q := jquants.DailyQuote{Date: types.NewDate(2024, time.April, 1+i), Code: "86970"}
+		if c != 0 {
+			q.AdjC, q.AdjH, q.AdjL, q.AdjVo, q.C = ptr(c), ptr(c+1), ptr(c-1), ptr(100.0), ptr(c*2)
+		}
+		quotes = append(quotes, q)
+	}
+	return FromDailyQuotes(quotes, bars.Adjusted)
+}
+
+func approx(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("%s = nil, want %v", name, want)
+		return
+	}
+	if math.Abs(*got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, *got, want)
+	}
+}
+
+func wantNil[T any](t *testing.T, name string, got []*T, idx ...int) {
+	t.Helper()
+	for _, i := range idx {
+		if got[i] != nil {
+			t.Errorf("%s[%d] = %v, want nil", name, i, *got[i])
+		}
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+	series := testSeries()
+
+	sma := Apply(series, NewSMA(3))
+	wantNil(t, "SMA", sma, 0, 1, 2)
+	approx(t, "SMA[3]", sma[3], 11)
+	approx(t, "SMA[4]", sma[4], 12)
+	approx(t, "SMA[5]", sma[5], 37.0/3)
+
+	ema := Apply(series, NewEMA(3))
+	wantNil(t, "EMA", ema, 0, 1, 2)
+	approx(t, "EMA[3]", ema[3], 11)
+	approx(t, "EMA[4]", ema[4], 12)
+	approx(t, "EMA[5]", ema[5], 12)
+
+	bb := Apply(series, NewBollinger(3, 2))
+	wantNil(t, "Bollinger", bb, 0, 1, 2)
+	sd := math.Sqrt(2.0 / 3)
+	approx(t, "Bollinger[3].Upper", &bb[3].Upper, 11+2*sd)
+	approx(t, "Bollinger[3].Lower", &bb[3].Lower, 11-2*sd)
+
+	macd := Apply(series, NewMACD(2, 3, 2))
+	wantNil(t, "MACD", macd, 0, 1, 2, 3)
+	approx(t, "MACD[4].MACD", &macd[4].MACD, 0.5)
+	approx(t, "MACD[4].Hist", &macd[4].Hist, 0)
+	approx(t, "MACD[5].MACD", &macd[5].MACD, 1.0/6)
+	approx(t, "MACD[5].Signal", &macd[5].Signal, 0.5+2.0/3*(1.0/6-0.5))
+}
+
+func TestOscillators(t *testing.T) {
+	series := testSeries()
+
+	rsi := Apply(series, NewRSI(2))
+	wantNil(t, "RSI", rsi, 0, 1, 2)
+	approx(t, "RSI[3]", rsi[3], 100)
+	approx(t, "RSI[5]", rsi[5], 50)
+
+	// 高値・安値がない本は終値を使用する
+	series[4].H, series[4].L = nil, nil
+	atr := Apply(series, NewATR(2))
+	wantNil(t, "ATR", atr, 0, 2)
+	approx(t, "ATR[1]", atr[1], 2)
+	approx(t, "ATR[4]", atr[4], 1.5)  // 真の値幅 max(13-13, |13-12|, |13-12|) = 1
+	approx(t, "ATR[5]", atr[5], 1.75) // 真の値幅 max(13-11, |13-13|, |11-13|) = 2
+
+	vol := Apply(series, NewVolatility(2, 0))
+	wantNil(t, "Volatility", vol, 0, 1, 2)
+	r1, r2 := math.Log(11.0/10), math.Log(12.0/11)
+	approx(t, "Volatility[3]", vol[3], math.Abs(r1-r2)/math.Sqrt2)
+	annual := Apply(series, NewVolatility(2, 245))
+	approx(t, "annualized Volatility[3]", annual[3], math.Abs(r1-r2)/math.Sqrt2*math.Sqrt(245))
+}
+
+func TestVWAP(t *testing.T) {
+	quotes := []jquants.MinuteQuote{
+		{Date: types.MustParseDate("2024-04-01"), Time: "09:00", H: 10, L: 10, C: 10, Vo: 10, Va: 100},
+		{Date: types.MustParseDate("2024-04-01"), Time: "09:01", H: 11, L: 11, C: 11, Vo: 20, Va: 220},
+		{Date: types.MustParseDate("2024-04-02"), Time: "09:00", H: 10, L: 10, C: 10, Vo: 5, Va: 50},
+	}
+	vwap := NewVWAP()
+	var got []float64
+	for _, q := range quotes {
+		v, ok := vwap.Update(FromMinuteQuote(q))
+		if !ok {
+			t.Fatalf("Update(%s %s) not ok", q.Date, q.Time)
+		}
+		got = append(got, v)
+	}
+	approx(t, "VWAP[1]", &got[1], 320.0/30)
+	approx(t, "VWAP[2] (reset)", &got[2], 10)
+
+	// 売買代金がない場合は代表値（(高値 + 安値 + 終値) / 3）を使用する
+	daily := Apply(testSeries(), NewVWAP())
+	wantNil(t, "VWAP", daily, 2)
+	approx(t, "daily VWAP[0]", daily[0], 10)
+}
+
+func TestFromBars(t *testing.T) {
+	bs := []bars.Bar{{Start: types.MustParseDate("2024-04-01"), Time: "09:00", O: 1, H: 3, L: 1, C: 2, Vo: 10}, {Start: types.MustParseDate("2024-04-01"), Time: "09:05", C: 4, H: 4, L: 4, Vo: 10}}
+	series := FromBars(bs)
+	if *series[0].C != 2 || *series[1].C != 4 || series[1].Time != "09:05" {
+		t.Errorf("series = %+v", series)
+	}
+	sma := Apply(series, NewSMA(2))
+	approx(t, "SMA[1]", sma[1], 3)
+	wantNil(t, "SMA", sma, 0)
+}
@@ -0,0 +1,330 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/utahta/jquants/types"
+)
+
+// window は直近n個の値を保持するリングバッファです。
+type window struct {
+	values []float64
+	next   int
+	full   bool
+}
+
+func newWindow(n int) *window {
+	return &window{values: make([]float64, max(n, 1))}
+}
+
+// push はxを追加し、溢れた値を返します（溢れていない場合はokにfalse）。
+func (w *window) push(x float64) (old float64, ok bool) {
+	old, ok = w.values[w.next], w.full
+	w.values[w.next] = x
+	w.next = (w.next + 1) % len(w.values)
+	if w.next == 0 {
+		w.full = true
+	}
+	return old, ok
+}
+
+// meanStd は保持している値の平均と母標準偏差を返します。
+func (w *window) meanStd() (mean, std float64) {
+	for _, v := range w.values {
+		mean += v
+	}
+	mean /= float64(len(w.values))
+	for _, v := range w.values {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(w.values)))
+}
+
+// SMA は終値の単純移動平均です。
+type SMA struct {
+	w   *window
+	sum float64
+}
+
+// NewSMA は期間nのSMAを作成します。nが1未満の場合は1として扱います。
+func NewSMA(n int) *SMA {
+	return &SMA{w: newWindow(n)}
+}
+
+// Update はUpdaterを実装します。
+func (s *SMA) Update(b OHLCV) (float64, bool) {
+	if !b.traded() {
+		return 0, false
+	}
+	return s.push(*b.C)
+}
+
+func (s *SMA) push(x float64) (float64, bool) {
+	old, ok := s.w.push(x)
+	if ok {
+		s.sum -= old
+	}
+	s.sum += x
+	if !s.w.full {
+		return 0, false
+	}
+	return s.sum / float64(len(s.w.values)), true
+}
+
+// EMA は終値の指数移動平均です。平滑化係数は 2/(n+1) で、最初のn本の単純平均を初期値とします。
+type EMA struct {
+	n     int
+	alpha float64
+	count int
+	value float64
+}
+
+// NewEMA は期間nのEMAを作成します。nが1未満の場合は1として扱います。
+func NewEMA(n int) *EMA {
+	n = max(n, 1)
+	return &EMA{n: n, alpha: 2 / float64(n+1)}
+}
+
+// Update はUpdaterを実装します。
+func (e *EMA) Update(b OHLCV) (float64, bool) {
+	if !b.traded() {
+		return 0, false
+	}
+	return e.push(*b.C)
+}
+
+func (e *EMA) push(x float64) (float64, bool) {
+	e.count++
+	switch {
+	case e.count < e.n:
+		e.value += x
+		return 0, false
+	case e.count == e.n:
+		e.value = (e.value + x) / float64(e.n)
+	default:
+		e.value += e.alpha * (x - e.value)
+	}
+	return e.value, true
+}
+
+// wilder はWilderの平滑化（最初のn個の単純平均を初期値とし、以降は (前回×(n-1) + x) / n）です。
+type wilder struct {
+	n     int
+	count int
+	value float64
+}
+
+func (w *wilder) push(x float64) (float64, bool) {
+	w.count++
+	switch {
+	case w.count < w.n:
+		w.value += x
+		return 0, false
+	case w.count == w.n:
+		w.value = (w.value + x) / float64(w.n)
+	default:
+		w.value = (w.value*float64(w.n-1) + x) / float64(w.n)
+	}
+	return w.value, true
+}
+
+// RSI は終値の相対力指数（0〜100）です。上昇幅・下落幅の平均にはWilderの平滑化を使用します。
+type RSI struct {
+	gain, loss wilder
+	prev       *float64
+}
+
+// NewRSI は期間nのRSIを作成します。nが1未満の場合は1として扱います。
+func NewRSI(n int) *RSI {
+	n = max(n, 1)
+	return &RSI{gain: wilder{n: n}, loss: wilder{n: n}}
+}
+
+// Update はUpdaterを実装します。
+func (r *RSI) Update(b OHLCV) (float64, bool) {
+	if !b.traded() {
+		return 0, false
+	}
+	c := *b.C
+	prev := r.prev
+	r.prev = &c
+	if prev == nil {
+		return 0, false
+	}
+	d := c - *prev
+	gain, ok := r.gain.push(max(d, 0))
+	loss, _ := r.loss.push(max(-d, 0))
+	if !ok {
+		return 0, false
+	}
+	if loss == 0 {
+		if gain == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+gain/loss), true
+}
+
+// MACDValue はMACDの値です。
+type MACDValue struct {
+	MACD   float64 // MACD（短期EMA - 長期EMA）
+	Signal float64 // シグナル（MACDのEMA）
+	Hist   float64 // ヒストグラム（MACD - シグナル）
+}
+
+// MACD は終値のMACDです。
+type MACD struct {
+	fast, slow, signal *EMA
+}
+
+// NewMACD は短期fast・長期slow・シグナルsignalの期間のMACDを作成します（一般的には12, 26, 9）。
+func NewMACD(fast, slow, signal int) *MACD {
+	return &MACD{fast: NewEMA(fast), slow: NewEMA(slow), signal: NewEMA(signal)}
+}
+
+// Update はUpdaterを実装します。シグナルが求められるまではokにfalseを返します。
+func (m *MACD) Update(b OHLCV) (MACDValue, bool) {
+	if !b.traded() {
+		return MACDValue{}, false
+	}
+	fast, ok1 := m.fast.push(*b.C)
+	slow, ok2 := m.slow.push(*b.C)
+	if !ok1 || !ok2 {
+		return MACDValue{}, false
+	}
+	macd := fast - slow
+	signal, ok := m.signal.push(macd)
+	if !ok {
+		return MACDValue{}, false
+	}
+	return MACDValue{MACD: macd, Signal: signal, Hist: macd - signal}, true
+}
+
+// Band はボリンジャーバンドの値です。
+type Band struct {
+	Middle float64 // 中心線（終値のSMA）
+	Upper  float64 // 上限（中心線 + k×標準偏差）
+	Lower  float64 // 下限（中心線 - k×標準偏差）
+}
+
+// Bollinger は終値のボリンジャーバンドです。標準偏差は母標準偏差を使用します。
+type Bollinger struct {
+	w *window
+	k float64
+}
+
+// NewBollinger は期間n・幅kのボリンジャーバンドを作成します（一般的には20, 2）。nが1未満の場合は1として扱います。
+func NewBollinger(n int, k float64) *Bollinger {
+	return &Bollinger{w: newWindow(n), k: k}
+}
+
+// Update はUpdaterを実装します。
+func (bb *Bollinger) Update(b OHLCV) (Band, bool) {
+	if !b.traded() {
+		return Band{}, false
+	}
+	bb.w.push(*b.C)
+	if !bb.w.full {
+		return Band{}, false
+	}
+	mean, std := bb.w.meanStd()
+	return Band{Middle: mean, Upper: mean + bb.k*std, Lower: mean - bb.k*std}, true
+}
+
+// ATR は平均真の値幅（Average True Range）です。真の値幅の平均にはWilderの平滑化を使用します。
+// 最初の本の真の値幅は高値 - 安値です。
+type ATR struct {
+	tr        wilder
+	prevClose *float64
+}
+
+// NewATR は期間nのATRを作成します。nが1未満の場合は1として扱います。
+func NewATR(n int) *ATR {
+	return &ATR{tr: wilder{n: max(n, 1)}}
+}
+
+// Update はUpdaterを実装します。
+func (a *ATR) Update(b OHLCV) (float64, bool) {
+	if !b.traded() {
+		return 0, false
+	}
+	h, l := b.high(), b.low()
+	tr := h - l
+	if a.prevClose != nil {
+		tr = max(tr, math.Abs(h-*a.prevClose), math.Abs(l-*a.prevClose))
+	}
+	c := *b.C
+	a.prevClose = &c
+	return a.tr.push(tr)
+}
+
+// VWAP は日中の出来高加重平均価格です。日付（OHLCV.Date）が変わるとリセットします。
+// 売買代金がある本は売買代金を、ない本は (高値 + 安値 + 終値) / 3 × 出来高 を使用します。
+type VWAP struct {
+	date   types.Date
+	va, vo float64
+}
+
+// NewVWAP は新しいVWAPを作成します。
+func NewVWAP() *VWAP {
+	return &VWAP{}
+}
+
+// Update はUpdaterを実装します。出来高が0の間はokにfalseを返します。
+func (v *VWAP) Update(b OHLCV) (float64, bool) {
+	if b.Date != v.date {
+		v.date, v.va, v.vo = b.Date, 0, 0
+	}
+	if !b.traded() {
+		return 0, false
+	}
+	vo := or(b.Vo, 0)
+	if b.Va != nil {
+		v.va += *b.Va
+	} else {
+		v.va += (b.high() + b.low() + *b.C) / 3 * vo
+	}
+	v.vo += vo
+	if v.vo == 0 {
+		return 0, false
+	}
+	return v.va / v.vo, true
+}
+
+// Volatility は終値の対数収益率のn本の標準偏差（不偏）です。
+type Volatility struct {
+	w         *window
+	prev      *float64
+	annualize float64
+}
+
+// NewVolatility は期間nのボラティリティを作成します。nが2未満の場合は2として扱います。
+// periodsPerYearが正の場合は sqrt(periodsPerYear) を乗じて年率換算します（日足では一般的に245や252）。
+func NewVolatility(n int, periodsPerYear float64) *Volatility {
+	v := &Volatility{w: newWindow(max(n, 2)), annualize: 1}
+	if periodsPerYear > 0 {
+		v.annualize = math.Sqrt(periodsPerYear)
+	}
+	return v
+}
+
+// Update はUpdaterを実装します。
+func (v *Volatility) Update(b OHLCV) (float64, bool) {
+	if !b.traded() || *b.C <= 0 {
+		return 0, false
+	}
+	c := *b.C
+	prev := v.prev
+	v.prev = &c
+	if prev == nil {
+		return 0, false
+	}
+	v.w.push(math.Log(c / *prev))
+	if !v.w.full {
+		return 0, false
+	}
+	_, std := v.w.meanStd()
+	n := float64(len(v.w.values))
+	return std * math.Sqrt(n/(n-1)) * v.annualize, true
+}
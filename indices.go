@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -23,17 +24,17 @@ func NewIndicesService(c client.HTTPClient) *IndicesService {
 // Index は指数四本値データを表します。
 // J-Quants API /indices/bars/daily エンドポイントのレスポンスデータ。
 type Index struct {
-	Date string   `json:"Date"` // 日付（YYYY-MM-DD形式）
-	Code string   `json:"Code"` // 指数コード
-	O    *float64 `json:"O"`    // 始値（終値のみ配信の指数・期間ではnull）
-	H    *float64 `json:"H"`    // 高値（終値のみ配信の指数・期間ではnull）
-	L    *float64 `json:"L"`    // 安値（終値のみ配信の指数・期間ではnull）
-	C    float64  `json:"C"`    // 終値
+	Date types.Date `json:"Date"` // 日付
+	Code string     `json:"Code"` // 指数コード
+	O    *float64   `json:"O"`    // 始値（終値のみ配信の指数・期間ではnull）
+	H    *float64   `json:"H"`    // 高値（終値のみ配信の指数・期間ではnull）
+	L    *float64   `json:"L"`    // 安値（終値のみ配信の指数・期間ではnull）
+	C    float64    `json:"C"`    // 終値
 }
 
 // RawIndex is used for unmarshaling JSON response with mixed types
 type RawIndex struct {
-	Date string                `json:"Date"`
+	Date types.Date            `json:"Date"`
 	Code string                `json:"Code"`
 	O    types.NullableFloat64 `json:"O"`
 	H    types.NullableFloat64 `json:"H"`
@@ -81,11 +82,11 @@ func (i *IndicesResponse) UnmarshalJSON(data []byte) error {
 
 // IndicesParams は指数四本値のリクエストパラメータです。
 type IndicesParams struct {
-	Code          string // 指数コード（例: "0000" TOPIX、"0028" TOPIX Core30）
-	Date          string // 基準日付（YYYYMMDD または YYYY-MM-DD）
-	From          string // 開始日付（YYYYMMDD または YYYY-MM-DD）
-	To            string // 終了日付（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 指数コード（例: "0000" TOPIX、"0028" TOPIX Core30）
+	Date          types.Date // 基準日付
+	From          types.Date // 開始日付
+	To            types.Date // 終了日付
+	PaginationKey string     // ページネーションキー
 }
 
 // GetIndices は指定された条件で指数四本値データを取得します。
@@ -98,26 +99,17 @@ type IndicesParams struct {
 func (s *IndicesService) GetIndices(ctx context.Context, params IndicesParams) (*IndicesResponse, error) {
 	path := "/indices/bars/daily"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp IndicesResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -127,34 +119,28 @@ func (s *IndicesService) GetIndices(ctx context.Context, params IndicesParams) (
 	return &resp, nil
 }
 
-// GetIndicesByCode は指定指数の全期間のデータを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *IndicesService) GetIndicesByCode(ctx context.Context, code string) ([]Index, error) {
-	var allIndices []Index
-	paginationKey := ""
-
-	for {
-		resp, err := s.GetIndices(ctx, IndicesParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		})
+// AllIndices は指定条件の指数四本値を1件ずつ返すイテレータです。
+func (s *IndicesService) AllIndices(ctx context.Context, params IndicesParams) iter.Seq2[Index, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Index, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetIndices(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allIndices = append(allIndices, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allIndices, nil
+// GetIndicesByCode は指定指数の全期間のデータを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *IndicesService) GetIndicesByCode(ctx context.Context, code string) ([]Index, error) {
+	return collectAll(s.AllIndices(ctx, IndicesParams{
+		Code: code,
+	}))
 }
 
 // GetIndicesByCodeAndDate は指定指数の指定日のデータを取得します。
-func (s *IndicesService) GetIndicesByCodeAndDate(ctx context.Context, code, date string) ([]Index, error) {
+func (s *IndicesService) GetIndicesByCodeAndDate(ctx context.Context, code string, date types.Date) ([]Index, error) {
 	resp, err := s.GetIndices(ctx, IndicesParams{
 		Code: code,
 		Date: date,
@@ -167,59 +153,20 @@ func (s *IndicesService) GetIndicesByCodeAndDate(ctx context.Context, code, date
 
 // GetIndicesByCodeAndDateRange は指定指数の指定期間のデータを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *IndicesService) GetIndicesByCodeAndDateRange(ctx context.Context, code, from, to string) ([]Index, error) {
-	var allIndices []Index
-	paginationKey := ""
-
-	for {
-		resp, err := s.GetIndices(ctx, IndicesParams{
-			Code:          code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		allIndices = append(allIndices, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allIndices, nil
+func (s *IndicesService) GetIndicesByCodeAndDateRange(ctx context.Context, code string, from, to types.Date) ([]Index, error) {
+	return collectAll(s.AllIndices(ctx, IndicesParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetIndicesByDate は指定日の全指数データを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *IndicesService) GetIndicesByDate(ctx context.Context, date string) ([]Index, error) {
-	var allIndices []Index
-	paginationKey := ""
-
-	for {
-		params := IndicesParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetIndices(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allIndices = append(allIndices, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allIndices, nil
+func (s *IndicesService) GetIndicesByDate(ctx context.Context, date types.Date) ([]Index, error) {
+	return collectAll(s.AllIndices(ctx, IndicesParams{
+		Date: date,
+	}))
 }
 
 // 主要指数コード定数
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestIndicesService_GetIndices(t *testing.T) {
@@ -18,9 +19,9 @@ func TestIndicesService_GetIndices(t *testing.T) {
 			name: "with all parameters",
 			params: IndicesParams{
 				Code:          "0000",
-				Date:          "20240101",
-				From:          "20240101",
-				To:            "20240131",
+				Date:          types.MustParseDate("20240101"),
+				From:          types.MustParseDate("20240101"),
+				To:            types.MustParseDate("20240131"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/indices/bars/daily?code=0000&date=20240101&from=20240101&to=20240131&pagination_key=key123",
@@ -29,8 +30,8 @@ func TestIndicesService_GetIndices(t *testing.T) {
 			name: "with code and date range",
 			params: IndicesParams{
 				Code: "0000",
-				From: "20240101",
-				To:   "20240131",
+				From: types.MustParseDate("20240101"),
+				To:   types.MustParseDate("20240131"),
 			},
 			wantPath: "/indices/bars/daily?code=0000&from=20240101&to=20240131",
 		},
@@ -44,7 +45,7 @@ func TestIndicesService_GetIndices(t *testing.T) {
 		{
 			name: "with date only",
 			params: IndicesParams{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 			},
 			wantPath: "/indices/bars/daily?date=20240101",
 		},
@@ -65,7 +66,7 @@ func TestIndicesService_GetIndices(t *testing.T) {
 			mockResponse := IndicesResponse{
 				Data: []Index{
 					{
-						Date: "2024-01-31",
+						Date: types.MustParseDate("2024-01-31"),
 						Code: "0000",
 						O:    floatPtr(2400.0),
 						H:    floatPtr(2420.0),
@@ -106,8 +107,8 @@ func TestIndicesService_GetIndicesByCode(t *testing.T) {
 	// Mock response - 最初のページ
 	mockResponse1 := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-01-01", Code: "0000", O: floatPtr(2400.0), H: floatPtr(2420.0), L: floatPtr(2390.0), C: 2410.0},
-			{Date: "2024-01-02", Code: "0000", C: 2420.0},
+			{Date: types.MustParseDate("2024-01-01"), Code: "0000", O: floatPtr(2400.0), H: floatPtr(2420.0), L: floatPtr(2390.0), C: 2410.0},
+			{Date: types.MustParseDate("2024-01-02"), Code: "0000", C: 2420.0},
 		},
 		PaginationKey: "next_page_key",
 	}
@@ -115,7 +116,7 @@ func TestIndicesService_GetIndicesByCode(t *testing.T) {
 	// Mock response - 2ページ目
 	mockResponse2 := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-01-03", Code: "0000", C: 2430.0},
+			{Date: types.MustParseDate("2024-01-03"), Code: "0000", C: 2430.0},
 		},
 		PaginationKey: "",
 	}
@@ -147,12 +148,12 @@ func TestIndicesService_GetIndicesByDate(t *testing.T) {
 	mockResponse1 := IndicesResponse{
 		Data: []Index{
 			{
-				Date: "2024-01-01",
+				Date: types.MustParseDate("2024-01-01"),
 				Code: "0000",
 				C:    2400.0,
 			},
 			{
-				Date: "2024-01-01",
+				Date: types.MustParseDate("2024-01-01"),
 				Code: "0028",
 				C:    1200.0,
 			},
@@ -164,7 +165,7 @@ func TestIndicesService_GetIndicesByDate(t *testing.T) {
 	mockResponse2 := IndicesResponse{
 		Data: []Index{
 			{
-				Date: "2024-01-01",
+				Date: types.MustParseDate("2024-01-01"),
 				Code: "0050",
 				C:    1600.0,
 			},
@@ -176,7 +177,7 @@ func TestIndicesService_GetIndicesByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/indices/bars/daily?date=20240101&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	indices, err := service.GetIndicesByDate(context.Background(), "20240101")
+	indices, err := service.GetIndicesByDate(context.Background(), types.MustParseDate("20240101"))
 
 	// Verify
 	if err != nil {
@@ -195,7 +196,7 @@ func TestIndicesService_GetTOPIX(t *testing.T) {
 	// Mock response
 	mockResponse := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-02-01", Code: "0000", C: 2400.0},
+			{Date: types.MustParseDate("2024-02-01"), Code: "0000", C: 2400.0},
 		},
 	}
 	mockClient.SetResponse("GET", "/indices/bars/daily?code=0000", mockResponse)
@@ -223,7 +224,7 @@ func TestIndicesService_GetSectorIndex(t *testing.T) {
 	// Mock response - 情報・通信業
 	mockResponse := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-02-01", Code: "0058", O: floatPtr(3000.0), H: floatPtr(3050.0), L: floatPtr(2980.0), C: 3020.0},
+			{Date: types.MustParseDate("2024-02-01"), Code: "0058", O: floatPtr(3000.0), H: floatPtr(3050.0), L: floatPtr(2980.0), C: 3020.0},
 		},
 	}
 	mockClient.SetResponse("GET", "/indices/bars/daily?code=0058", mockResponse)
@@ -251,7 +252,7 @@ func TestIndicesService_GetPrimeMarketIndex(t *testing.T) {
 	// Mock response
 	mockResponse := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-02-01", Code: "0500", C: 1800.0},
+			{Date: types.MustParseDate("2024-02-01"), Code: "0500", C: 1800.0},
 		},
 	}
 	mockClient.SetResponse("GET", "/indices/bars/daily?code=0500", mockResponse)
@@ -277,7 +278,7 @@ func TestIndicesService_GetIndicesByCodeAndDate(t *testing.T) {
 	mockResponse := IndicesResponse{
 		Data: []Index{
 			{
-				Date: "2024-01-01",
+				Date: types.MustParseDate("2024-01-01"),
 				Code: "0000",
 				O:    floatPtr(2400.0),
 				H:    floatPtr(2420.0),
@@ -289,7 +290,7 @@ func TestIndicesService_GetIndicesByCodeAndDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/indices/bars/daily?code=0000&date=20240101", mockResponse)
 
 	// Execute
-	indices, err := service.GetIndicesByCodeAndDate(context.Background(), "0000", "20240101")
+	indices, err := service.GetIndicesByCodeAndDate(context.Background(), "0000", types.MustParseDate("20240101"))
 
 	// Verify
 	if err != nil {
@@ -298,7 +299,7 @@ func TestIndicesService_GetIndicesByCodeAndDate(t *testing.T) {
 	if len(indices) != 1 {
 		t.Errorf("GetIndicesByCodeAndDate() returned %d items, want 1", len(indices))
 	}
-	if indices[0].Code != "0000" || indices[0].Date != "2024-01-01" {
+	if indices[0].Code != "0000" || indices[0].Date.String() != "2024-01-01" {
 		t.Errorf("Index data mismatch: code=%s, date=%s", indices[0].Code, indices[0].Date)
 	}
 	if mockClient.LastPath != "/indices/bars/daily?code=0000&date=20240101" {
@@ -316,8 +317,8 @@ func TestIndicesService_GetIndicesByCodeAndDateRange(t *testing.T) {
 	// Mock response - 最初のページ
 	mockResponse1 := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-01-01", Code: "0000", C: 2400.0},
-			{Date: "2024-01-02", Code: "0000", C: 2410.0},
+			{Date: types.MustParseDate("2024-01-01"), Code: "0000", C: 2400.0},
+			{Date: types.MustParseDate("2024-01-02"), Code: "0000", C: 2410.0},
 		},
 		PaginationKey: "next_page_key",
 	}
@@ -325,7 +326,7 @@ func TestIndicesService_GetIndicesByCodeAndDateRange(t *testing.T) {
 	// Mock response - 2ページ目
 	mockResponse2 := IndicesResponse{
 		Data: []Index{
-			{Date: "2024-01-03", Code: "0000", C: 2420.0},
+			{Date: types.MustParseDate("2024-01-03"), Code: "0000", C: 2420.0},
 		},
 		PaginationKey: "",
 	}
@@ -334,7 +335,7 @@ func TestIndicesService_GetIndicesByCodeAndDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", basePath+"&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	indices, err := service.GetIndicesByCodeAndDateRange(context.Background(), "0000", "20240101", "20240131")
+	indices, err := service.GetIndicesByCodeAndDateRange(context.Background(), "0000", types.MustParseDate("20240101"), types.MustParseDate("20240131"))
 
 	// Verify
 	if err != nil {
@@ -343,10 +344,10 @@ func TestIndicesService_GetIndicesByCodeAndDateRange(t *testing.T) {
 	if len(indices) != 3 {
 		t.Errorf("GetIndicesByCodeAndDateRange() returned %d items, want 3", len(indices))
 	}
-	if indices[0].Date != "2024-01-01" || indices[0].C != 2400.0 {
+	if indices[0].Date.String() != "2024-01-01" || indices[0].C != 2400.0 {
 		t.Errorf("First index data mismatch")
 	}
-	if indices[2].Date != "2024-01-03" || indices[2].C != 2420.0 {
+	if indices[2].Date.String() != "2024-01-03" || indices[2].C != 2420.0 {
 		t.Errorf("Last index data mismatch")
 	}
 }
@@ -364,7 +365,7 @@ func TestIndicesService_GetIndices_CloseOnlyIndex(t *testing.T) {
 	}
 	mockClient.SetResponse("GET", "/indices/bars/daily?date=20250613", mockResponse)
 
-	resp, err := service.GetIndices(context.Background(), IndicesParams{Date: "20250613"})
+	resp, err := service.GetIndices(context.Background(), IndicesParams{Date: types.MustParseDate("20250613")})
 	if err != nil {
 		t.Fatalf("GetIndices() error = %v", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // 市場区分コード定義
@@ -109,20 +110,20 @@ func NewListedService(c client.HTTPClient) *ListedService {
 // J-Quants API /equities/master エンドポイントのレスポンスデータ。
 // 過去時点、当日、翌営業日時点の銘柄情報が取得可能（翌営業日は17:30以降）。
 type ListedInfo struct {
-	Date     string `json:"Date"`     // 情報適用年月日（YYYY-MM-DD形式）
-	Code     string `json:"Code"`     // 銘柄コード（4桁または5桁）
-	CoName   string `json:"CoName"`   // 企業名（日本語）
-	CoNameEn string `json:"CoNameEn"` // 企業名（英語）
-	S17      string `json:"S17"`      // 17業種コード
-	S17Nm    string `json:"S17Nm"`    // 17業種コード名
-	S33      string `json:"S33"`      // 33業種コード
-	S33Nm    string `json:"S33Nm"`    // 33業種コード名
-	ScaleCat string `json:"ScaleCat"` // 規模コード（TOPIX Core30、Large70等）
-	Mkt      string `json:"Mkt"`      // 市場区分コード
-	MktNm    string `json:"MktNm"`    // 市場区分名（プライム、スタンダード、グロース等）
-	Mrgn     string `json:"Mrgn"`     // 貸借信用区分（1: 信用 / 2: 貸借 / 3: その他）（Standard/Premiumのみ）
-	MrgnNm   string `json:"MrgnNm"`   // 貸借信用区分名（Standard/Premiumのみ）
-	ProdCat  string `json:"ProdCat"`  // 商品区分コード（ProductCategory定数を参照）
+	Date     types.Date `json:"Date"`     // 情報適用年月日
+	Code     string     `json:"Code"`     // 銘柄コード（4桁または5桁）
+	CoName   string     `json:"CoName"`   // 企業名（日本語）
+	CoNameEn string     `json:"CoNameEn"` // 企業名（英語）
+	S17      string     `json:"S17"`      // 17業種コード
+	S17Nm    string     `json:"S17Nm"`    // 17業種コード名
+	S33      string     `json:"S33"`      // 33業種コード
+	S33Nm    string     `json:"S33Nm"`    // 33業種コード名
+	ScaleCat string     `json:"ScaleCat"` // 規模コード（TOPIX Core30、Large70等）
+	Mkt      string     `json:"Mkt"`      // 市場区分コード
+	MktNm    string     `json:"MktNm"`    // 市場区分名（プライム、スタンダード、グロース等）
+	Mrgn     string     `json:"Mrgn"`     // 貸借信用区分（1: 信用 / 2: 貸借 / 3: その他）（Standard/Premiumのみ）
+	MrgnNm   string     `json:"MrgnNm"`   // 貸借信用区分名（Standard/Premiumのみ）
+	ProdCat  string     `json:"ProdCat"`  // 商品区分コード（ProductCategory定数を参照）
 }
 
 // IsETF はETF（外国ETF含む）かを判定します。
@@ -141,8 +142,8 @@ type ListedInfoResponse struct {
 
 // ListedInfoParams は上場企業情報のリクエストパラメータです。
 type ListedInfoParams struct {
-	Code string // 銘柄コード（4桁または5桁）
-	Date string // 基準日付（YYYYMMDD または YYYY-MM-DD）
+	Code string     // 銘柄コード（4桁または5桁）
+	Date types.Date // 基準日付
 }
 
 // GetListedInfo は指定された条件で上場企業情報を取得します。
@@ -152,17 +153,14 @@ type ListedInfoParams struct {
 func (s *ListedService) GetListedInfo(ctx context.Context, params ListedInfoParams) (*ListedInfoResponse, error) {
 	path := "/equities/master"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp ListedInfoResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -191,7 +189,7 @@ func (s *ListedService) GetListedInfoByCode(ctx context.Context, code string) ([
 }
 
 // GetListedInfoByDate は指定日時点の全銘柄情報を取得します。
-func (s *ListedService) GetListedInfoByDate(ctx context.Context, date string) ([]ListedInfo, error) {
+func (s *ListedService) GetListedInfoByDate(ctx context.Context, date types.Date) ([]ListedInfo, error) {
 	resp, err := s.GetListedInfo(ctx, ListedInfoParams{Date: date})
 	if err != nil {
 		return nil, err
@@ -200,7 +198,7 @@ func (s *ListedService) GetListedInfoByDate(ctx context.Context, date string) ([
 }
 
 // GetListedInfoByCodeAndDate は指定日時点の指定銘柄情報を取得します。
-func (s *ListedService) GetListedInfoByCodeAndDate(ctx context.Context, code, date string) ([]ListedInfo, error) {
+func (s *ListedService) GetListedInfoByCodeAndDate(ctx context.Context, code string, date types.Date) ([]ListedInfo, error) {
 	resp, err := s.GetListedInfo(ctx, ListedInfoParams{Code: code, Date: date})
 	if err != nil {
 		return nil, err
@@ -210,7 +208,7 @@ func (s *ListedService) GetListedInfoByCodeAndDate(ctx context.Context, code, da
 
 // GetListedBySector17 は指定した17業種コードの銘柄一覧を取得します。
 // 例: GetListedBySector17(ctx, Sector17IT, "") でIT関連銘柄を取得
-func (s *ListedService) GetListedBySector17(ctx context.Context, sector17Code string, date string) ([]ListedInfo, error) {
+func (s *ListedService) GetListedBySector17(ctx context.Context, sector17Code string, date types.Date) ([]ListedInfo, error) {
 	allInfo, err := s.GetListedInfoByDate(ctx, date)
 	if err != nil {
 		return nil, err
@@ -228,7 +226,7 @@ func (s *ListedService) GetListedBySector17(ctx context.Context, sector17Code st
 
 // GetListedBySector33 は指定した33業種コードの銘柄一覧を取得します。
 // 例: GetListedBySector33(ctx, Sector33IT, "") で情報・通信業銘柄を取得
-func (s *ListedService) GetListedBySector33(ctx context.Context, sector33Code string, date string) ([]ListedInfo, error) {
+func (s *ListedService) GetListedBySector33(ctx context.Context, sector33Code string, date types.Date) ([]ListedInfo, error) {
 	allInfo, err := s.GetListedInfoByDate(ctx, date)
 	if err != nil {
 		return nil, err
@@ -246,7 +244,7 @@ func (s *ListedService) GetListedBySector33(ctx context.Context, sector33Code st
 
 // GetListedByMarket は指定した市場区分の銘柄一覧を取得します。
 // marketCode: MarketPrime, MarketStandard, MarketGrowth など
-func (s *ListedService) GetListedByMarket(ctx context.Context, marketCode string, date string) ([]ListedInfo, error) {
+func (s *ListedService) GetListedByMarket(ctx context.Context, marketCode string, date types.Date) ([]ListedInfo, error) {
 	allInfo, err := s.GetListedInfoByDate(ctx, date)
 	if err != nil {
 		return nil, err
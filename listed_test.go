@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestListedService_GetListedInfo(t *testing.T) {
@@ -18,21 +19,21 @@ func TestListedService_GetListedInfo(t *testing.T) {
 			name: "with code and date",
 			params: ListedInfoParams{
 				Code: "7203",
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 			},
-			wantPath: "/equities/master?code=7203&date=20240101",
+			wantPath: "/equities/master?code=72030&date=20240101",
 		},
 		{
 			name: "with code only",
 			params: ListedInfoParams{
 				Code: "7203",
 			},
-			wantPath: "/equities/master?code=7203",
+			wantPath: "/equities/master?code=72030",
 		},
 		{
 			name: "with date only",
 			params: ListedInfoParams{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 			},
 			wantPath: "/equities/master?date=20240101",
 		},
@@ -53,7 +54,7 @@ func TestListedService_GetListedInfo(t *testing.T) {
 			mockResponse := ListedInfoResponse{
 				Data: []ListedInfo{
 					{
-						Date:     "20240101",
+						Date:     types.MustParseDate("20240101"),
 						Code:     "7203",
 						CoName:   "トヨタ自動車",
 						CoNameEn: "TOYOTA MOTOR CORPORATION",
@@ -129,7 +130,7 @@ func TestListedService_GetListedInfoByCode(t *testing.T) {
 	mockResponse := ListedInfoResponse{
 		Data: []ListedInfo{
 			{
-				Date:     "20240101",
+				Date:     types.MustParseDate("20240101"),
 				Code:     "7203",
 				CoName:   "トヨタ自動車",
 				CoNameEn: "TOYOTA MOTOR CORPORATION",
@@ -143,7 +144,7 @@ func TestListedService_GetListedInfoByCode(t *testing.T) {
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/equities/master?code=7203", mockResponse)
+	mockClient.SetResponse("GET", "/equities/master?code=72030", mockResponse)
 
 	// Execute
 	infos, err := service.GetListedInfoByCode(context.Background(), "7203")
@@ -174,14 +175,14 @@ func TestListedService_GetListedInfoByDate(t *testing.T) {
 	// Mock response
 	mockResponse := ListedInfoResponse{
 		Data: []ListedInfo{
-			{Code: "7203", CoName: "トヨタ自動車", Date: "2024-01-01"},
-			{Code: "9984", CoName: "ソフトバンクグループ", Date: "2024-01-01"},
+			{Code: "7203", CoName: "トヨタ自動車", Date: types.MustParseDate("2024-01-01")},
+			{Code: "9984", CoName: "ソフトバンクグループ", Date: types.MustParseDate("2024-01-01")},
 		},
 	}
 	mockClient.SetResponse("GET", "/equities/master?date=20240101", mockResponse)
 
 	// Execute
-	infos, err := service.GetListedInfoByDate(context.Background(), "20240101")
+	infos, err := service.GetListedInfoByDate(context.Background(), types.MustParseDate("20240101"))
 	if err != nil {
 		t.Fatalf("GetListedInfoByDate() error = %v", err)
 	}
@@ -201,16 +202,16 @@ func TestListedService_GetListedInfoByCodeAndDate(t *testing.T) {
 	mockResponse := ListedInfoResponse{
 		Data: []ListedInfo{
 			{
-				Date:   "2024-01-01",
+				Date:   types.MustParseDate("2024-01-01"),
 				Code:   "7203",
 				CoName: "トヨタ自動車",
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/equities/master?code=7203&date=20240101", mockResponse)
+	mockClient.SetResponse("GET", "/equities/master?code=72030&date=20240101", mockResponse)
 
 	// Execute
-	infos, err := service.GetListedInfoByCodeAndDate(context.Background(), "7203", "20240101")
+	infos, err := service.GetListedInfoByCodeAndDate(context.Background(), "7203", types.MustParseDate("20240101"))
 	if err != nil {
 		t.Fatalf("GetListedInfoByCodeAndDate() error = %v", err)
 	}
@@ -219,11 +220,11 @@ func TestListedService_GetListedInfoByCodeAndDate(t *testing.T) {
 	if len(infos) != 1 {
 		t.Errorf("GetListedInfoByCodeAndDate() returned %d items, want 1", len(infos))
 	}
-	if infos[0].Code != "7203" || infos[0].Date != "2024-01-01" {
+	if infos[0].Code != "7203" || infos[0].Date.String() != "2024-01-01" {
 		t.Errorf("Data mismatch: code=%s, date=%s", infos[0].Code, infos[0].Date)
 	}
-	if mockClient.LastPath != "/equities/master?code=7203&date=20240101" {
-		t.Errorf("Expected path /equities/master?code=7203&date=20240101, got %s", mockClient.LastPath)
+	if mockClient.LastPath != "/equities/master?code=72030&date=20240101" {
+		t.Errorf("Expected path /equities/master?code=72030&date=20240101, got %s", mockClient.LastPath)
 	}
 }
 
@@ -233,7 +234,7 @@ func TestListedService_GetListedInfo_Error(t *testing.T) {
 	service := NewListedService(mockClient)
 
 	// Mock error
-	mockClient.SetError("GET", "/equities/master?code=7203", fmt.Errorf("API error"))
+	mockClient.SetError("GET", "/equities/master?code=72030", fmt.Errorf("API error"))
 
 	// Test
 	_, err := service.GetListedInfo(context.Background(), ListedInfoParams{Code: "7203"})
@@ -276,7 +277,7 @@ func TestListedService_GetListedBySector17(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/master", mockResponse)
 
 	// Test - 自動車・輸送機セクターの銘柄を取得
-	infos, err := service.GetListedBySector17(context.Background(), Sector17Auto, "")
+	infos, err := service.GetListedBySector17(context.Background(), Sector17Auto, types.Date{})
 	if err != nil {
 		t.Errorf("GetListedBySector17 failed: %v", err)
 	}
@@ -327,7 +328,7 @@ func TestListedService_GetListedBySector33(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/master", mockResponse)
 
 	// Test - 情報・通信業の銘柄を取得
-	infos, err := service.GetListedBySector33(context.Background(), Sector33IT, "")
+	infos, err := service.GetListedBySector33(context.Background(), Sector33IT, types.Date{})
 	if err != nil {
 		t.Errorf("GetListedBySector33 failed: %v", err)
 	}
@@ -375,7 +376,7 @@ func TestListedService_GetListedByMarket(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/master", mockResponse)
 
 	// Test - プライム市場の銘柄を取得
-	infos, err := service.GetListedByMarket(context.Background(), MarketPrime, "")
+	infos, err := service.GetListedByMarket(context.Background(), MarketPrime, types.Date{})
 	if err != nil {
 		t.Errorf("GetListedByMarket failed: %v", err)
 	}
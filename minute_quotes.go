@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -24,11 +25,11 @@ func NewMinuteQuotesService(c client.HTTPClient) *MinuteQuotesService {
 
 // MinuteQuotesParams は株価分足のリクエストパラメータです。
 type MinuteQuotesParams struct {
-	Code          string // 銘柄コード（4桁または5桁）（code、dateのいずれかが必須）
-	Date          string // 日付（YYYYMMDD または YYYY-MM-DD）（code、dateのいずれかが必須）
-	From          string // fromの指定（YYYYMMDD または YYYY-MM-DD）
-	To            string // toの指定（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（4桁または5桁）（code、dateのいずれかが必須）
+	Date          types.Date // 日付（code、dateのいずれかが必須）
+	From          types.Date // fromの指定
+	To            types.Date // toの指定
+	PaginationKey string     // ページネーションキー
 }
 
 // MinuteQuotesResponse は株価分足のレスポンスです。
@@ -42,9 +43,9 @@ type MinuteQuotesResponse struct {
 // 約定のない時間帯の分足は返却されないため、四本値・出来高・売買代金は常に値を持ちます。
 type MinuteQuote struct {
 	// 基本情報
-	Date string `json:"Date"` // 日付（YYYY-MM-DD形式）
-	Time string `json:"Time"` // 時刻（HH:mm形式）
-	Code string `json:"Code"` // 銘柄コード
+	Date types.Date `json:"Date"` // 日付
+	Time string     `json:"Time"` // 時刻（HH:mm形式）
+	Code string     `json:"Code"` // 銘柄コード
 
 	// 四本値
 	O float64 `json:"O"` // 始値
@@ -60,9 +61,9 @@ type MinuteQuote struct {
 // RawMinuteQuote is used for unmarshaling JSON response with mixed types
 type RawMinuteQuote struct {
 	// 基本情報
-	Date string `json:"Date"`
-	Time string `json:"Time"`
-	Code string `json:"Code"`
+	Date types.Date `json:"Date"`
+	Time string     `json:"Time"`
+	Code string     `json:"Code"`
 
 	// 四本値
 	O types.NullableFloat64 `json:"O"`
@@ -118,32 +119,23 @@ func (r *MinuteQuotesResponse) UnmarshalJSON(data []byte) error {
 // GetMinuteQuotes は株価分足データを取得します。
 func (s *MinuteQuotesService) GetMinuteQuotes(ctx context.Context, params MinuteQuotesParams) (*MinuteQuotesResponse, error) {
 	// code、dateのいずれかが必須
-	if params.Code == "" && params.Date == "" {
+	if params.Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either code or date parameter is required")
 	}
 
 	path := "/equities/bars/minute"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp MinuteQuotesResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -153,90 +145,39 @@ func (s *MinuteQuotesService) GetMinuteQuotes(ctx context.Context, params Minute
 	return &resp, nil
 }
 
-// GetMinuteQuotesByCode は指定銘柄の株価分足データを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *MinuteQuotesService) GetMinuteQuotesByCode(ctx context.Context, code string) ([]MinuteQuote, error) {
-	var allData []MinuteQuote
-	paginationKey := ""
-
-	for {
-		params := MinuteQuotesParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllMinuteQuotes は指定条件の株価分足を1件ずつ返すイテレータです。
+func (s *MinuteQuotesService) AllMinuteQuotes(ctx context.Context, params MinuteQuotesParams) iter.Seq2[MinuteQuote, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]MinuteQuote, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetMinuteQuotes(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetMinuteQuotesByCode は指定銘柄の株価分足データを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *MinuteQuotesService) GetMinuteQuotesByCode(ctx context.Context, code string) ([]MinuteQuote, error) {
+	return collectAll(s.AllMinuteQuotes(ctx, MinuteQuotesParams{
+		Code: code,
+	}))
 }
 
 // GetMinuteQuotesByCodeAndDate は指定銘柄の指定日の株価分足データを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *MinuteQuotesService) GetMinuteQuotesByCodeAndDate(ctx context.Context, code, date string) ([]MinuteQuote, error) {
-	var allData []MinuteQuote
-	paginationKey := ""
-
-	for {
-		params := MinuteQuotesParams{
-			Code:          code,
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetMinuteQuotes(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *MinuteQuotesService) GetMinuteQuotesByCodeAndDate(ctx context.Context, code string, date types.Date) ([]MinuteQuote, error) {
+	return collectAll(s.AllMinuteQuotes(ctx, MinuteQuotesParams{
+		Code: code,
+		Date: date,
+	}))
 }
 
 // GetMinuteQuotesByDate は指定日の全上場銘柄の株価分足データを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *MinuteQuotesService) GetMinuteQuotesByDate(ctx context.Context, date string) ([]MinuteQuote, error) {
-	var allData []MinuteQuote
-	paginationKey := ""
-
-	for {
-		params := MinuteQuotesParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetMinuteQuotes(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *MinuteQuotesService) GetMinuteQuotesByDate(ctx context.Context, date types.Date) ([]MinuteQuote, error) {
+	return collectAll(s.AllMinuteQuotes(ctx, MinuteQuotesParams{
+		Date: date,
+	}))
 }
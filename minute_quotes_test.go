@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestMinuteQuotesService_GetMinuteQuotes(t *testing.T) {
@@ -27,23 +28,23 @@ func TestMinuteQuotesService_GetMinuteQuotes(t *testing.T) {
 			name: "with code and date",
 			params: MinuteQuotesParams{
 				Code: "86970",
-				Date: "20230324",
+				Date: types.MustParseDate("20230324"),
 			},
 			wantPath: "/equities/bars/minute?code=86970&date=20230324",
 		},
 		{
 			name: "with date only",
 			params: MinuteQuotesParams{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 			},
-			wantPath: "/equities/bars/minute?date=2023-03-24",
+			wantPath: "/equities/bars/minute?date=20230324",
 		},
 		{
 			name: "with code and date range",
 			params: MinuteQuotesParams{
 				Code: "86970",
-				From: "20230301",
-				To:   "20230331",
+				From: types.MustParseDate("20230301"),
+				To:   types.MustParseDate("20230331"),
 			},
 			wantPath: "/equities/bars/minute?code=86970&from=20230301&to=20230331",
 		},
@@ -73,7 +74,7 @@ func TestMinuteQuotesService_GetMinuteQuotes(t *testing.T) {
 			mockResponse := MinuteQuotesResponse{
 				Data: []MinuteQuote{
 					{
-						Date: "2023-03-24",
+						Date: types.MustParseDate("2023-03-24"),
 						Time: "09:00",
 						Code: "86970",
 						O:    2047.0,
@@ -178,7 +179,7 @@ func TestMinuteQuotesResponse_UnmarshalJSON(t *testing.T) {
 	}
 
 	q := resp.Data[0]
-	if q.Date != "2023-03-24" {
+	if q.Date.String() != "2023-03-24" {
 		t.Errorf("UnmarshalJSON() Date = %v, want 2023-03-24", q.Date)
 	}
 	if q.Time != "09:00" {
@@ -224,7 +225,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCode(t *testing.T) {
 	mockResponse1 := MinuteQuotesResponse{
 		Data: []MinuteQuote{
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:00",
 				Code: "86970",
 				O:    2047.0,
@@ -235,7 +236,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCode(t *testing.T) {
 				Va:   25625000.0,
 			},
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:01",
 				Code: "86970",
 				O:    2050.0,
@@ -253,7 +254,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCode(t *testing.T) {
 	mockResponse2 := MinuteQuotesResponse{
 		Data: []MinuteQuote{
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:02",
 				Code: "86970",
 				O:    2051.0,
@@ -296,7 +297,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCodeAndDate(t *testing.T) {
 	mockResponse := MinuteQuotesResponse{
 		Data: []MinuteQuote{
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:00",
 				Code: "86970",
 				O:    2047.0,
@@ -309,7 +310,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCodeAndDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/bars/minute?code=86970&date=20230324", mockResponse)
 
 	// Execute
-	data, err := service.GetMinuteQuotesByCodeAndDate(context.Background(), "86970", "20230324")
+	data, err := service.GetMinuteQuotesByCodeAndDate(context.Background(), "86970", types.MustParseDate("20230324"))
 
 	// Verify
 	if err != nil {
@@ -321,7 +322,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByCodeAndDate(t *testing.T) {
 	if data[0].Code != "86970" {
 		t.Errorf("GetMinuteQuotesByCodeAndDate() returned code %v, want 86970", data[0].Code)
 	}
-	if data[0].Date != "2023-03-24" {
+	if data[0].Date.String() != "2023-03-24" {
 		t.Errorf("GetMinuteQuotesByCodeAndDate() returned date %v, want 2023-03-24", data[0].Date)
 	}
 }
@@ -335,13 +336,13 @@ func TestMinuteQuotesService_GetMinuteQuotesByDate(t *testing.T) {
 	mockResponse := MinuteQuotesResponse{
 		Data: []MinuteQuote{
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:00",
 				Code: "86970",
 				O:    2047.0,
 			},
 			{
-				Date: "2023-03-24",
+				Date: types.MustParseDate("2023-03-24"),
 				Time: "09:00",
 				Code: "13660",
 				O:    1500.0,
@@ -352,7 +353,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/bars/minute?date=20230324", mockResponse)
 
 	// Execute
-	data, err := service.GetMinuteQuotesByDate(context.Background(), "20230324")
+	data, err := service.GetMinuteQuotesByDate(context.Background(), types.MustParseDate("20230324"))
 
 	// Verify
 	if err != nil {
@@ -362,7 +363,7 @@ func TestMinuteQuotesService_GetMinuteQuotesByDate(t *testing.T) {
 		t.Errorf("GetMinuteQuotesByDate() returned %d items, want 2", len(data))
 	}
 	for _, item := range data {
-		if item.Date != "2023-03-24" {
+		if item.Date.String() != "2023-03-24" {
 			t.Errorf("GetMinuteQuotesByDate() returned date %v, want 2023-03-24", item.Date)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -25,11 +26,11 @@ func NewOptionsService(c client.HTTPClient) *OptionsService {
 
 // OptionsParams はオプション四本値のリクエストパラメータです。
 type OptionsParams struct {
-	Date          string // 取引日（必須）（YYYYMMDD または YYYY-MM-DD）
-	Category      string // 商品区分の指定（TOPIXE, NK225E等）
-	Code          string // 対象有価証券コード（categoryでEQOPを指定した場合に設定）
-	ContractFlag  string // 中心限月フラグの指定（1: 中心限月のみ）
-	PaginationKey string // ページネーションキー
+	Date          types.Date // 取引日（必須）
+	Category      string     // 商品区分の指定（TOPIXE, NK225E等）
+	Code          string     // 対象有価証券コード（categoryでEQOPを指定した場合に設定）
+	ContractFlag  string     // 中心限月フラグの指定（1: 中心限月のみ）
+	PaginationKey string     // ページネーションキー
 }
 
 // OptionsResponse はオプション四本値のレスポンスです。
@@ -44,14 +45,14 @@ type OptionsResponse struct {
 // 注意: このデータはプレミアムプラン専用APIで取得されます。
 type Option struct {
 	// 基本情報
-	Code         string  `json:"Code"`         // 銘柄コード
-	ProdCat      string  `json:"ProdCat"`      // オプション商品区分
-	UndSSO       string  `json:"UndSSO"`       // 有価証券オプション対象銘柄（有価証券オプション以外は"-"）
-	Date         string  `json:"Date"`         // 取引日（YYYY-MM-DD形式）
-	CM           string  `json:"CM"`           // 限月（YYYY-MM形式、日経225miniオプションは週表記）
-	Strike       float64 `json:"Strike"`       // 権利行使価格
-	PCDiv        string  `json:"PCDiv"`        // プットコール区分（1: プット、2: コール）
-	EmMrgnTrgDiv string  `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 清算価格算出時）
+	Code         string     `json:"Code"`         // 銘柄コード
+	ProdCat      string     `json:"ProdCat"`      // オプション商品区分
+	UndSSO       string     `json:"UndSSO"`       // 有価証券オプション対象銘柄（有価証券オプション以外は"-"）
+	Date         types.Date `json:"Date"`         // 取引日
+	CM           string     `json:"CM"`           // 限月（YYYY-MM形式、日経225miniオプションは週表記）
+	Strike       float64    `json:"Strike"`       // 権利行使価格
+	PCDiv        string     `json:"PCDiv"`        // プットコール区分（1: プット、2: コール）
+	EmMrgnTrgDiv string     `json:"EmMrgnTrgDiv"` // 緊急取引証拠金発動区分（001: 発動時、002: 清算価格算出時）
 
 	// 日通し四本値
 	O float64 `json:"O"` // 日通し始値
@@ -100,7 +101,7 @@ type RawOption struct {
 	Code         string                `json:"Code"`
 	ProdCat      string                `json:"ProdCat"`
 	UndSSO       string                `json:"UndSSO"`
-	Date         string                `json:"Date"`
+	Date         types.Date            `json:"Date"`
 	CM           string                `json:"CM"`
 	Strike       float64               `json:"Strike"`
 	PCDiv        string                `json:"PCDiv"`
@@ -209,27 +210,23 @@ func (r *OptionsResponse) UnmarshalJSON(data []byte) error {
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *OptionsService) GetOptions(ctx context.Context, params OptionsParams) (*OptionsResponse, error) {
 	// dateは必須パラメータ
-	if params.Date == "" {
+	if params.Date.IsZero() {
 		return nil, fmt.Errorf("date parameter is required")
 	}
 
 	path := "/derivatives/bars/daily/options"
 
-	query := fmt.Sprintf("?date=%s", params.Date)
-	if params.Category != "" {
-		query += fmt.Sprintf("&category=%s", params.Category)
-	}
-	if params.Code != "" {
-		query += fmt.Sprintf("&code=%s", params.Code)
-	}
-	if params.ContractFlag != "" {
-		query += fmt.Sprintf("&contract_flag=%s", params.ContractFlag)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("&pagination_key=%s", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setDate("date", params.Date)
+	query.set("category", params.Category)
+	query.setCode("code", params.Code)
+	query.set("contract_flag", params.ContractFlag)
+	query.set("pagination_key", params.PaginationKey)
 
-	path += query
+	if err := query.err(); err != nil {
+		return nil, err
+	}
+	path += query.encode()
 
 	var resp OptionsResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -239,130 +236,61 @@ func (s *OptionsService) GetOptions(ctx context.Context, params OptionsParams) (
 	return &resp, nil
 }
 
+// AllOptions は指定条件のオプション四本値を1件ずつ返すイテレータです。
+func (s *OptionsService) AllOptions(ctx context.Context, params OptionsParams) iter.Seq2[Option, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Option, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetOptions(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetOptionsByDate は指定日の全オプションデータを取得します。
 // ページネーションを使用して全データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *OptionsService) GetOptionsByDate(ctx context.Context, date string) ([]Option, error) {
-	var allData []Option
-	paginationKey := ""
-
-	for {
-		params := OptionsParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetOptions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *OptionsService) GetOptionsByDate(ctx context.Context, date types.Date) ([]Option, error) {
+	return collectAll(s.AllOptions(ctx, OptionsParams{
+		Date: date,
+	}))
 }
 
 // GetOptionsByCategory は指定日・商品カテゴリのオプションデータを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *OptionsService) GetOptionsByCategory(ctx context.Context, date, category string) ([]Option, error) {
-	var allData []Option
-	paginationKey := ""
-
-	for {
-		params := OptionsParams{
-			Date:          date,
-			Category:      category,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetOptions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *OptionsService) GetOptionsByCategory(ctx context.Context, date types.Date, category string) ([]Option, error) {
+	return collectAll(s.AllOptions(ctx, OptionsParams{
+		Date:     date,
+		Category: category,
+	}))
 }
 
 // GetSecurityOptionsByCode は指定日・銘柄の有価証券オプションデータを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *OptionsService) GetSecurityOptionsByCode(ctx context.Context, date, code string) ([]Option, error) {
-	var allData []Option
-	paginationKey := ""
-
-	for {
-		params := OptionsParams{
-			Date:          date,
-			Category:      "EQOP",
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetOptions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *OptionsService) GetSecurityOptionsByCode(ctx context.Context, date types.Date, code string) ([]Option, error) {
+	return collectAll(s.AllOptions(ctx, OptionsParams{
+		Date:     date,
+		Category: "EQOP",
+		Code:     code,
+	}))
 }
 
 // GetCentralContractMonthOptions は中心限月のオプションデータのみを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
-func (s *OptionsService) GetCentralContractMonthOptions(ctx context.Context, date string) ([]Option, error) {
-	var allData []Option
-	paginationKey := ""
-
-	for {
-		params := OptionsParams{
-			Date:          date,
-			ContractFlag:  "1",
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetOptions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *OptionsService) GetCentralContractMonthOptions(ctx context.Context, date types.Date) ([]Option, error) {
+	return collectAll(s.AllOptions(ctx, OptionsParams{
+		Date:         date,
+		ContractFlag: "1",
+	}))
 }
 
 // Helper methods for Option
@@ -0,0 +1,105 @@
+package pricing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/bars"
+	"github.com/utahta/jquants/types"
+)
+
+// SQDate は限月（YYYY-MM形式）のSQ日（第2金曜日。休業日の場合は直前の営業日）を返します。
+// 営業日の判定にはcalを使用します（calがnilの場合は土日以外を営業日とみなします）。
+// 日経225miniオプションなどの週次限月は対応していないため、APIのSQ日（SQD）を使用してください。
+func SQDate(contractMonth string, cal *bars.Calendar) (types.Date, error) {
+	t, err := time.Parse("2006-01", contractMonth)
+	if err != nil {
+		return types.Date{}, fmt.Errorf("invalid contract month %q: expected YYYY-MM", contractMonth)
+	}
+	d := types.NewDate(t.Year(), t.Month(), 1)
+	d = d.AddDays((int(time.Friday)-int(d.Weekday())+7)%7 + 7)
+	for !cal.IsTradingDay(d) {
+		d = d.AddDays(-1)
+	}
+	return d, nil
+}
+
+// TimeToExpiry は取引日dateから満期expiryまでの暦日数を365で割った年数を返します。満期を過ぎている場合は0です。
+func TimeToExpiry(date, expiry types.Date) float64 {
+	return float64(max(expiry.DaysSince(date), 0)) / 365
+}
+
+// TradingDaysToExpiry は取引日dateの翌日から満期expiry（SQ日）までの営業日数を返します（SQ日を含む）。
+// 営業日の判定にはcalを使用します（calがnilの場合は土日以外を営業日とみなします）。
+func TradingDaysToExpiry(date, expiry types.Date, cal *bars.Calendar) int {
+	n := 0
+	for d := date.AddDays(1); !d.After(expiry); d = d.AddDays(1) {
+		if cal.IsTradingDay(d) {
+			n++
+		}
+	}
+	return n
+}
+
+// TradingTimeToExpiry はTradingDaysToExpiryの営業日数をdaysPerYearで割った年数を返します。
+// daysPerYearが0以下の場合は245とします。
+func TradingTimeToExpiry(date, expiry types.Date, cal *bars.Calendar, daysPerYear float64) float64 {
+	if daysPerYear <= 0 {
+		daysPerYear = 245
+	}
+	return float64(TradingDaysToExpiry(date, expiry, cal)) / daysPerYear
+}
+
+// FromIndexOption は日経225オプションの四本値からParamsを作成します。
+// 満期までの期間は取引日からSQ日（SQDがない場合は限月とcalから求めたSQ日）までのTimeToExpiry、
+// 金利・ボラティリティは理論価格計算用金利（IR）・インプライドボラティリティ（IV）を小数に換算した値です（ない場合は0）。
+// 原証券価格（UnderPx）がない場合はエラーを返します。
+func FromIndexOption(o *jquants.IndexOption, cal *bars.Calendar) (Params, error) {
+	return newParams(o.Date, o.SQD, o.CM, o.PCDiv, o.Strike, o.UnderPx, o.IR, o.IV, cal)
+}
+
+// FromOption はオプション四本値からParamsを作成します。変換方法はFromIndexOptionと同じです。
+func FromOption(o *jquants.Option, cal *bars.Calendar) (Params, error) {
+	var sqd string
+	if o.SQD != nil {
+		sqd = *o.SQD
+	}
+	return newParams(o.Date, sqd, o.CM, o.PCDiv, o.Strike, o.UnderPx, o.IR, o.IV, cal)
+}
+
+func newParams(d types.Date, sqd, cm, pcDiv string, strike float64, underPx, ir, iv *float64, cal *bars.Calendar) (Params, error) {
+	var p Params
+	switch pcDiv {
+	case "1":
+		p.Type = Put
+	case "2":
+		p.Type = Call
+	default:
+		return Params{}, fmt.Errorf("invalid put/call division %q", pcDiv)
+	}
+	if underPx == nil {
+		return Params{}, fmt.Errorf("underlying price is not available")
+	}
+
+	if d.IsZero() {
+		return Params{}, fmt.Errorf("trade date is not available")
+	}
+	expiry, err := types.ParseDate(sqd)
+	if err != nil {
+		if expiry, err = SQDate(cm, cal); err != nil {
+			return Params{}, err
+		}
+	}
+
+	p.Underlying = *underPx
+	p.Strike = strike
+	p.T = TimeToExpiry(d, expiry)
+	if ir != nil {
+		p.Rate = *ir / 100
+	}
+	if iv != nil {
+		p.Vol = *iv / 100
+	}
+	return p, nil
+}
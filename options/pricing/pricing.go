@@ -0,0 +1,234 @@
+// Package pricing はBlack-Scholes・Black-76モデルによるオプションの理論価格、インプライドボラティリティ、
+// グリークス（デルタ・ガンマ・ベガ・セータ・ロー）を求めます。
+//
+// ボラティリティ・金利・配当利回りは小数（20%は0.2）、満期までの期間は年数で指定します。
+// APIのIV・IR（インプライドボラティリティ・理論価格計算用金利）はパーセント表記のため、
+// FromIndexOption・FromOptionは100で割って変換します。
+//
+//	p, err := pricing.FromIndexOption(&o, cal)
+//	iv, err := pricing.BlackScholes.ImpliedVol(p, o.C)
+//	p.Vol = iv
+//	g := pricing.BlackScholes.Greeks(p)
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+// OptionType はオプションの種類（コール・プット）です。
+type OptionType int
+
+const (
+	Call OptionType = iota + 1 // コール
+	Put                        // プット
+)
+
+// String は種類名を返します。
+func (t OptionType) String() string {
+	switch t {
+	case Call:
+		return "call"
+	case Put:
+		return "put"
+	default:
+		return fmt.Sprintf("OptionType(%d)", int(t))
+	}
+}
+
+// Model は理論価格の計算モデルです。
+type Model int
+
+const (
+	// BlackScholes は原資産の現在値を使用するBlack-Scholesモデルです（配当利回りDividendを考慮します）。
+	BlackScholes Model = iota + 1
+	// Black76 は原資産の先物価格を使用するBlack-76モデルです（Dividendは使用しません）。
+	Black76
+)
+
+// String はモデル名を返します。
+func (m Model) String() string {
+	switch m {
+	case BlackScholes:
+		return "Black-Scholes"
+	case Black76:
+		return "Black-76"
+	default:
+		return fmt.Sprintf("Model(%d)", int(m))
+	}
+}
+
+// Params は理論価格の計算に使用する値です。
+type Params struct {
+	Type       OptionType // コール・プット
+	Underlying float64    // 原資産価格（Black76では先物価格）
+	Strike     float64    // 権利行使価格
+	T          float64    // 満期までの年数
+	Rate       float64    // 無リスク金利（連続複利。小数）
+	Dividend   float64    // 配当利回り（連続複利。小数。BlackScholesのみ）
+	Vol        float64    // ボラティリティ（年率。小数）
+}
+
+// Greeks はオプションの感応度です。
+type Greeks struct {
+	Delta float64 // 原資産価格に対する感応度
+	Gamma float64 // デルタの原資産価格に対する感応度
+	Vega  float64 // ボラティリティ1.00（100%）あたりの感応度（1%あたりは100で割る）
+	Theta float64 // 1年あたりの時間経過による価格の変化（1日あたりは365で割る）
+	Rho   float64 // 金利1.00（100%）あたりの感応度
+}
+
+// carry は保有コスト（Black-Scholesは金利 - 配当利回り、Black-76は0）を返します。
+func (m Model) carry(p *Params) float64 {
+	if m == Black76 {
+		return 0
+	}
+	return p.Rate - p.Dividend
+}
+
+// d は満期・ボラティリティが正の場合のd1, d2と、割引係数を返します。
+func (m Model) d(p *Params) (d1, d2, carryDF, rateDF float64) {
+	b := m.carry(p)
+	sqrtT := math.Sqrt(p.T)
+	d1 = (math.Log(p.Underlying/p.Strike) + (b+p.Vol*p.Vol/2)*p.T) / (p.Vol * sqrtT)
+	d2 = d1 - p.Vol*sqrtT
+	return d1, d2, math.Exp((b - p.Rate) * p.T), math.Exp(-p.Rate * p.T)
+}
+
+// degenerate は満期・ボラティリティが0以下で、価格が割り引いた本質的価値になるかどうかを返します。
+func degenerate(p *Params) bool {
+	return p.T <= 0 || p.Vol <= 0 || p.Underlying <= 0 || p.Strike <= 0
+}
+
+// bounds はボラティリティによらない価格の下限と上限を返します。
+func (m Model) bounds(p *Params) (lower, upper float64) {
+	t := max(p.T, 0)
+	fwd := p.Underlying * math.Exp((m.carry(p)-p.Rate)*t)
+	k := p.Strike * math.Exp(-p.Rate*t)
+	if p.Type == Put {
+		return max(k-fwd, 0), k
+	}
+	return max(fwd-k, 0), fwd
+}
+
+// Price は理論価格を返します。満期までの期間・ボラティリティが0以下の場合は割り引いた本質的価値を返します。
+func (m Model) Price(p Params) float64 {
+	if degenerate(&p) {
+		lower, _ := m.bounds(&p)
+		return lower
+	}
+	d1, d2, cdf, rdf := m.d(&p)
+	if p.Type == Put {
+		return p.Strike*rdf*cnd(-d2) - p.Underlying*cdf*cnd(-d1)
+	}
+	return p.Underlying*cdf*cnd(d1) - p.Strike*rdf*cnd(d2)
+}
+
+// Greeks はグリークスを返します。満期までの期間・ボラティリティが0以下の場合はデルタのみ（本質的価値の傾き）を返します。
+func (m Model) Greeks(p Params) Greeks {
+	if degenerate(&p) {
+		lower, _ := m.bounds(&p)
+		var g Greeks
+		if lower > 0 {
+			g.Delta = math.Exp((m.carry(&p) - p.Rate) * max(p.T, 0))
+			if p.Type == Put {
+				g.Delta = -g.Delta
+			}
+		}
+		return g
+	}
+
+	d1, d2, cdf, rdf := m.d(&p)
+	b := m.carry(&p)
+	sqrtT := math.Sqrt(p.T)
+	pdf := npd(d1)
+
+	g := Greeks{
+		Gamma: cdf * pdf / (p.Underlying * p.Vol * sqrtT),
+		Vega:  p.Underlying * cdf * pdf * sqrtT,
+	}
+	decay := -p.Underlying * cdf * pdf * p.Vol / (2 * sqrtT)
+	if p.Type == Put {
+		g.Delta = cdf * (cnd(d1) - 1)
+		g.Theta = decay + (b-p.Rate)*p.Underlying*cdf*cnd(-d1) + p.Rate*p.Strike*rdf*cnd(-d2)
+		g.Rho = -p.T * p.Strike * rdf * cnd(-d2)
+	} else {
+		g.Delta = cdf * cnd(d1)
+		g.Theta = decay - (b-p.Rate)*p.Underlying*cdf*cnd(d1) - p.Rate*p.Strike*rdf*cnd(d2)
+		g.Rho = p.T * p.Strike * rdf * cnd(d2)
+	}
+	if m == Black76 {
+		// 先物価格は金利に依存しないため、割引係数のみが金利に感応する
+		g.Rho = -p.T * m.Price(p)
+	}
+	return g
+}
+
+// ImpliedVol はpriceを理論価格とするボラティリティを返します。p.Volは使用しません。
+// 価格が無裁定の範囲（割り引いた本質的価値以上、原資産価格または割り引いた権利行使価格未満）にない場合、
+// 満期までの期間が0以下の場合はエラーを返します。
+// 範囲を絞りながらニュートン法で求め、収束しない場合は二分法に切り替えます。
+func (m Model) ImpliedVol(p Params, price float64) (float64, error) {
+	if p.T <= 0 || p.Underlying <= 0 || p.Strike <= 0 {
+		return 0, fmt.Errorf("underlying, strike and time to expiry must be positive")
+	}
+	lower, upper := m.bounds(&p)
+	if math.IsNaN(price) || price <= lower || price >= upper {
+		return 0, fmt.Errorf("price %v is outside the no-arbitrage bounds (%v, %v)", price, lower, upper)
+	}
+
+	const (
+		tolerance = 1e-10
+		maxIter   = 200
+	)
+	lo, hi := 1e-9, 1.0
+	// 上限を価格が上回るまで広げる
+	for p.Vol = hi; m.Price(p) < price; p.Vol = hi {
+		if hi >= 100 {
+			return 0, fmt.Errorf("implied volatility exceeds %v", hi)
+		}
+		lo, hi = hi, hi*2
+	}
+
+	// Brenner-Subrahmanyamの近似を初期値とする
+	vol := math.Sqrt(2*math.Pi/max(p.T, 1e-12)) * price / p.Underlying
+	if vol <= lo || vol >= hi {
+		vol = (lo + hi) / 2
+	}
+	for range maxIter {
+		p.Vol = vol
+		diff := m.Price(p) - price
+		if math.Abs(diff) < tolerance {
+			return vol, nil
+		}
+		if diff > 0 {
+			hi = vol
+		} else {
+			lo = vol
+		}
+		if hi-lo < tolerance*1e-2 {
+			return vol, nil
+		}
+
+		next := vol
+		if vega := m.Greeks(p).Vega; vega > 1e-12 {
+			next = vol - diff/vega
+		}
+		// ニュートン法の次の値が範囲外の場合は二分法
+		if next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		vol = next
+	}
+	return 0, fmt.Errorf("implied volatility did not converge for price %v", price)
+}
+
+// cnd は標準正規分布の累積分布関数です。
+func cnd(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// npd は標準正規分布の確率密度関数です。
+func npd(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,174 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/bars"
+	"github.com/utahta/jquants/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func near(t *testing.T, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestModel_Price(t *testing.T) {
+	p := Params{Type: Call, Underlying: 100, Strike: 100, T: 1, Rate: 0.05, Vol: 0.2}
+	near(t, "BS call", BlackScholes.Price(p), 10.450584, 1e-6)
+	g := BlackScholes.Greeks(p)
+	near(t, "BS call delta", g.Delta, 0.636831, 1e-6)
+	near(t, "BS call gamma", g.Gamma, 0.018762, 1e-6)
+	near(t, "BS call vega", g.Vega, 37.524035, 1e-6)
+	near(t, "BS call theta", g.Theta, -6.414028, 1e-6)
+	near(t, "BS call rho", g.Rho, 53.232482, 1e-6)
+
+	near(t, "Black-76 call", Black76.Price(p), 7.577082, 1e-6)
+
+	// プット・コール・パリティ
+	for _, m := range []Model{BlackScholes, Black76} {
+		q := Params{Type: Call, Underlying: 39000, Strike: 40000, T: 30.0 / 365, Rate: 0.005, Dividend: 0.02, Vol: 0.25}
+		call := m.Price(q)
+		q.Type = Put
+		put := m.Price(q)
+		fwd := q.Underlying * math.Exp((m.carry(&q)-q.Rate)*q.T)
+		near(t, m.String()+" parity", call-put, fwd-q.Strike*math.Exp(-q.Rate*q.T), 1e-8)
+	}
+
+	// 満期では本質的価値
+	p.T = 0
+	near(t, "expired call", BlackScholes.Price(p), 0, 0)
+	p.Underlying = 110
+	near(t, "expired ITM call", BlackScholes.Price(p), 10, 1e-12)
+	if g := BlackScholes.Greeks(p); g.Delta != 1 || g.Gamma != 0 {
+		t.Errorf("expired Greeks = %+v", g)
+	}
+}
+
+// TestModel_Greeks はグリークスを数値微分と比較します。
+func TestModel_Greeks(t *testing.T) {
+	for _, m := range []Model{BlackScholes, Black76} {
+		for _, typ := range []OptionType{Call, Put} {
+			p := Params{Type: typ, Underlying: 105, Strike: 100, T: 0.5, Rate: 0.03, Dividend: 0.01, Vol: 0.3}
+			g := m.Greeks(p)
+			name := m.String() + " " + typ.String()
+			diff := func(f func(q *Params, h float64)) float64 {
+				const h = 1e-5
+				up, down := p, p
+				f(&up, h)
+				f(&down, -h)
+				return (m.Price(up) - m.Price(down)) / (2 * h)
+			}
+			near(t, name+" delta", g.Delta, diff(func(q *Params, h float64) { q.Underlying += h }), 1e-6)
+			near(t, name+" vega", g.Vega, diff(func(q *Params, h float64) { q.Vol += h }), 1e-5)
+			near(t, name+" theta", g.Theta, -diff(func(q *Params, h float64) { q.T += h }), 1e-5)
+			near(t, name+" rho", g.Rho, diff(func(q *Params, h float64) { q.Rate += h }), 1e-5)
+
+			const h = 1e-3
+			up, down := p, p
+			up.Underlying += h
+			down.Underlying -= h
+			near(t, name+" gamma", g.Gamma, (m.Greeks(up).Delta-m.Greeks(down).Delta)/(2*h), 1e-6)
+		}
+	}
+}
+
+func TestModel_ImpliedVol(t *testing.T) {
+	for _, m := range []Model{BlackScholes, Black76} {
+		for _, vol := range []float64{0.01, 0.15, 0.8, 3} {
+			for _, strike := range []float64{60, 100, 160} {
+				for _, typ := range []OptionType{Call, Put} {
+					p := Params{Type: typ, Underlying: 100, Strike: strike, T: 0.25, Rate: 0.01, Vol: vol}
+					price := m.Price(p)
+					lower, upper := m.bounds(&p)
+					if price-lower < 1e-9 || upper-price < 1e-9 {
+						continue // 価格から区別できない
+					}
+					got, err := m.ImpliedVol(p, price)
+					if err != nil {
+						t.Errorf("%s %s K=%v vol=%v: %v", m, typ, strike, vol, err)
+						continue
+					}
+					p.Vol = got
+					near(t, "repriced", m.Price(p), price, 1e-8)
+				}
+			}
+		}
+	}
+
+	p := Params{Type: Call, Underlying: 100, Strike: 90, T: 0.25}
+	for _, price := range []float64{5, 100, math.NaN()} {
+		if _, err := BlackScholes.ImpliedVol(p, price); err == nil {
+			t.Errorf("ImpliedVol(%v) expected error", price)
+		}
+	}
+	p.T = 0
+	if _, err := BlackScholes.ImpliedVol(p, 12); err == nil {
+		t.Error("ImpliedVol(T=0) expected error")
+	}
+}
+
+func TestSQDate(t *testing.T) {
+	d, err := SQDate("2024-05", nil)
+	if err != nil || d.String() != "2024-05-10" {
+		t.Errorf("SQDate() = %v, %v", d, err)
+	}
+	cal := bars.NewCalendar([]jquants.TradingCalendar{{Date: types.MustParseDate("2024-05-10"), HolDiv: jquants.HolidayDivisionNonTradingDay}})
+	if d, _ := SQDate("2024-05", cal); d.String() != "2024-05-09" {
+		t.Errorf("SQDate(holiday) = %v", d)
+	}
+	if d, _ := SQDate("2024-03", nil); d.String() != "2024-03-08" {
+		t.Errorf("SQDate(2024-03) = %v", d)
+	}
+	if _, err := SQDate("2024-05-W2", nil); err == nil {
+		t.Error("SQDate(weekly) expected error")
+	}
+
+	date, expiry := types.NewDate(2024, 5, 1), types.NewDate(2024, 5, 10)
+	near(t, "TimeToExpiry", TimeToExpiry(date, expiry), 9.0/365, 1e-12)
+	if n := TradingDaysToExpiry(date, expiry, nil); n != 7 {
+		t.Errorf("TradingDaysToExpiry() = %d, want 7", n)
+	}
+	holidays := bars.NewCalendar([]jquants.TradingCalendar{
+		{Date: types.MustParseDate("2024-05-03"), HolDiv: jquants.HolidayDivisionNonTradingDay},
+		{Date: types.MustParseDate("2024-05-06"), HolDiv: jquants.HolidayDivisionNonTradingDay},
+	})
+	near(t, "TradingTimeToExpiry", TradingTimeToExpiry(date, expiry, holidays, 0), 5.0/245, 1e-12)
+}
+
+func TestFromIndexOption(t *testing.T) {
+	o := jquants.IndexOption{Date: types.MustParseDate("2024-05-01"), CM: "2024-05", Strike: 38000, PCDiv: "2", SQD: "2024-05-10",
+		UnderPx: ptr(38405.66), IV: ptr(18.5), IR: ptr(0.5), Theo: ptr(750.0)}
+	p, err := FromIndexOption(&o, nil)
+	if err != nil {
+		t.Fatalf("FromIndexOption() error = %v", err)
+	}
+	want := Params{Type: Call, Underlying: 38405.66, Strike: 38000, T: 9.0 / 365, Rate: 0.005, Vol: 0.185}
+	if p != want {
+		t.Errorf("FromIndexOption() = %+v, want %+v", p, want)
+	}
+	if _, err := BlackScholes.ImpliedVol(p, *o.Theo); err != nil {
+		t.Errorf("ImpliedVol() error = %v", err)
+	}
+
+	// SQ日がない場合は限月から求める
+	opt := jquants.Option{Date: types.MustParseDate("2024-05-01"), CM: "2024-05", Strike: 38000, PCDiv: "1", UnderPx: ptr(38405.66)}
+	p, err = FromOption(&opt, nil)
+	if err != nil || p.Type != Put || p.T != 9.0/365 || p.Vol != 0 {
+		t.Errorf("FromOption() = %+v, %v", p, err)
+	}
+
+	opt.UnderPx = nil
+	if _, err := FromOption(&opt, nil); err == nil {
+		t.Error("missing UnderPx: expected error")
+	}
+	o.PCDiv = ""
+	if _, err := FromIndexOption(&o, nil); err == nil {
+		t.Error("invalid PCDiv: expected error")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestOptionsService_GetOptions(t *testing.T) {
@@ -19,25 +20,25 @@ func TestOptionsService_GetOptions(t *testing.T) {
 		{
 			name: "with all parameters",
 			params: OptionsParams{
-				Date:          "20240723",
+				Date:          types.MustParseDate("20240723"),
 				Category:      "NK225E",
 				Code:          "7203",
 				ContractFlag:  "1",
 				PaginationKey: "test_key",
 			},
-			wantPath: "/derivatives/bars/daily/options?date=20240723&category=NK225E&code=7203&contract_flag=1&pagination_key=test_key",
+			wantPath: "/derivatives/bars/daily/options?date=20240723&category=NK225E&code=72030&contract_flag=1&pagination_key=test_key",
 		},
 		{
 			name: "with date only (required)",
 			params: OptionsParams{
-				Date: "20240723",
+				Date: types.MustParseDate("20240723"),
 			},
 			wantPath: "/derivatives/bars/daily/options?date=20240723",
 		},
 		{
 			name: "with date and category",
 			params: OptionsParams{
-				Date:     "20240723",
+				Date:     types.MustParseDate("20240723"),
 				Category: "TOPIXE",
 			},
 			wantPath: "/derivatives/bars/daily/options?date=20240723&category=TOPIXE",
@@ -45,11 +46,11 @@ func TestOptionsService_GetOptions(t *testing.T) {
 		{
 			name: "with EQOP category and code",
 			params: OptionsParams{
-				Date:     "20240723",
+				Date:     types.MustParseDate("20240723"),
 				Category: "EQOP",
 				Code:     "7203",
 			},
-			wantPath: "/derivatives/bars/daily/options?date=20240723&category=EQOP&code=7203",
+			wantPath: "/derivatives/bars/daily/options?date=20240723&category=EQOP&code=72030",
 		},
 		{
 			name:    "without date (should error)",
@@ -72,7 +73,7 @@ func TestOptionsService_GetOptions(t *testing.T) {
 							Code:         "140014505",
 							ProdCat:      "TOPIXE",
 							UndSSO:       "-",
-							Date:         "2024-07-23",
+							Date:         types.MustParseDate("2024-07-23"),
 							CM:           "2025-01",
 							Strike:       2450.0,
 							PCDiv:        "2",
@@ -152,7 +153,7 @@ func TestOptionsService_GetOptionsByDate(t *testing.T) {
 				Code:         "140014505",
 				ProdCat:      "TOPIXE",
 				UndSSO:       "-",
-				Date:         "2024-07-23",
+				Date:         types.MustParseDate("2024-07-23"),
 				CM:           "2025-01",
 				Strike:       2450.0,
 				PCDiv:        "2",
@@ -172,7 +173,7 @@ func TestOptionsService_GetOptionsByDate(t *testing.T) {
 				Code:         "140014506",
 				ProdCat:      "NK225E",
 				UndSSO:       "-",
-				Date:         "2024-07-23",
+				Date:         types.MustParseDate("2024-07-23"),
 				CM:           "2025-01",
 				Strike:       40000.0,
 				PCDiv:        "1",
@@ -186,7 +187,7 @@ func TestOptionsService_GetOptionsByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options?date=20240723&pagination_key=next_page", mockResponse2)
 
 	// Execute
-	options, err := service.GetOptionsByDate(context.Background(), "20240723")
+	options, err := service.GetOptionsByDate(context.Background(), types.MustParseDate("20240723"))
 
 	// Verify
 	if err != nil {
@@ -215,7 +216,7 @@ func TestOptionsService_GetOptionsByCategory(t *testing.T) {
 				Code:         "140014505",
 				ProdCat:      "NK225E",
 				UndSSO:       "-",
-				Date:         "2024-07-23",
+				Date:         types.MustParseDate("2024-07-23"),
 				CM:           "2025-01",
 				Strike:       40000.0,
 				PCDiv:        "2",
@@ -226,7 +227,7 @@ func TestOptionsService_GetOptionsByCategory(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options?date=20240723&category=NK225E", mockResponse)
 
 	// Execute
-	options, err := service.GetOptionsByCategory(context.Background(), "20240723", "NK225E")
+	options, err := service.GetOptionsByCategory(context.Background(), types.MustParseDate("20240723"), "NK225E")
 
 	// Verify
 	if err != nil {
@@ -252,7 +253,7 @@ func TestOptionsService_GetSecurityOptionsByCode(t *testing.T) {
 				Code:         "10014505",
 				ProdCat:      "EQOP",
 				UndSSO:       "7203",
-				Date:         "2024-07-23",
+				Date:         types.MustParseDate("2024-07-23"),
 				CM:           "2025-01",
 				Strike:       2500.0,
 				PCDiv:        "1",
@@ -260,10 +261,10 @@ func TestOptionsService_GetSecurityOptionsByCode(t *testing.T) {
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/derivatives/bars/daily/options?date=20240723&category=EQOP&code=7203", mockResponse)
+	mockClient.SetResponse("GET", "/derivatives/bars/daily/options?date=20240723&category=EQOP&code=72030", mockResponse)
 
 	// Execute
-	options, err := service.GetSecurityOptionsByCode(context.Background(), "20240723", "7203")
+	options, err := service.GetSecurityOptionsByCode(context.Background(), types.MustParseDate("20240723"), "7203")
 
 	// Verify
 	if err != nil {
@@ -292,7 +293,7 @@ func TestOptionsService_GetCentralContractMonthOptions(t *testing.T) {
 				Code:         "140014505",
 				ProdCat:      "NK225E",
 				UndSSO:       "-",
-				Date:         "2024-07-23",
+				Date:         types.MustParseDate("2024-07-23"),
 				CM:           "2025-01",
 				Strike:       40000.0,
 				PCDiv:        "2",
@@ -304,7 +305,7 @@ func TestOptionsService_GetCentralContractMonthOptions(t *testing.T) {
 	mockClient.SetResponse("GET", "/derivatives/bars/daily/options?date=20240723&contract_flag=1", mockResponse)
 
 	// Execute
-	options, err := service.GetCentralContractMonthOptions(context.Background(), "20240723")
+	options, err := service.GetCentralContractMonthOptions(context.Background(), types.MustParseDate("20240723"))
 
 	// Verify
 	if err != nil {
@@ -327,7 +328,7 @@ func TestOptionsService_GetOptions_Error(t *testing.T) {
 	mockClient.SetError("GET", "/derivatives/bars/daily/options?date=20240723", fmt.Errorf("unauthorized"))
 
 	// Execute
-	_, err := service.GetOptions(context.Background(), OptionsParams{Date: "20240723"})
+	_, err := service.GetOptions(context.Background(), OptionsParams{Date: types.MustParseDate("20240723")})
 
 	// Verify
 	if err == nil {
@@ -0,0 +1,53 @@
+package jquants
+
+import (
+	"context"
+	"iter"
+)
+
+// paginate はページネーションキーを辿って全ページの要素を1件ずつ返すイテレータを作成します。
+// fetchはページネーションキーを受け取り、そのページの要素と次ページのキーを返します。
+// startは最初のページのキーです（空文字で先頭ページから）。
+// 各サービスのAllXxxメソッドはこのイテレータを返し、params.PaginationKeyをstartとして渡します。
+//
+// ページは必要になった時点で1ページずつ取得するため、全件をメモリに保持しません。
+// 呼び出し側がbreakすると以降のページは取得しません。
+// エラーが発生した場合はゼロ値とエラーを1度だけ返して終了します。
+func paginate[T any](ctx context.Context, start string, fetch func(ctx context.Context, paginationKey string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		paginationKey := start
+		for {
+			items, next, err := fetch(ctx, paginationKey)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// ページネーションキーがなければ終了
+			if next == "" {
+				return
+			}
+			paginationKey = next
+		}
+	}
+}
+
+// collectAll はイテレータの全要素をスライスに集めます。
+// エラーが発生した場合は途中までの要素を破棄してエラーを返します。
+func collectAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
@@ -0,0 +1,161 @@
+package jquants
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {items: []int{1, 2}, next: "p2"},
+		"p2": {items: []int{3}, next: "p3"},
+		"p3": {items: []int{4, 5}, next: ""},
+	}
+
+	var fetched []string
+	fetch := func(ctx context.Context, paginationKey string) ([]int, string, error) {
+		fetched = append(fetched, paginationKey)
+		page := pages[paginationKey]
+		return page.items, page.next, nil
+	}
+
+	t.Run("all pages", func(t *testing.T) {
+		fetched = nil
+		got, err := collectAll(paginate(context.Background(), "", fetch))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+			t.Errorf("got %v, want [1 2 3 4 5]", got)
+		}
+		if len(fetched) != 3 {
+			t.Errorf("fetched %v, want 3 pages", fetched)
+		}
+	})
+
+	t.Run("break stops fetching", func(t *testing.T) {
+		fetched = nil
+		var got []int
+		for v, err := range paginate(context.Background(), "", fetch) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, v)
+			if v == 2 {
+				break
+			}
+		}
+		// 1ページ目の途中で終了したため2ページ目は取得しない
+		if len(fetched) != 1 {
+			t.Errorf("fetched %v, want only the first page", fetched)
+		}
+		if len(got) != 2 {
+			t.Errorf("got %v, want [1 2]", got)
+		}
+	})
+
+	t.Run("start from pagination key", func(t *testing.T) {
+		fetched = nil
+		got, err := collectAll(paginate(context.Background(), "p3", fetch))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 2 || got[0] != 4 {
+			t.Errorf("got %v, want [4 5]", got)
+		}
+	})
+}
+
+func TestPaginate_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	fetch := func(ctx context.Context, paginationKey string) ([]int, string, error) {
+		if paginationKey == "p2" {
+			return nil, "", wantErr
+		}
+		return []int{1}, "p2", nil
+	}
+
+	var items, errs int
+	for _, err := range paginate(context.Background(), "", fetch) {
+		if err != nil {
+			errs++
+			if !errors.Is(err, wantErr) {
+				t.Errorf("err = %v, want %v", err, wantErr)
+			}
+			continue
+		}
+		items++
+	}
+	if items != 1 || errs != 1 {
+		t.Errorf("items = %d, errs = %d, want 1, 1", items, errs)
+	}
+
+	// collectAllはエラー時に途中までの要素を返さない
+	got, err := collectAll(paginate(context.Background(), "", fetch))
+	if !errors.Is(err, wantErr) || got != nil {
+		t.Errorf("collectAll() = (%v, %v), want (nil, %v)", got, err, wantErr)
+	}
+}
+
+func TestQuotesService_AllDailyQuotes(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewQuotesService(mockClient)
+
+	mockClient.SetResponse("GET", "/equities/bars/daily?date=20240101", DailyQuotesResponse{
+		Data:          []DailyQuote{{Date: types.MustParseDate("2024-01-01"), Code: "72030"}, {Date: types.MustParseDate("2024-01-01"), Code: "99840"}},
+		PaginationKey: "next",
+	})
+	mockClient.SetResponse("GET", "/equities/bars/daily?date=20240101&pagination_key=next", DailyQuotesResponse{
+		Data: []DailyQuote{{Date: types.MustParseDate("2024-01-01"), Code: "13010"}},
+	})
+
+	var codes []string
+	for q, err := range service.AllDailyQuotes(context.Background(), DailyQuotesParams{Date: types.MustParseDate("20240101")}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		codes = append(codes, q.Code)
+	}
+	if len(codes) != 3 || codes[2] != "13010" {
+		t.Errorf("codes = %v, want [72030 99840 13010]", codes)
+	}
+	if mockClient.RequestCount != 2 {
+		t.Errorf("RequestCount = %d, want 2", mockClient.RequestCount)
+	}
+
+	// 1件目でbreakすると次ページは取得しない
+	mockClient.RequestCount = 0
+	for range service.AllDailyQuotes(context.Background(), DailyQuotesParams{Date: types.MustParseDate("20240101")}) {
+		break
+	}
+	if mockClient.RequestCount != 1 {
+		t.Errorf("RequestCount = %d after break, want 1", mockClient.RequestCount)
+	}
+}
+
+func TestTimelyDisclosureService_AllDisclosures_Error(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewTimelyDisclosureService(mockClient)
+
+	// パラメータ検証エラーもイテレータ経由で返る
+	var errs int
+	for _, err := range service.AllDisclosures(context.Background(), TimelyDisclosureParams{}) {
+		if err == nil {
+			t.Fatal("expected an error for missing date and code")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want 1", errs)
+	}
+	if mockClient.RequestCount != 0 {
+		t.Errorf("RequestCount = %d, want 0", mockClient.RequestCount)
+	}
+}
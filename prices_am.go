@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -42,8 +43,8 @@ type PricesAMResponse struct {
 // 注意: このデータはプレミアムプラン専用APIで取得されます。
 type PriceAM struct {
 	// 基本情報
-	Date string `json:"Date"` // 日付（YYYY-MM-DD形式）
-	Code string `json:"Code"` // 銘柄コード
+	Date types.Date `json:"Date"` // 日付
+	Code string     `json:"Code"` // 銘柄コード
 
 	// 前場四本値データ
 	MO *float64 `json:"MO"` // 前場始値（前場最初の約定価格）
@@ -59,8 +60,8 @@ type PriceAM struct {
 // RawPriceAM is used for unmarshaling JSON response with mixed types
 type RawPriceAM struct {
 	// 基本情報
-	Date string `json:"Date"`
-	Code string `json:"Code"`
+	Date types.Date `json:"Date"`
+	Code string     `json:"Code"`
 
 	// 前場四本値データ
 	MO types.NullableFloat64 `json:"MO"`
@@ -119,17 +120,14 @@ func (r *PricesAMResponse) UnmarshalJSON(data []byte) error {
 func (s *PricesAMService) GetPricesAM(ctx context.Context, params PricesAMParams) (*PricesAMResponse, error) {
 	path := "/equities/bars/daily/am"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp PricesAMResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -139,6 +137,18 @@ func (s *PricesAMService) GetPricesAM(ctx context.Context, params PricesAMParams
 	return &resp, nil
 }
 
+// AllPricesAM は指定条件の前場四本値を1件ずつ返すイテレータです。
+func (s *PricesAMService) AllPricesAM(ctx context.Context, params PricesAMParams) iter.Seq2[PriceAM, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]PriceAM, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetPricesAM(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
+
 // GetPricesAMByCode は指定銘柄の前場四本値データを取得します。
 //
 // 注意: このAPIはプレミアムプラン専用です。
@@ -153,29 +163,7 @@ func (s *PricesAMService) GetPricesAMByCode(ctx context.Context, code string) (*
 // 注意: このAPIはプレミアムプラン専用です。
 // スタンダードプラン以下では "This API is not available on your subscription" エラーが返されます。
 func (s *PricesAMService) GetAllPricesAM(ctx context.Context) ([]PriceAM, error) {
-	var allData []PriceAM
-	paginationKey := ""
-
-	for {
-		params := PricesAMParams{
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetPricesAM(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllPricesAM(ctx, PricesAMParams{}))
 }
 
 // GetMorningRange は前場の値幅を計算します。
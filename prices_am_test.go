@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestPricesAMService_GetPricesAM(t *testing.T) {
@@ -53,7 +54,7 @@ func TestPricesAMService_GetPricesAM(t *testing.T) {
 			mockResponse := PricesAMResponse{
 				Data: []PriceAM{
 					{
-						Date: "2023-03-20",
+						Date: types.MustParseDate("2023-03-20"),
 						Code: "39400",
 						MO:   floatPtr(232.0),
 						MH:   floatPtr(244.0),
@@ -97,7 +98,7 @@ func TestPricesAMService_GetPricesAMByCode(t *testing.T) {
 	mockResponse := PricesAMResponse{
 		Data: []PriceAM{
 			{
-				Date: "2023-03-20",
+				Date: types.MustParseDate("2023-03-20"),
 				Code: "39400",
 				MO:   floatPtr(232.0),
 				MH:   floatPtr(244.0),
@@ -135,12 +136,12 @@ func TestPricesAMService_GetAllPricesAM(t *testing.T) {
 	mockResponse1 := PricesAMResponse{
 		Data: []PriceAM{
 			{
-				Date: "2023-03-20",
+				Date: types.MustParseDate("2023-03-20"),
 				Code: "13010",
 				MC:   floatPtr(2000.0),
 			},
 			{
-				Date: "2023-03-20",
+				Date: types.MustParseDate("2023-03-20"),
 				Code: "13020",
 				MC:   floatPtr(1500.0),
 			},
@@ -152,7 +153,7 @@ func TestPricesAMService_GetAllPricesAM(t *testing.T) {
 	mockResponse2 := PricesAMResponse{
 		Data: []PriceAM{
 			{
-				Date: "2023-03-20",
+				Date: types.MustParseDate("2023-03-20"),
 				Code: "13030",
 				MC:   floatPtr(1800.0),
 			},
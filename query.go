@@ -0,0 +1,106 @@
+package jquants
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/utahta/jquants/types"
+)
+
+// queryParams はリクエストのクエリパラメータを組み立てます。
+// 値はurl.Valuesと同じ規則でエスケープされるため、ページネーションキーやカーソルに
+// 「+」「/」「=」などの予約文字が含まれていても壊れません。
+// パラメータは追加した順序で出力されます。
+type queryParams struct {
+	values  url.Values
+	keys    []string
+	invalid error // 最初に検出したパラメータの形式エラー
+}
+
+// newQuery は空のqueryParamsを作成します。
+func newQuery() *queryParams {
+	return &queryParams{values: url.Values{}}
+}
+
+// set はパラメータを設定します。値が空文字の場合は何もしません（省略可能なパラメータ用）。
+func (q *queryParams) set(key, value string) {
+	if value == "" {
+		return
+	}
+	if _, ok := q.values[key]; !ok {
+		q.keys = append(q.keys, key)
+	}
+	q.values.Set(key, value)
+}
+
+// setDate は日付パラメータを YYYYMMDD 形式で設定します。ゼロ値の場合は何もしません。
+func (q *queryParams) setDate(key string, value types.Date) {
+	q.set(key, value.Compact())
+}
+
+// setDateOrMonth は日付または年月（YYYYMM、YYYY-MM）を受けるパラメータを設定します。
+func (q *queryParams) setDateOrMonth(key, value string) {
+	if value != "" && !isMonth(value) {
+		if _, err := types.ParseDate(value); err != nil {
+			q.fail(key, err)
+		}
+	}
+	q.set(key, value)
+}
+
+// setCode は銘柄コードパラメータを5桁の形式に正規化して設定します。
+// 4桁と5桁の指定が同じリクエスト（キャッシュキー）になるようにするためです。
+// 銘柄コードの形式でない場合はerrで返せるよう記録します。
+func (q *queryParams) setCode(key, value string) {
+	if value == "" {
+		return
+	}
+	code, err := types.ParseCode(value)
+	if err != nil {
+		q.fail(key, err)
+		q.set(key, value)
+		return
+	}
+	q.set(key, code.Long())
+}
+
+func (q *queryParams) fail(key string, err error) {
+	if q.invalid == nil {
+		q.invalid = fmt.Errorf("invalid %s parameter: %w", key, err)
+	}
+}
+
+// err はsetDateなどで検出したパラメータの形式エラーを返します。
+func (q *queryParams) err() error {
+	return q.invalid
+}
+
+func isMonth(s string) bool {
+	for _, layout := range []string{"2006-01", "200601"} {
+		if _, err := time.Parse(layout, s); err == nil && len(s) == len(layout) {
+			return true
+		}
+	}
+	return false
+}
+
+// encode は先頭に「?」を付けたクエリ文字列を返します。パラメータがない場合は空文字を返します。
+func (q *queryParams) encode() string {
+	if len(q.keys) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, key := range q.keys {
+		if b.Len() == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(q.values.Get(key)))
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package jquants
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func TestQueryParams_Encode(t *testing.T) {
+	tests := []struct {
+		name   string
+		params [][2]string
+		want   string
+	}{
+		{name: "empty", want: ""},
+		{name: "empty values are skipped", params: [][2]string{{"code", ""}, {"date", ""}}, want: ""},
+		{name: "insertion order", params: [][2]string{{"to", "20240131"}, {"code", "7203"}}, want: "?to=20240131&code=7203"},
+		{name: "overwrite keeps position", params: [][2]string{{"code", "7203"}, {"date", "20240101"}, {"code", "9984"}}, want: "?code=9984&date=20240101"},
+		{name: "reserved characters", params: [][2]string{{"pagination_key", "a+b/c=="}}, want: "?pagination_key=a%2Bb%2Fc%3D%3D"},
+		{name: "ampersand and space", params: [][2]string{{"cursor", "x&y z"}}, want: "?cursor=x%26y+z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := newQuery()
+			for _, p := range tt.params {
+				query.set(p[0], p[1])
+			}
+			if got := query.encode(); got != tt.want {
+				t.Errorf("encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// ページネーションキーはBase64由来の「+」「/」「=」を含むことがあり、
+// エスケープせずに連結するとサーバー側で別の値として解釈される
+func TestQuotesService_GetDailyQuotes_ReservedCharsInPaginationKey(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewQuotesService(mockClient)
+
+	const key = "eyJjb2RlIjoiNzIwMyJ9+a/b=="
+	wantPath := "/equities/bars/daily?date=20240101&pagination_key=eyJjb2RlIjoiNzIwMyJ9%2Ba%2Fb%3D%3D"
+	mockClient.SetResponse("GET", wantPath, DailyQuotesResponse{
+		Data: []DailyQuote{{Date: types.MustParseDate("2024-01-01"), Code: "72030"}},
+	})
+
+	resp, err := service.GetDailyQuotes(context.Background(), DailyQuotesParams{Date: types.MustParseDate("20240101"), PaginationKey: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 {
+		t.Errorf("got %d quotes, want 1", len(resp.Data))
+	}
+
+	// サーバー側でデコードすると元のキーに戻る
+	_, rawQuery, _ := strings.Cut(mockClient.LastPath, "?")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	if got := values.Get("pagination_key"); got != key {
+		t.Errorf("decoded pagination_key = %q, want %q", got, key)
+	}
+}
+
+func TestTimelyDisclosureService_AllDisclosures_ReservedCharsInPaginationKey(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewTimelyDisclosureService(mockClient)
+
+	mockClient.SetResponse("GET", "/td/list?date=20250401", TimelyDisclosureResponse{
+		Data:          []TimelyDisclosure{{DiscNo: "1"}},
+		PaginationKey: "k+1/2=",
+	})
+	mockClient.SetResponse("GET", "/td/list?date=20250401&pagination_key=k%2B1%2F2%3D", TimelyDisclosureResponse{
+		Data: []TimelyDisclosure{{DiscNo: "2"}},
+	})
+
+	var discNos []string
+	for d, err := range service.AllDisclosures(context.Background(), TimelyDisclosureParams{Date: types.MustParseDate("20250401")}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		discNos = append(discNos, d.DiscNo)
+	}
+	if len(discNos) != 2 || discNos[1] != "2" {
+		t.Errorf("discNos = %v, want [1 2]", discNos)
+	}
+}
+
+// 日付パラメータはtypes.DateからYYYYMMDD形式で送信され、ゼロ値は省略される
+func TestQueryParams_Date(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewQuotesService(mockClient)
+
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=72030&from=20240115", DailyQuotesResponse{})
+	params := DailyQuotesParams{Code: "7203", From: types.NewDate(2024, time.January, 15)}
+	if _, err := service.GetDailyQuotes(context.Background(), params); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if want := "/equities/bars/daily?code=72030&from=20240115"; mockClient.LastPath != want {
+		t.Errorf("path = %q, want %q", mockClient.LastPath, want)
+	}
+}
+
+func TestBulkService_GetFiles_InvalidMonth(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewBulkService(mockClient)
+
+	mockClient.SetResponse("GET", "/bulk/list?endpoint=%2Fequities%2Fbars%2Fdaily&from=2024-01&to=20241231", BulkListResponse{})
+	if _, err := service.GetFiles(context.Background(), BulkListParams{Endpoint: "/equities/bars/daily", From: "2024-01", To: "20241231"}); err != nil {
+		t.Errorf("unexpected error for month and date: %v", err)
+	}
+	if _, err := service.GetFiles(context.Background(), BulkListParams{Endpoint: "/equities/bars/daily", From: "2024-13"}); err == nil {
+		t.Error("expected an error for an invalid month")
+	}
+}
+
+func TestQueryParams_Code(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewQuotesService(mockClient)
+
+	// 4桁・5桁・小文字の指定は同じ5桁のリクエストになる
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=130A0", DailyQuotesResponse{})
+	for _, code := range []string{"130A", "130A0", "130a"} {
+		if _, err := service.GetDailyQuotes(context.Background(), DailyQuotesParams{Code: code}); err != nil {
+			t.Errorf("GetDailyQuotes(%q) unexpected error: %v", code, err)
+		}
+	}
+
+	mockClient.RequestCount = 0
+	if _, err := service.GetDailyQuotes(context.Background(), DailyQuotesParams{Code: "72"}); err == nil {
+		t.Error("expected an error for an invalid code")
+	}
+	if mockClient.RequestCount != 0 {
+		t.Errorf("RequestCount = %d, want 0", mockClient.RequestCount)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -33,8 +34,8 @@ func NewQuotesService(c client.HTTPClient) *QuotesService {
 // 売買が成立しなかった日は四本値、取引高、売買代金がnullになります。
 type DailyQuote struct {
 	// 基本情報
-	Date string `json:"Date"` // 日付 (YYYY-MM-DD形式)
-	Code string `json:"Code"` // 銘柄コード
+	Date types.Date `json:"Date"` // 日付
+	Code string     `json:"Code"` // 銘柄コード
 
 	// 日通しデータ（調整前）
 	O  *float64 `json:"O"`  // 始値（調整前）
@@ -91,8 +92,8 @@ type DailyQuote struct {
 
 // RawDailyQuote is used for unmarshaling JSON response with mixed types
 type RawDailyQuote struct {
-	Date string `json:"Date"`
-	Code string `json:"Code"`
+	Date types.Date `json:"Date"`
+	Code string     `json:"Code"`
 	// 日通しデータ
 	O         types.NullableFloat64 `json:"O"`
 	H         types.NullableFloat64 `json:"H"`
@@ -221,11 +222,11 @@ func (d *DailyQuotesResponse) UnmarshalJSON(data []byte) error {
 }
 
 type DailyQuotesParams struct {
-	Code          string // 銘柄コード（4桁または5桁）
-	Date          string // 基準日付（YYYYMMDD または YYYY-MM-DD）
-	From          string // 開始日付（YYYYMMDD または YYYY-MM-DD）
-	To            string // 終了日付（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（4桁または5桁）
+	Date          types.Date // 基準日付
+	From          types.Date // 開始日付
+	To            types.Date // 終了日付
+	PaginationKey string     // ページネーションキー
 }
 
 // GetDailyQuotes は指定された条件で日次株価データを取得します。
@@ -238,26 +239,17 @@ type DailyQuotesParams struct {
 func (s *QuotesService) GetDailyQuotes(ctx context.Context, params DailyQuotesParams) (*DailyQuotesResponse, error) {
 	path := "/equities/bars/daily"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp DailyQuotesResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -267,36 +259,28 @@ func (s *QuotesService) GetDailyQuotes(ctx context.Context, params DailyQuotesPa
 	return &resp, nil
 }
 
-// GetDailyQuotesByCode は指定銘柄の全期間の株価データを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *QuotesService) GetDailyQuotesByCode(ctx context.Context, code string) ([]DailyQuote, error) {
-	var allQuotes []DailyQuote
-	paginationKey := ""
-
-	for {
-		params := DailyQuotesParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllDailyQuotes は指定条件の日次株価データを1件ずつ返すイテレータです。
+func (s *QuotesService) AllDailyQuotes(ctx context.Context, params DailyQuotesParams) iter.Seq2[DailyQuote, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]DailyQuote, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetDailyQuotes(ctx, params)
 		if err != nil {
-			return nil, err
-		}
-
-		allQuotes = append(allQuotes, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
+			return nil, "", err
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allQuotes, nil
+// GetDailyQuotesByCode は指定銘柄の全期間の株価データを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *QuotesService) GetDailyQuotesByCode(ctx context.Context, code string) ([]DailyQuote, error) {
+	return collectAll(s.AllDailyQuotes(ctx, DailyQuotesParams{
+		Code: code,
+	}))
 }
 
 // GetDailyQuotesByCodeAndDate は指定銘柄の指定日の株価データを取得します。
-func (s *QuotesService) GetDailyQuotesByCodeAndDate(ctx context.Context, code, date string) ([]DailyQuote, error) {
+func (s *QuotesService) GetDailyQuotesByCodeAndDate(ctx context.Context, code string, date types.Date) ([]DailyQuote, error) {
 	resp, err := s.GetDailyQuotes(ctx, DailyQuotesParams{
 		Code: code,
 		Date: date,
@@ -309,61 +293,20 @@ func (s *QuotesService) GetDailyQuotesByCodeAndDate(ctx context.Context, code, d
 
 // GetDailyQuotesByCodeAndDateRange は指定銘柄の指定期間の株価データを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *QuotesService) GetDailyQuotesByCodeAndDateRange(ctx context.Context, code, from, to string) ([]DailyQuote, error) {
-	var allQuotes []DailyQuote
-	paginationKey := ""
-
-	for {
-		params := DailyQuotesParams{
-			Code:          code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDailyQuotes(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allQuotes = append(allQuotes, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allQuotes, nil
+func (s *QuotesService) GetDailyQuotesByCodeAndDateRange(ctx context.Context, code string, from, to types.Date) ([]DailyQuote, error) {
+	return collectAll(s.AllDailyQuotes(ctx, DailyQuotesParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetDailyQuotesByDate は指定日の全銘柄の株価データを取得します。
 // ページネーションを使用して大量データを分割取得します。
-func (s *QuotesService) GetDailyQuotesByDate(ctx context.Context, date string) ([]DailyQuote, error) {
-	var allQuotes []DailyQuote
-	paginationKey := ""
-
-	for {
-		params := DailyQuotesParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDailyQuotes(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allQuotes = append(allQuotes, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allQuotes, nil
+func (s *QuotesService) GetDailyQuotesByDate(ctx context.Context, date types.Date) ([]DailyQuote, error) {
+	return collectAll(s.AllDailyQuotes(ctx, DailyQuotesParams{
+		Date: date,
+	}))
 }
 
 // IsStopHigh はストップ高かどうかを判定します
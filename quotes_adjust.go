@@ -0,0 +1,278 @@
+package jquants
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/utahta/jquants/types"
+)
+
+// CorporateAction は権利落ちによる株数の変更（株式分割・株式併合・ライツイシュー）を表します。
+type CorporateAction struct {
+	Date      types.Date // 権利落ち日
+	Code      string     // 銘柄コード
+	Type      string     // 権利落種類（ExRightsType定数）。ExRTがない場合は調整係数から分割・併合を判定
+	AdjFactor float64    // 調整係数（例: 1:2の株式分割は0.5）
+	Ratio     float64    // 1株あたりの権利落ち後の株数（1/AdjFactor。例: 1:2の株式分割は2、10:1の株式併合は0.1）
+}
+
+// DividendAdjustment はトータルリターン調整に使用した配当です。
+type DividendAdjustment struct {
+	Date      types.Date // 調整を適用した営業日（権利落日。休業日の場合は翌営業日）
+	ExDate    types.Date // 権利落日
+	Amount    float64    // 1株当たり配当金額
+	PrevClose float64    // 権利落日前の直近の終値（調整前）
+	Factor    float64    // 調整係数（1 - Amount / PrevClose）
+}
+
+// AdjustedQuote は基準日の株数基準に調整した日次株価です。売買が成立しなかった日の四本値と取引高はnilです。
+type AdjustedQuote struct {
+	Date   types.Date // 日付
+	Code   string     // 銘柄コード
+	O      *float64   // 調整後始値
+	H      *float64   // 調整後高値
+	L      *float64   // 調整後安値
+	C      *float64   // 調整後終値
+	Vo     *float64   // 調整後取引高
+	Va     *float64   // 取引代金（円。調整しない）
+	Factor float64    // 調整前の価格に乗じた係数
+}
+
+// AdjustedSeries はAdjustDailyQuotesで求めた調整後の株価の時系列です。
+type AdjustedSeries struct {
+	Code        string               // 銘柄コード
+	Anchor      types.Date           // 基準日（この日の株数基準に揃える）
+	TotalReturn bool                 // 配当を含めたトータルリターン調整かどうか
+	Quotes      []AdjustedQuote      // 調整後の株価（日付の古い順）
+	Actions     []CorporateAction    // 株式分割・株式併合・ライツイシューの一覧（日付の古い順）
+	Dividends   []DividendAdjustment // トータルリターン調整に使用した配当（日付の古い順）
+}
+
+// AdjustOption はAdjustDailyQuotesの動作を設定するオプションです。
+type AdjustOption func(*adjustConfig)
+
+type adjustConfig struct {
+	anchor    types.Date
+	forward   bool
+	dividends []Dividend
+	total     bool
+}
+
+// WithAdjustAnchor は調整の基準日を設定します。
+// 基準日以前の権利落ちは基準日の株数基準に遡って調整し（後方調整）、基準日より後の権利落ちは基準日の株数基準に戻します（前方調整）。
+// 基準日が休業日の場合は直前の営業日の株数基準を使用します。
+func WithAdjustAnchor(date types.Date) AdjustOption {
+	return func(c *adjustConfig) {
+		c.anchor = date
+	}
+}
+
+// WithAdjustForward は基準日を指定しない場合に、最終日ではなく初日を基準日にします（前方調整）。
+func WithAdjustForward() AdjustOption {
+	return func(c *adjustConfig) {
+		c.forward = true
+	}
+}
+
+// WithTotalReturn は配当を含めたトータルリターン調整を行います。
+// 権利落日の前日までの価格に (1 - 1株当たり配当金額 / 権利落日前の終値) を乗じ、配当の再投資を仮定した価格にします。
+// 削除・訂正された通知は除き、同じ権利落日に決定と予想がある場合は決定を使用します。取引高は調整しません。
+func WithTotalReturn(dividends []Dividend) AdjustOption {
+	return func(c *adjustConfig) {
+		c.dividends = dividends
+		c.total = true
+	}
+}
+
+// AdjustDailyQuotes は1銘柄の調整前の日次株価から、基準日の株数基準に揃えた調整後の株価と権利落ちの一覧を求めます。
+// APIの調整済み株価（AdjC等）は取得時点の株数基準のため取得のたびに変わりますが、
+// この関数は調整係数（AdjFactor）を累積して任意の基準日の株数基準に揃えます。
+// 基準日を指定しない場合は最終日を基準日とします（後方調整）。
+//
+// 価格は調整係数の累積値の比で、取引高はその逆数で調整します。調整係数がない日（0）は1とみなします。
+func AdjustDailyQuotes(quotes []DailyQuote, opts ...AdjustOption) (*AdjustedSeries, error) {
+	var cfg adjustConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	quotes = slices.Clone(quotes)
+	slices.SortStableFunc(quotes, func(a, b DailyQuote) int { return a.Date.Compare(b.Date) })
+	series := &AdjustedSeries{TotalReturn: cfg.total}
+	if len(quotes) == 0 {
+		return series, nil
+	}
+	series.Code = quotes[0].Code
+	for _, q := range quotes {
+		if q.Code != series.Code {
+			return nil, fmt.Errorf("quotes must be for a single code: %s, %s", series.Code, q.Code)
+		}
+	}
+
+	anchor := quotes[len(quotes)-1].Date
+	if cfg.forward {
+		anchor = quotes[0].Date
+	}
+	if !cfg.anchor.IsZero() {
+		anchor = cfg.anchor
+	}
+	series.Anchor = anchor
+
+	divFactors := map[int]float64{}
+	if cfg.total {
+		series.Dividends = dividendAdjustments(quotes, cfg.dividends)
+		for _, d := range series.Dividends {
+			i, _ := slices.BinarySearchFunc(quotes, d.Date, func(q DailyQuote, date types.Date) int { return q.Date.Compare(date) })
+			if f, ok := divFactors[i]; ok {
+				divFactors[i] = f * d.Factor
+			} else {
+				divFactors[i] = d.Factor
+			}
+		}
+	}
+
+	// cum[i] はi日目までの価格の調整係数の累積値、shares[i] は株式分割・併合のみの累積値
+	cum := make([]float64, len(quotes))
+	shares := make([]float64, len(quotes))
+	c, s := 1.0, 1.0
+	anchorCum, anchorShares := 1.0, 1.0
+	for i, q := range quotes {
+		if q.AdjFactor > 0 {
+			c *= q.AdjFactor
+			s *= q.AdjFactor
+		}
+		if f, ok := divFactors[i]; ok {
+			c *= f
+		}
+		cum[i], shares[i] = c, s
+		if !q.Date.After(anchor) {
+			anchorCum, anchorShares = c, s
+		}
+
+		if q.AdjFactor > 0 && q.AdjFactor != 1 || q.ExRT != nil {
+			series.Actions = append(series.Actions, corporateAction(&q))
+		}
+	}
+
+	series.Quotes = make([]AdjustedQuote, len(quotes))
+	for i, q := range quotes {
+		f := anchorCum / cum[i]
+		series.Quotes[i] = AdjustedQuote{
+			Date:   q.Date,
+			Code:   q.Code,
+			O:      scale(q.O, f),
+			H:      scale(q.H, f),
+			L:      scale(q.L, f),
+			C:      scale(q.C, f),
+			Vo:     scale(q.Vo, shares[i]/anchorShares),
+			Va:     q.Va,
+			Factor: f,
+		}
+	}
+	return series, nil
+}
+
+func corporateAction(q *DailyQuote) CorporateAction {
+	a := CorporateAction{Date: q.Date, Code: q.Code, AdjFactor: q.AdjFactor}
+	switch {
+	case q.ExRT != nil:
+		a.Type = *q.ExRT
+	case q.AdjFactor < 1:
+		a.Type = ExRightsTypeSplit
+	default:
+		a.Type = ExRightsTypeReverseSplit
+	}
+	if q.AdjFactor > 0 {
+		a.Ratio = 1 / q.AdjFactor
+	}
+	return a
+}
+
+// dividendAdjustments は日付順の日次株価に対して、トータルリターン調整に使用する配当を求めます。
+// 期間外の権利落日、権利落日前の終値がない配当、金額が未定の配当は除きます。
+func dividendAdjustments(quotes []DailyQuote, dividends []Dividend) []DividendAdjustment {
+	var adjustments []DividendAdjustment
+	for _, d := range effectiveDividends(dividends) {
+		if d.Code != quotes[0].Code {
+			continue
+		}
+		amount, ok := d.DivRate.Get()
+		if !ok || amount <= 0 {
+			continue
+		}
+		exDate, err := types.ParseDate(d.ExDate)
+		if err != nil {
+			continue
+		}
+		i, _ := slices.BinarySearchFunc(quotes, exDate, func(q DailyQuote, date types.Date) int { return q.Date.Compare(date) })
+		if i == len(quotes) {
+			continue
+		}
+		var prev *float64
+		for j := i - 1; j >= 0 && prev == nil; j-- {
+			prev = quotes[j].C
+		}
+		if prev == nil || *prev <= amount {
+			continue
+		}
+		adjustments = append(adjustments, DividendAdjustment{
+			Date:      quotes[i].Date,
+			ExDate:    exDate,
+			Amount:    amount,
+			PrevClose: *prev,
+			Factor:    1 - amount / *prev,
+		})
+	}
+	slices.SortStableFunc(adjustments, func(a, b DividendAdjustment) int { return a.Date.Compare(b.Date) })
+	return adjustments
+}
+
+// effectiveDividends は削除・訂正された通知を除き、権利落日ごとに有効な配当通知を1件返します。
+// 同じ権利落日に複数の通知がある場合は、決定を予想より優先し、その中で最新の通知を使用します。
+func effectiveDividends(dividends []Dividend) []Dividend {
+	superseded := map[string]bool{}
+	for _, d := range dividends {
+		if (d.IsRevision() || d.IsDeleted()) && d.CARefNo != "" && d.CARefNo != d.RefNo {
+			superseded[d.CARefNo] = true
+		}
+	}
+
+	byExDate := map[string]Dividend{}
+	for _, d := range dividends {
+		if d.IsDeleted() || superseded[d.RefNo] {
+			continue
+		}
+		cur, ok := byExDate[d.ExDate]
+		if !ok || cmp.Or(
+			cmp.Compare(boolRank(d.IsResult()), boolRank(cur.IsResult())),
+			cmp.Compare(d.PubDate, cur.PubDate),
+			cmp.Compare(d.PubTime, cur.PubTime),
+			cmp.Compare(d.RefNo, cur.RefNo),
+		) > 0 {
+			byExDate[d.ExDate] = d
+		}
+	}
+
+	out := make([]Dividend, 0, len(byExDate))
+	for _, d := range byExDate {
+		out = append(out, d)
+	}
+	slices.SortFunc(out, func(a, b Dividend) int { return cmp.Compare(a.ExDate, b.ExDate) })
+	return out
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func scale(v *float64, f float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	x := *v * f
+	return &x
+}
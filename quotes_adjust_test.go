@@ -0,0 +1,113 @@
+package jquants
+
+import (
+	"testing"
+
+	"github.com/utahta/jquants/types"
+)
+
+func adjustTestQuotes() []DailyQuote {
+	split := ExRightsTypeSplit
+	return []DailyQuote{
+		{Date: types.MustParseDate("2024-03-27"), Code: "86970", C: floatPtr(1000), Vo: floatPtr(100), AdjFactor: 1},
+		{Date: types.MustParseDate("2024-03-28"), Code: "86970", C: floatPtr(980), Vo: floatPtr(100), AdjFactor: 1}, // 配当落ち（20円）
+		{Date: types.MustParseDate("2024-04-01"), Code: "86970", C: floatPtr(500), Vo: floatPtr(200), AdjFactor: 0.5, ExRT: &split},
+		{Date: types.MustParseDate("2024-04-02"), Code: "86970", AdjFactor: 1},                                       // 売買不成立
+		{Date: types.MustParseDate("2024-04-03"), Code: "86970", C: floatPtr(5100), Vo: floatPtr(20), AdjFactor: 10}, // 10:1の株式併合
+	}
+}
+
+func TestAdjustDailyQuotes(t *testing.T) {
+	// 入力の順序によらず日付順に処理する
+	quotes := adjustTestQuotes()
+	quotes[0], quotes[4] = quotes[4], quotes[0]
+
+	s, err := AdjustDailyQuotes(quotes)
+	if err != nil {
+		t.Fatalf("AdjustDailyQuotes() error = %v", err)
+	}
+	if s.Code != "86970" || s.Anchor.String() != "2024-04-03" || len(s.Quotes) != 5 {
+		t.Fatalf("series = %+v", s)
+	}
+	approx(t, "back C[0]", s.Quotes[0].C, 5000)
+	approx(t, "back Vo[0]", s.Quotes[0].Vo, 20)
+	approx(t, "back C[2]", s.Quotes[2].C, 5000)
+	approx(t, "back C[4]", s.Quotes[4].C, 5100)
+	if s.Quotes[3].C != nil || s.Quotes[3].Factor != 10 {
+		t.Errorf("Quotes[3] = %+v", s.Quotes[3])
+	}
+
+	if len(s.Actions) != 2 {
+		t.Fatalf("Actions = %+v", s.Actions)
+	}
+	if a := s.Actions[0]; a.Date.String() != "2024-04-01" || a.Type != ExRightsTypeSplit || a.Ratio != 2 {
+		t.Errorf("Actions[0] = %+v", a)
+	}
+	// ExRTがない場合は調整係数から判定する
+	if a := s.Actions[1]; a.Type != ExRightsTypeReverseSplit || a.Ratio != 0.1 {
+		t.Errorf("Actions[1] = %+v", a)
+	}
+
+	// 前方調整は初日の株数基準に揃える
+	s, err = AdjustDailyQuotes(adjustTestQuotes(), WithAdjustForward())
+	if err != nil {
+		t.Fatalf("AdjustDailyQuotes() error = %v", err)
+	}
+	approx(t, "forward C[0]", s.Quotes[0].C, 1000)
+	approx(t, "forward C[2]", s.Quotes[2].C, 1000)
+	approx(t, "forward C[4]", s.Quotes[4].C, 1020)
+	approx(t, "forward Vo[2]", s.Quotes[2].Vo, 100)
+
+	// 休業日を基準日にすると直前の営業日の株数基準を使用する
+	s, err = AdjustDailyQuotes(adjustTestQuotes(), WithAdjustAnchor(types.MustParseDate("20240402")))
+	if err != nil {
+		t.Fatalf("AdjustDailyQuotes() error = %v", err)
+	}
+	if s.Anchor.String() != "2024-04-02" {
+		t.Errorf("Anchor = %q", s.Anchor)
+	}
+	approx(t, "anchor C[0]", s.Quotes[0].C, 500)
+	approx(t, "anchor C[2]", s.Quotes[2].C, 500)
+	approx(t, "anchor C[4]", s.Quotes[4].C, 510)
+}
+
+func TestAdjustDailyQuotes_TotalReturn(t *testing.T) {
+	dividends := []Dividend{
+		{Code: "86970", RefNo: "1", StatCode: "1", FRCode: "2", PubDate: "2024-01-30", ExDate: "2024-03-28", DivRate: types.NewNullable(30.0)},
+		// 決定の通知を予想より優先する
+		{Code: "86970", RefNo: "2", StatCode: "1", FRCode: "1", PubDate: "2024-03-15", ExDate: "2024-03-28", DivRate: types.NewNullable(25.0)},
+		// 訂正された通知は使用しない
+		{Code: "86970", RefNo: "3", StatCode: "2", FRCode: "1", CARefNo: "2", PubDate: "2024-03-20", ExDate: "2024-03-28", DivRate: types.NewNullable(20.0)},
+		// 削除された通知、期間外、他の銘柄は使用しない
+		{Code: "86970", RefNo: "4", StatCode: "1", FRCode: "1", PubDate: "2024-03-01", ExDate: "2024-04-02", DivRate: types.NewNullable(10.0)},
+		{Code: "86970", RefNo: "5", StatCode: "3", CARefNo: "4", PubDate: "2024-03-05", ExDate: "2024-04-02"},
+		{Code: "86970", RefNo: "6", StatCode: "1", FRCode: "1", ExDate: "2024-09-27", DivRate: types.NewNullable(10.0)},
+		{Code: "72030", RefNo: "7", StatCode: "1", FRCode: "1", ExDate: "2024-03-28", DivRate: types.NewNullable(10.0)},
+	}
+
+	s, err := AdjustDailyQuotes(adjustTestQuotes(), WithTotalReturn(dividends))
+	if err != nil {
+		t.Fatalf("AdjustDailyQuotes() error = %v", err)
+	}
+	if !s.TotalReturn || len(s.Dividends) != 1 {
+		t.Fatalf("Dividends = %+v", s.Dividends)
+	}
+	if d := s.Dividends[0]; d.Date.String() != "2024-03-28" || d.Amount != 20 || d.PrevClose != 1000 {
+		t.Errorf("Dividends[0] = %+v", d)
+	}
+	approx(t, "total C[0]", s.Quotes[0].C, 5000*0.98)
+	approx(t, "total C[1]", s.Quotes[1].C, 4900)
+	// 取引高は配当で調整しない
+	approx(t, "total Vo[0]", s.Quotes[0].Vo, 20)
+}
+
+func TestAdjustDailyQuotes_Errors(t *testing.T) {
+	s, err := AdjustDailyQuotes(nil)
+	if err != nil || len(s.Quotes) != 0 {
+		t.Errorf("AdjustDailyQuotes(nil) = %+v, %v", s, err)
+	}
+	quotes := append(adjustTestQuotes(), DailyQuote{Date: types.MustParseDate("2024-04-04"), Code: "72030"})
+	if _, err := AdjustDailyQuotes(quotes); err == nil {
+		t.Error("mixed codes: expected error")
+	}
+}
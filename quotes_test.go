@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestQuotesService_GetDailyQuotes(t *testing.T) {
@@ -18,22 +19,22 @@ func TestQuotesService_GetDailyQuotes(t *testing.T) {
 	}{
 		{
 			name:     "with all parameters",
-			params:   DailyQuotesParams{Code: "7203", From: "20240101", To: "20240131"},
-			wantPath: "/equities/bars/daily?code=7203&from=20240101&to=20240131",
+			params:   DailyQuotesParams{Code: "7203", From: types.MustParseDate("20240101"), To: types.MustParseDate("20240131")},
+			wantPath: "/equities/bars/daily?code=72030&from=20240101&to=20240131",
 		},
 		{
 			name:     "with code only",
 			params:   DailyQuotesParams{Code: "7203"},
-			wantPath: "/equities/bars/daily?code=7203",
+			wantPath: "/equities/bars/daily?code=72030",
 		},
 		{
 			name:     "with date only",
-			params:   DailyQuotesParams{Date: "20240101"},
+			params:   DailyQuotesParams{Date: types.MustParseDate("20240101")},
 			wantPath: "/equities/bars/daily?date=20240101",
 		},
 		{
 			name:     "with pagination key",
-			params:   DailyQuotesParams{Date: "20240101", PaginationKey: "key123"},
+			params:   DailyQuotesParams{Date: types.MustParseDate("20240101"), PaginationKey: "key123"},
 			wantPath: "/equities/bars/daily?date=20240101&pagination_key=key123",
 		},
 		{
@@ -53,7 +54,7 @@ func TestQuotesService_GetDailyQuotes(t *testing.T) {
 			mockResponse := DailyQuotesResponse{
 				Data: []DailyQuote{
 					{
-						Date:      "20240101",
+						Date:      types.MustParseDate("20240101"),
 						Code:      "7203",
 						O:         floatPtr(2490.0),
 						H:         floatPtr(2510.0),
@@ -66,7 +67,7 @@ func TestQuotesService_GetDailyQuotes(t *testing.T) {
 						AdjC:      floatPtr(2500.0),
 					},
 					{
-						Date: "20240102",
+						Date: types.MustParseDate("20240102"),
 						Code: "7203",
 						C:    floatPtr(2520.0),
 						UL:   "1", // ストップ高
@@ -108,7 +109,7 @@ func TestQuotesService_GetDailyQuotesByCode(t *testing.T) {
 	mockResponse1 := DailyQuotesResponse{
 		Data: []DailyQuote{
 			{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 				Code: "7203",
 				O:    floatPtr(2480.0),
 				H:    floatPtr(2510.0),
@@ -125,7 +126,7 @@ func TestQuotesService_GetDailyQuotesByCode(t *testing.T) {
 	mockResponse2 := DailyQuotesResponse{
 		Data: []DailyQuote{
 			{
-				Date: "20240102",
+				Date: types.MustParseDate("20240102"),
 				Code: "7203",
 				C:    floatPtr(2520.0),
 			},
@@ -133,8 +134,8 @@ func TestQuotesService_GetDailyQuotesByCode(t *testing.T) {
 		PaginationKey: "",
 	}
 
-	mockClient.SetResponse("GET", "/equities/bars/daily?code=7203", mockResponse1)
-	mockClient.SetResponse("GET", "/equities/bars/daily?code=7203&pagination_key=next_page_key", mockResponse2)
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=72030", mockResponse1)
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=72030&pagination_key=next_page_key", mockResponse2)
 
 	// Test
 	quotes, err := service.GetDailyQuotesByCode(context.Background(), "7203")
@@ -158,7 +159,7 @@ func TestQuotesService_GetDailyQuotes_Error(t *testing.T) {
 	service := NewQuotesService(mockClient)
 
 	// Mock error
-	mockClient.SetError("GET", "/equities/bars/daily?code=7203", fmt.Errorf("API error"))
+	mockClient.SetError("GET", "/equities/bars/daily?code=72030", fmt.Errorf("API error"))
 
 	// Test
 	_, err := service.GetDailyQuotes(context.Background(), DailyQuotesParams{Code: "7203"})
@@ -176,12 +177,12 @@ func TestQuotesService_GetDailyQuotesByDate(t *testing.T) {
 	mockResponse1 := DailyQuotesResponse{
 		Data: []DailyQuote{
 			{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 				Code: "1301",
 				C:    floatPtr(1000.0),
 			},
 			{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 				Code: "1332",
 				C:    floatPtr(2000.0),
 			},
@@ -193,7 +194,7 @@ func TestQuotesService_GetDailyQuotesByDate(t *testing.T) {
 	mockResponse2 := DailyQuotesResponse{
 		Data: []DailyQuote{
 			{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 				Code: "7203",
 				C:    floatPtr(2500.0),
 			},
@@ -205,7 +206,7 @@ func TestQuotesService_GetDailyQuotesByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/bars/daily?date=20240101&pagination_key=next_page_key", mockResponse2)
 
 	// Test
-	quotes, err := service.GetDailyQuotesByDate(context.Background(), "20240101")
+	quotes, err := service.GetDailyQuotesByDate(context.Background(), types.MustParseDate("20240101"))
 	if err != nil {
 		t.Errorf("GetDailyQuotesByDate failed: %v", err)
 	}
@@ -440,7 +441,7 @@ func TestQuotesService_GetDailyQuotesByCodeAndDate(t *testing.T) {
 	mockResponse := DailyQuotesResponse{
 		Data: []DailyQuote{
 			{
-				Date: "20240101",
+				Date: types.MustParseDate("20240101"),
 				Code: "7203",
 				O:    floatPtr(2490.0),
 				H:    floatPtr(2510.0),
@@ -449,10 +450,10 @@ func TestQuotesService_GetDailyQuotesByCodeAndDate(t *testing.T) {
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/equities/bars/daily?code=7203&date=20240101", mockResponse)
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=72030&date=20240101", mockResponse)
 
 	// Test
-	quotes, err := service.GetDailyQuotesByCodeAndDate(context.Background(), "7203", "20240101")
+	quotes, err := service.GetDailyQuotesByCodeAndDate(context.Background(), "7203", types.MustParseDate("20240101"))
 	if err != nil {
 		t.Errorf("GetDailyQuotesByCodeAndDate failed: %v", err)
 	}
@@ -462,12 +463,12 @@ func TestQuotesService_GetDailyQuotesByCodeAndDate(t *testing.T) {
 		t.Errorf("Expected 1 quote, got %d", len(quotes))
 	}
 
-	if quotes[0].Code != "7203" || quotes[0].Date != "20240101" {
+	if quotes[0].Code != "7203" || quotes[0].Date.String() != "2024-01-01" {
 		t.Errorf("Quote data mismatch: code=%s, date=%s", quotes[0].Code, quotes[0].Date)
 	}
 
-	if mockClient.LastPath != "/equities/bars/daily?code=7203&date=20240101" {
-		t.Errorf("Expected path /equities/bars/daily?code=7203&date=20240101, got %s", mockClient.LastPath)
+	if mockClient.LastPath != "/equities/bars/daily?code=72030&date=20240101" {
+		t.Errorf("Expected path /equities/bars/daily?code=72030&date=20240101, got %s", mockClient.LastPath)
 	}
 }
 
@@ -476,13 +477,13 @@ func TestQuotesService_GetDailyQuotesByCodeAndDateRange(t *testing.T) {
 	mockClient := client.NewMockClient()
 	service := NewQuotesService(mockClient)
 
-	basePath := "/equities/bars/daily?code=7203&from=20240101&to=20240131"
+	basePath := "/equities/bars/daily?code=72030&from=20240101&to=20240131"
 
 	// Mock response - 最初のページ
 	mockResponse1 := DailyQuotesResponse{
 		Data: []DailyQuote{
-			{Date: "20240101", Code: "7203", C: floatPtr(2500.0)},
-			{Date: "20240102", Code: "7203", C: floatPtr(2510.0)},
+			{Date: types.MustParseDate("20240101"), Code: "7203", C: floatPtr(2500.0)},
+			{Date: types.MustParseDate("20240102"), Code: "7203", C: floatPtr(2510.0)},
 		},
 		PaginationKey: "next_page_key",
 	}
@@ -490,7 +491,7 @@ func TestQuotesService_GetDailyQuotesByCodeAndDateRange(t *testing.T) {
 	// Mock response - 2ページ目
 	mockResponse2 := DailyQuotesResponse{
 		Data: []DailyQuote{
-			{Date: "20240103", Code: "7203", C: floatPtr(2520.0)},
+			{Date: types.MustParseDate("20240103"), Code: "7203", C: floatPtr(2520.0)},
 		},
 		PaginationKey: "",
 	}
@@ -499,7 +500,7 @@ func TestQuotesService_GetDailyQuotesByCodeAndDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", basePath+"&pagination_key=next_page_key", mockResponse2)
 
 	// Test
-	quotes, err := service.GetDailyQuotesByCodeAndDateRange(context.Background(), "7203", "20240101", "20240131")
+	quotes, err := service.GetDailyQuotesByCodeAndDateRange(context.Background(), "7203", types.MustParseDate("20240101"), types.MustParseDate("20240131"))
 	if err != nil {
 		t.Errorf("GetDailyQuotesByCodeAndDateRange failed: %v", err)
 	}
@@ -509,10 +510,10 @@ func TestQuotesService_GetDailyQuotesByCodeAndDateRange(t *testing.T) {
 		t.Errorf("Expected 3 quotes total, got %d", len(quotes))
 	}
 
-	if quotes[0].Date != "20240101" || *quotes[0].C != 2500.0 {
+	if quotes[0].Date.String() != "2024-01-01" || *quotes[0].C != 2500.0 {
 		t.Errorf("First quote data mismatch")
 	}
-	if quotes[2].Date != "20240103" || *quotes[2].C != 2520.0 {
+	if quotes[2].Date.String() != "2024-01-03" || *quotes[2].C != 2520.0 {
 		t.Errorf("Last quote data mismatch")
 	}
 }
@@ -13,7 +13,7 @@ import (
 // errors.As で *client.APIError を取り出せることを確認する。
 func TestServiceError_As(t *testing.T) {
 	mockClient := client.NewMockClient()
-	mockClient.SetError("GET", "/equities/bars/daily?code=7203", &client.APIError{
+	mockClient.SetError("GET", "/equities/bars/daily?code=72030", &client.APIError{
 		StatusCode: http.StatusTooManyRequests,
 		Body:       `{"message":"Too Many Requests"}`,
 	})
@@ -45,7 +45,7 @@ func TestServiceError_As(t *testing.T) {
 func TestServiceError_MisleadingMessage(t *testing.T) {
 	mockClient := client.NewMockClient()
 	// ボディに 401 / 429 という数字列を含むが、実際のステータスは400
-	mockClient.SetError("GET", "/equities/bars/daily?code=4290", &client.APIError{
+	mockClient.SetError("GET", "/equities/bars/daily?code=42900", &client.APIError{
 		StatusCode: http.StatusBadRequest,
 		Body:       `{"message":"invalid code 4290, retry limit 429 exceeded for 401k"}`,
 	})
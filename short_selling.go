@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -21,11 +22,11 @@ func NewShortSellingService(c client.HTTPClient) *ShortSellingService {
 
 // ShortSellingParams は業種別空売り比率のリクエストパラメータです。
 type ShortSellingParams struct {
-	Sector33Code  string // 33業種コード（s33またはdateのいずれかが必須）
-	Date          string // 日付（YYYYMMDD または YYYY-MM-DD）（s33またはdateのいずれかが必須）
-	From          string // 期間の開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 期間の終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Sector33Code  string     // 33業種コード（s33またはdateのいずれかが必須）
+	Date          types.Date // 日付（s33またはdateのいずれかが必須）
+	From          types.Date // 期間の開始日
+	To            types.Date // 期間の終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // ShortSellingResponse は業種別空売り比率のレスポンスです。
@@ -38,8 +39,8 @@ type ShortSellingResponse struct {
 // J-Quants API /markets/short-ratio エンドポイントのレスポンスデータ。
 type ShortSelling struct {
 	// 基本情報
-	Date string `json:"Date"` // 日付（YYYY-MM-DD形式）
-	S33  string `json:"S33"`  // 33業種コード
+	Date types.Date `json:"Date"` // 日付
+	S33  string     `json:"S33"`  // 33業種コード
 
 	// 売買代金データ（単位：円）
 	SellExShortVa float64 `json:"SellExShortVa"` // 実注文の売買代金（空売り以外の通常売り注文）
@@ -50,8 +51,8 @@ type ShortSelling struct {
 // RawShortSelling is used for unmarshaling JSON response with mixed types
 type RawShortSelling struct {
 	// 基本情報
-	Date string `json:"Date"`
-	S33  string `json:"S33"`
+	Date types.Date `json:"Date"`
+	S33  string     `json:"S33"`
 
 	// 売買代金データ
 	SellExShortVa types.NullableFloat64 `json:"SellExShortVa"`
@@ -96,32 +97,23 @@ func (r *ShortSellingResponse) UnmarshalJSON(data []byte) error {
 // GetShortSelling は業種別空売り比率を取得します。
 func (s *ShortSellingService) GetShortSelling(ctx context.Context, params ShortSellingParams) (*ShortSellingResponse, error) {
 	// s33またはdateのいずれかが必須
-	if params.Sector33Code == "" && params.Date == "" {
+	if params.Sector33Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either s33 or date parameter is required")
 	}
 
 	path := "/markets/short-ratio"
 
-	query := "?"
-	if params.Sector33Code != "" {
-		query += fmt.Sprintf("s33=%s&", params.Sector33Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("s33", params.Sector33Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp ShortSellingResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -131,96 +123,45 @@ func (s *ShortSellingService) GetShortSelling(ctx context.Context, params ShortS
 	return &resp, nil
 }
 
-// GetShortSellingBySector は指定業種の空売り比率を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *ShortSellingService) GetShortSellingBySector(ctx context.Context, sector33Code string) ([]ShortSelling, error) {
-	var allData []ShortSelling
-	paginationKey := ""
-
-	for {
-		params := ShortSellingParams{
-			Sector33Code:  sector33Code,
-			PaginationKey: paginationKey,
-		}
-
+// AllShortSelling は指定条件の業種別空売り比率を1件ずつ返すイテレータです。
+func (s *ShortSellingService) AllShortSelling(ctx context.Context, params ShortSellingParams) iter.Seq2[ShortSelling, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]ShortSelling, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetShortSelling(ctx, params)
 		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+			return nil, "", err
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allData, nil
+// GetShortSellingBySector は指定業種の空売り比率を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *ShortSellingService) GetShortSellingBySector(ctx context.Context, sector33Code string) ([]ShortSelling, error) {
+	return collectAll(s.AllShortSelling(ctx, ShortSellingParams{
+		Sector33Code: sector33Code,
+	}))
 }
 
 // GetShortSellingByDate は指定日の全業種空売り比率を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *ShortSellingService) GetShortSellingByDate(ctx context.Context, date string) ([]ShortSelling, error) {
-	var allData []ShortSelling
-	paginationKey := ""
-
-	for {
-		params := ShortSellingParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetShortSelling(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingService) GetShortSellingByDate(ctx context.Context, date types.Date) ([]ShortSelling, error) {
+	return collectAll(s.AllShortSelling(ctx, ShortSellingParams{
+		Date: date,
+	}))
 }
 
 // GetShortSellingBySectorAndDateRange は指定業種・期間の空売り比率を取得します。
-func (s *ShortSellingService) GetShortSellingBySectorAndDateRange(ctx context.Context, sector33Code, from, to string) ([]ShortSelling, error) {
-	var allData []ShortSelling
-	paginationKey := ""
-
-	for {
-		params := ShortSellingParams{
-			Sector33Code:  sector33Code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetShortSelling(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingService) GetShortSellingBySectorAndDateRange(ctx context.Context, sector33Code string, from, to types.Date) ([]ShortSelling, error) {
+	return collectAll(s.AllShortSelling(ctx, ShortSellingParams{
+		Sector33Code: sector33Code,
+		From:         from,
+		To:           to,
+	}))
 }
 
 // GetShortSellingBySectorAndDate は指定業種の指定日の空売り比率を取得します。
-func (s *ShortSellingService) GetShortSellingBySectorAndDate(ctx context.Context, sector33Code, date string) ([]ShortSelling, error) {
+func (s *ShortSellingService) GetShortSellingBySectorAndDate(ctx context.Context, sector33Code string, date types.Date) ([]ShortSelling, error) {
 	resp, err := s.GetShortSelling(ctx, ShortSellingParams{
 		Sector33Code: sector33Code,
 		Date:         date,
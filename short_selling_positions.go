@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -21,12 +22,12 @@ func NewShortSellingPositionsService(c client.HTTPClient) *ShortSellingPositions
 
 // ShortSellingPositionsParams は空売り残高報告のリクエストパラメータです。
 type ShortSellingPositionsParams struct {
-	Code              string // 4桁もしくは5桁の銘柄コード（code、disclosed_date、calculated_dateのいずれかが必須）
-	DisclosedDate     string // 公表日（YYYYMMDD または YYYY-MM-DD）（code、disclosed_date、calculated_dateのいずれかが必須）
-	DisclosedDateFrom string // 公表日のfrom指定（YYYYMMDD または YYYY-MM-DD）
-	DisclosedDateTo   string // 公表日のto指定（YYYYMMDD または YYYY-MM-DD）
-	CalculatedDate    string // 計算日（YYYYMMDD または YYYY-MM-DD）（code、disclosed_date、calculated_dateのいずれかが必須）
-	PaginationKey     string // ページネーションキー
+	Code              string     // 4桁もしくは5桁の銘柄コード（code、disclosed_date、calculated_dateのいずれかが必須）
+	DisclosedDate     types.Date // 公表日（code、disclosed_date、calculated_dateのいずれかが必須）
+	DisclosedDateFrom types.Date // 公表日のfrom指定
+	DisclosedDateTo   types.Date // 公表日のto指定
+	CalculatedDate    types.Date // 計算日（code、disclosed_date、calculated_dateのいずれかが必須）
+	PaginationKey     string     // ページネーションキー
 }
 
 // ShortSellingPositionsResponse は空売り残高報告のレスポンスです。
@@ -40,9 +41,9 @@ type ShortSellingPositionsResponse struct {
 // 有価証券の取引等の規制に関する内閣府令に基づく大口空売り残高（0.5％以上）の報告データ。
 type ShortSellingPosition struct {
 	// 基本情報
-	DiscDate string `json:"DiscDate"` // 公表日（YYYY-MM-DD形式）
-	CalcDate string `json:"CalcDate"` // 計算日（YYYY-MM-DD形式）
-	Code     string `json:"Code"`     // 銘柄コード（5桁）
+	DiscDate types.Date `json:"DiscDate"` // 公表日
+	CalcDate string     `json:"CalcDate"` // 計算日（YYYY-MM-DD形式）
+	Code     string     `json:"Code"`     // 銘柄コード（5桁）
 
 	// 空売り者情報
 	SSName string `json:"SSName"` // 商号・名称・氏名（日本語名称または英語名称が混在）
@@ -69,9 +70,9 @@ type ShortSellingPosition struct {
 // RawShortSellingPosition is used for unmarshaling JSON response with mixed types
 type RawShortSellingPosition struct {
 	// 基本情報
-	DiscDate string `json:"DiscDate"`
-	CalcDate string `json:"CalcDate"`
-	Code     string `json:"Code"`
+	DiscDate types.Date `json:"DiscDate"`
+	CalcDate string     `json:"CalcDate"`
+	Code     string     `json:"Code"`
 
 	// 空売り者情報
 	SSName string `json:"SSName"`
@@ -149,35 +150,24 @@ func (r *ShortSellingPositionsResponse) UnmarshalJSON(data []byte) error {
 // GetShortSellingPositions は空売り残高報告を取得します。
 func (s *ShortSellingPositionsService) GetShortSellingPositions(ctx context.Context, params ShortSellingPositionsParams) (*ShortSellingPositionsResponse, error) {
 	// code、disc_date、calc_dateのいずれかが必須
-	if params.Code == "" && params.DisclosedDate == "" && params.CalculatedDate == "" {
+	if params.Code == "" && params.DisclosedDate.IsZero() && params.CalculatedDate.IsZero() {
 		return nil, fmt.Errorf("either code, disc_date, or calc_date parameter is required")
 	}
 
 	path := "/markets/short-sale-report"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.DisclosedDate != "" {
-		query += fmt.Sprintf("disc_date=%s&", params.DisclosedDate)
-	}
-	if params.DisclosedDateFrom != "" {
-		query += fmt.Sprintf("disc_date_from=%s&", params.DisclosedDateFrom)
-	}
-	if params.DisclosedDateTo != "" {
-		query += fmt.Sprintf("disc_date_to=%s&", params.DisclosedDateTo)
-	}
-	if params.CalculatedDate != "" {
-		query += fmt.Sprintf("calc_date=%s&", params.CalculatedDate)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("disc_date", params.DisclosedDate)
+	query.setDate("disc_date_from", params.DisclosedDateFrom)
+	query.setDate("disc_date_to", params.DisclosedDateTo)
+	query.setDate("calc_date", params.CalculatedDate)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp ShortSellingPositionsResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -187,177 +177,65 @@ func (s *ShortSellingPositionsService) GetShortSellingPositions(ctx context.Cont
 	return &resp, nil
 }
 
-// GetShortSellingPositionsByCode は指定銘柄の空売り残高報告を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByCode(ctx context.Context, code string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllShortSellingPositions は指定条件の空売り残高報告を1件ずつ返すイテレータです。
+func (s *ShortSellingPositionsService) AllShortSellingPositions(ctx context.Context, params ShortSellingPositionsParams) iter.Seq2[ShortSellingPosition, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]ShortSellingPosition, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetShortSellingPositions(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+// GetShortSellingPositionsByCode は指定銘柄の空売り残高報告を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByCode(ctx context.Context, code string) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		Code: code,
+	}))
 }
 
 // GetShortSellingPositionsByDisclosedDate は指定公表日の全銘柄空売り残高報告を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByDisclosedDate(ctx context.Context, disclosedDate string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			DisclosedDate: disclosedDate,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetShortSellingPositions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByDisclosedDate(ctx context.Context, disclosedDate types.Date) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		DisclosedDate: disclosedDate,
+	}))
 }
 
 // GetShortSellingPositionsByCalculatedDate は指定計算日の全銘柄空売り残高報告を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByCalculatedDate(ctx context.Context, calculatedDate string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			CalculatedDate: calculatedDate,
-			PaginationKey:  paginationKey,
-		}
-
-		resp, err := s.GetShortSellingPositions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByCalculatedDate(ctx context.Context, calculatedDate types.Date) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		CalculatedDate: calculatedDate,
+	}))
 }
 
 // GetShortSellingPositionsByCodeAndDateRange は指定銘柄・期間の空売り残高報告を取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndDateRange(ctx context.Context, code, fromDate, toDate string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			Code:              code,
-			DisclosedDateFrom: fromDate,
-			DisclosedDateTo:   toDate,
-			PaginationKey:     paginationKey,
-		}
-
-		resp, err := s.GetShortSellingPositions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndDateRange(ctx context.Context, code string, fromDate, toDate types.Date) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		Code:              code,
+		DisclosedDateFrom: fromDate,
+		DisclosedDateTo:   toDate,
+	}))
 }
 
 // GetShortSellingPositionsByCodeAndDisclosedDate は指定銘柄の指定公表日の空売り残高報告を取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndDisclosedDate(ctx context.Context, code, disclosedDate string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			Code:          code,
-			DisclosedDate: disclosedDate,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetShortSellingPositions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndDisclosedDate(ctx context.Context, code string, disclosedDate types.Date) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		Code:          code,
+		DisclosedDate: disclosedDate,
+	}))
 }
 
 // GetShortSellingPositionsByCodeAndCalculatedDate は指定銘柄の指定計算日の空売り残高報告を取得します。
-func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndCalculatedDate(ctx context.Context, code, calculatedDate string) ([]ShortSellingPosition, error) {
-	var allData []ShortSellingPosition
-	paginationKey := ""
-
-	for {
-		params := ShortSellingPositionsParams{
-			Code:           code,
-			CalculatedDate: calculatedDate,
-			PaginationKey:  paginationKey,
-		}
-
-		resp, err := s.GetShortSellingPositions(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *ShortSellingPositionsService) GetShortSellingPositionsByCodeAndCalculatedDate(ctx context.Context, code string, calculatedDate types.Date) ([]ShortSellingPosition, error) {
+	return collectAll(s.AllShortSellingPositions(ctx, ShortSellingPositionsParams{
+		Code:           code,
+		CalculatedDate: calculatedDate,
+	}))
 }
 
 // GetPositionChange は前回報告からの残高変化を計算します（株数）。
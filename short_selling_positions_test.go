@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestShortSellingPositionsService_GetShortSellingPositions(t *testing.T) {
@@ -19,7 +20,7 @@ func TestShortSellingPositionsService_GetShortSellingPositions(t *testing.T) {
 			name: "with code and disclosed date",
 			params: ShortSellingPositionsParams{
 				Code:          "13660",
-				DisclosedDate: "20240801",
+				DisclosedDate: types.MustParseDate("20240801"),
 			},
 			wantPath: "/markets/short-sale-report?code=13660&disc_date=20240801",
 		},
@@ -33,14 +34,14 @@ func TestShortSellingPositionsService_GetShortSellingPositions(t *testing.T) {
 		{
 			name: "with disclosed date only",
 			params: ShortSellingPositionsParams{
-				DisclosedDate: "2024-08-01",
+				DisclosedDate: types.MustParseDate("2024-08-01"),
 			},
-			wantPath: "/markets/short-sale-report?disc_date=2024-08-01",
+			wantPath: "/markets/short-sale-report?disc_date=20240801",
 		},
 		{
 			name: "with calculated date only",
 			params: ShortSellingPositionsParams{
-				CalculatedDate: "20240731",
+				CalculatedDate: types.MustParseDate("20240731"),
 			},
 			wantPath: "/markets/short-sale-report?calc_date=20240731",
 		},
@@ -48,15 +49,15 @@ func TestShortSellingPositionsService_GetShortSellingPositions(t *testing.T) {
 			name: "with code and date range",
 			params: ShortSellingPositionsParams{
 				Code:              "86970",
-				DisclosedDateFrom: "20240101",
-				DisclosedDateTo:   "20241231",
+				DisclosedDateFrom: types.MustParseDate("20240101"),
+				DisclosedDateTo:   types.MustParseDate("20241231"),
 			},
 			wantPath: "/markets/short-sale-report?code=86970&disc_date_from=20240101&disc_date_to=20241231",
 		},
 		{
 			name: "with pagination key",
 			params: ShortSellingPositionsParams{
-				DisclosedDate: "20240801",
+				DisclosedDate: types.MustParseDate("20240801"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/markets/short-sale-report?disc_date=20240801&pagination_key=key123",
@@ -79,7 +80,7 @@ func TestShortSellingPositionsService_GetShortSellingPositions(t *testing.T) {
 			mockResponse := ShortSellingPositionsResponse{
 				Data: []ShortSellingPosition{
 					{
-						DiscDate:      "2024-08-01",
+						DiscDate:      types.MustParseDate("2024-08-01"),
 						CalcDate:      "2024-07-31",
 						Code:          "13660",
 						SSName:        "個人",
@@ -155,7 +156,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCode(t *testing.
 	mockResponse1 := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:      "2024-08-01",
+				DiscDate:      types.MustParseDate("2024-08-01"),
 				CalcDate:      "2024-07-31",
 				Code:          "86970",
 				SSName:        "ABC Investment Management",
@@ -164,7 +165,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCode(t *testing.
 				ShrtPosShares: 520000,
 			},
 			{
-				DiscDate:      "2024-07-25",
+				DiscDate:      types.MustParseDate("2024-07-25"),
 				CalcDate:      "2024-07-24",
 				Code:          "86970",
 				SSName:        "ABC Investment Management",
@@ -179,7 +180,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCode(t *testing.
 	mockResponse2 := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:      "2024-07-18",
+				DiscDate:      types.MustParseDate("2024-07-18"),
 				CalcDate:      "2024-07-17",
 				Code:          "86970",
 				SSName:        "ABC Investment Management",
@@ -219,14 +220,14 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByDisclosedDate(t
 	mockResponse := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:    "2024-08-01",
+				DiscDate:    types.MustParseDate("2024-08-01"),
 				CalcDate:    "2024-07-31",
 				Code:        "13660",
 				SSName:      "個人",
 				ShrtPosToSO: 0.0053,
 			},
 			{
-				DiscDate:    "2024-08-01",
+				DiscDate:    types.MustParseDate("2024-08-01"),
 				CalcDate:    "2024-07-31",
 				Code:        "86970",
 				SSName:      "XYZ Capital",
@@ -238,7 +239,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByDisclosedDate(t
 	mockClient.SetResponse("GET", "/markets/short-sale-report?disc_date=20240801", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingPositionsByDisclosedDate(context.Background(), "20240801")
+	data, err := service.GetShortSellingPositionsByDisclosedDate(context.Background(), types.MustParseDate("20240801"))
 
 	// Verify
 	if err != nil {
@@ -248,7 +249,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByDisclosedDate(t
 		t.Errorf("GetShortSellingPositionsByDisclosedDate() returned %d items, want 2", len(data))
 	}
 	for _, item := range data {
-		if item.DiscDate != "2024-08-01" {
+		if item.DiscDate.String() != "2024-08-01" {
 			t.Errorf("GetShortSellingPositionsByDisclosedDate() returned date %v, want 2024-08-01", item.DiscDate)
 		}
 	}
@@ -263,7 +264,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCalculatedDate(t
 	mockResponse := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:    "2024-08-01",
+				DiscDate:    types.MustParseDate("2024-08-01"),
 				CalcDate:    "2024-07-31",
 				Code:        "13660",
 				SSName:      "個人",
@@ -275,7 +276,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCalculatedDate(t
 	mockClient.SetResponse("GET", "/markets/short-sale-report?calc_date=20240731", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingPositionsByCalculatedDate(context.Background(), "20240731")
+	data, err := service.GetShortSellingPositionsByCalculatedDate(context.Background(), types.MustParseDate("20240731"))
 
 	// Verify
 	if err != nil {
@@ -298,12 +299,12 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndDateRange
 	mockResponse := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:    "2024-03-01",
+				DiscDate:    types.MustParseDate("2024-03-01"),
 				Code:        "86970",
 				ShrtPosToSO: 0.0087,
 			},
 			{
-				DiscDate:    "2024-02-01",
+				DiscDate:    types.MustParseDate("2024-02-01"),
 				Code:        "86970",
 				ShrtPosToSO: 0.0075,
 			},
@@ -313,7 +314,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndDateRange
 	mockClient.SetResponse("GET", "/markets/short-sale-report?code=86970&disc_date_from=20240101&disc_date_to=20240331", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingPositionsByCodeAndDateRange(context.Background(), "86970", "20240101", "20240331")
+	data, err := service.GetShortSellingPositionsByCodeAndDateRange(context.Background(), "86970", types.MustParseDate("20240101"), types.MustParseDate("20240331"))
 
 	// Verify
 	if err != nil {
@@ -338,7 +339,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndDisclosed
 	mockResponse := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:      "2024-08-01",
+				DiscDate:      types.MustParseDate("2024-08-01"),
 				CalcDate:      "2024-07-31",
 				Code:          "86970",
 				SSName:        "ABC Investment Management",
@@ -351,7 +352,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndDisclosed
 	mockClient.SetResponse("GET", "/markets/short-sale-report?code=86970&disc_date=20240801", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingPositionsByCodeAndDisclosedDate(context.Background(), "86970", "20240801")
+	data, err := service.GetShortSellingPositionsByCodeAndDisclosedDate(context.Background(), "86970", types.MustParseDate("20240801"))
 
 	// Verify
 	if err != nil {
@@ -363,7 +364,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndDisclosed
 	if data[0].Code != "86970" {
 		t.Errorf("GetShortSellingPositionsByCodeAndDisclosedDate() returned code %v, want 86970", data[0].Code)
 	}
-	if data[0].DiscDate != "2024-08-01" {
+	if data[0].DiscDate.String() != "2024-08-01" {
 		t.Errorf("GetShortSellingPositionsByCodeAndDisclosedDate() returned disc_date %v, want 2024-08-01", data[0].DiscDate)
 	}
 }
@@ -377,7 +378,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndCalculate
 	mockResponse := ShortSellingPositionsResponse{
 		Data: []ShortSellingPosition{
 			{
-				DiscDate:      "2024-08-01",
+				DiscDate:      types.MustParseDate("2024-08-01"),
 				CalcDate:      "2024-07-31",
 				Code:          "86970",
 				SSName:        "ABC Investment Management",
@@ -390,7 +391,7 @@ func TestShortSellingPositionsService_GetShortSellingPositionsByCodeAndCalculate
 	mockClient.SetResponse("GET", "/markets/short-sale-report?code=86970&calc_date=20240731", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingPositionsByCodeAndCalculatedDate(context.Background(), "86970", "20240731")
+	data, err := service.GetShortSellingPositionsByCodeAndCalculatedDate(context.Background(), "86970", types.MustParseDate("20240731"))
 
 	// Verify
 	if err != nil {
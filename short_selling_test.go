@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestShortSellingService_GetShortSelling(t *testing.T) {
@@ -18,8 +19,8 @@ func TestShortSellingService_GetShortSelling(t *testing.T) {
 			name: "with sector and date range",
 			params: ShortSellingParams{
 				Sector33Code: "0050",
-				From:         "20220101",
-				To:           "20221231",
+				From:         types.MustParseDate("20220101"),
+				To:           types.MustParseDate("20221231"),
 			},
 			wantPath: "/markets/short-ratio?s33=0050&from=20220101&to=20221231",
 		},
@@ -33,14 +34,14 @@ func TestShortSellingService_GetShortSelling(t *testing.T) {
 		{
 			name: "with date only",
 			params: ShortSellingParams{
-				Date: "20221025",
+				Date: types.MustParseDate("20221025"),
 			},
 			wantPath: "/markets/short-ratio?date=20221025",
 		},
 		{
 			name: "with date and pagination key",
 			params: ShortSellingParams{
-				Date:          "20221025",
+				Date:          types.MustParseDate("20221025"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/markets/short-ratio?date=20221025&pagination_key=key123",
@@ -57,7 +58,7 @@ func TestShortSellingService_GetShortSelling(t *testing.T) {
 			mockResponse := ShortSellingResponse{
 				Data: []ShortSelling{
 					{
-						Date:          "2022-10-25",
+						Date:          types.MustParseDate("2022-10-25"),
 						S33:           "0050",
 						SellExShortVa: 1333126400.0,
 						ShrtWithResVa: 787355200.0,
@@ -115,14 +116,14 @@ func TestShortSellingService_GetShortSellingBySector(t *testing.T) {
 	mockResponse1 := ShortSellingResponse{
 		Data: []ShortSelling{
 			{
-				Date:          "2022-10-25",
+				Date:          types.MustParseDate("2022-10-25"),
 				S33:           "0050",
 				SellExShortVa: 1333126400.0,
 				ShrtWithResVa: 787355200.0,
 				ShrtNoResVa:   149084300.0,
 			},
 			{
-				Date:          "2022-10-24",
+				Date:          types.MustParseDate("2022-10-24"),
 				S33:           "0050",
 				SellExShortVa: 1200000000.0,
 				ShrtWithResVa: 750000000.0,
@@ -136,7 +137,7 @@ func TestShortSellingService_GetShortSellingBySector(t *testing.T) {
 	mockResponse2 := ShortSellingResponse{
 		Data: []ShortSelling{
 			{
-				Date:          "2022-10-21",
+				Date:          types.MustParseDate("2022-10-21"),
 				S33:           "0050",
 				SellExShortVa: 1100000000.0,
 				ShrtWithResVa: 700000000.0,
@@ -175,14 +176,14 @@ func TestShortSellingService_GetShortSellingByDate(t *testing.T) {
 	mockResponse := ShortSellingResponse{
 		Data: []ShortSelling{
 			{
-				Date:          "2022-10-25",
+				Date:          types.MustParseDate("2022-10-25"),
 				S33:           "0050",
 				SellExShortVa: 1333126400.0,
 				ShrtWithResVa: 787355200.0,
 				ShrtNoResVa:   149084300.0,
 			},
 			{
-				Date:          "2022-10-25",
+				Date:          types.MustParseDate("2022-10-25"),
 				S33:           "1050",
 				SellExShortVa: 500000000.0,
 				ShrtWithResVa: 300000000.0,
@@ -194,7 +195,7 @@ func TestShortSellingService_GetShortSellingByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/short-ratio?date=20221025", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingByDate(context.Background(), "20221025")
+	data, err := service.GetShortSellingByDate(context.Background(), types.MustParseDate("20221025"))
 
 	// Verify
 	if err != nil {
@@ -204,7 +205,7 @@ func TestShortSellingService_GetShortSellingByDate(t *testing.T) {
 		t.Errorf("GetShortSellingByDate() returned %d items, want 2", len(data))
 	}
 	for _, item := range data {
-		if item.Date != "2022-10-25" {
+		if item.Date.String() != "2022-10-25" {
 			t.Errorf("GetShortSellingByDate() returned date %v, want 2022-10-25", item.Date)
 		}
 	}
@@ -219,14 +220,14 @@ func TestShortSellingService_GetShortSellingBySectorAndDateRange(t *testing.T) {
 	mockResponse := ShortSellingResponse{
 		Data: []ShortSelling{
 			{
-				Date:          "2022-10-25",
+				Date:          types.MustParseDate("2022-10-25"),
 				S33:           "0050",
 				SellExShortVa: 1333126400.0,
 				ShrtWithResVa: 787355200.0,
 				ShrtNoResVa:   149084300.0,
 			},
 			{
-				Date:          "2022-10-24",
+				Date:          types.MustParseDate("2022-10-24"),
 				S33:           "0050",
 				SellExShortVa: 1200000000.0,
 				ShrtWithResVa: 750000000.0,
@@ -238,7 +239,7 @@ func TestShortSellingService_GetShortSellingBySectorAndDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/short-ratio?s33=0050&from=20220101&to=20221231", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingBySectorAndDateRange(context.Background(), "0050", "20220101", "20221231")
+	data, err := service.GetShortSellingBySectorAndDateRange(context.Background(), "0050", types.MustParseDate("20220101"), types.MustParseDate("20221231"))
 
 	// Verify
 	if err != nil {
@@ -263,7 +264,7 @@ func TestShortSellingService_GetShortSellingBySectorAndDate(t *testing.T) {
 	mockResponse := ShortSellingResponse{
 		Data: []ShortSelling{
 			{
-				Date:          "2022-10-25",
+				Date:          types.MustParseDate("2022-10-25"),
 				S33:           "0050",
 				SellExShortVa: 1333126400.0,
 				ShrtWithResVa: 787355200.0,
@@ -275,7 +276,7 @@ func TestShortSellingService_GetShortSellingBySectorAndDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/short-ratio?s33=0050&date=20221025", mockResponse)
 
 	// Execute
-	data, err := service.GetShortSellingBySectorAndDate(context.Background(), "0050", "20221025")
+	data, err := service.GetShortSellingBySectorAndDate(context.Background(), "0050", types.MustParseDate("20221025"))
 
 	// Verify
 	if err != nil {
@@ -287,7 +288,7 @@ func TestShortSellingService_GetShortSellingBySectorAndDate(t *testing.T) {
 	if data[0].S33 != "0050" {
 		t.Errorf("GetShortSellingBySectorAndDate() returned s33 %v, want 0050", data[0].S33)
 	}
-	if data[0].Date != "2022-10-25" {
+	if data[0].Date.String() != "2022-10-25" {
 		t.Errorf("GetShortSellingBySectorAndDate() returned date %v, want 2022-10-25", data[0].Date)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -27,7 +28,7 @@ func NewStatementsService(c client.HTTPClient) *StatementsService {
 // すべてのフィールドはAPIでは文字列型で返されますが、このstructでは適切な型に変換されています。
 type Statement struct {
 	// 基本情報
-	DiscDate   string         `json:"DiscDate"`   // 開示日
+	DiscDate   types.Date     `json:"DiscDate"`   // 開示日
 	DiscTime   string         `json:"DiscTime"`   // 開示時刻
 	Code       string         `json:"Code"`       // 銘柄コード（5桁）
 	DiscNo     string         `json:"DiscNo"`     // 開示番号
@@ -173,18 +174,18 @@ type Statement struct {
 // RawStatement is used for unmarshaling JSON response with mixed types
 type RawStatement struct {
 	// 基本情報
-	DiscDate   string `json:"DiscDate"`
-	DiscTime   string `json:"DiscTime"`
-	Code       string `json:"Code"`
-	DiscNo     string `json:"DiscNo"`
-	DocType    string `json:"DocType"`
-	CurPerType string `json:"CurPerType"`
-	CurPerSt   string `json:"CurPerSt"`
-	CurPerEn   string `json:"CurPerEn"`
-	CurFYSt    string `json:"CurFYSt"`
-	CurFYEn    string `json:"CurFYEn"`
-	NxtFYSt    string `json:"NxtFYSt"`
-	NxtFYEn    string `json:"NxtFYEn"`
+	DiscDate   types.Date `json:"DiscDate"`
+	DiscTime   string     `json:"DiscTime"`
+	Code       string     `json:"Code"`
+	DiscNo     string     `json:"DiscNo"`
+	DocType    string     `json:"DocType"`
+	CurPerType string     `json:"CurPerType"`
+	CurPerSt   string     `json:"CurPerSt"`
+	CurPerEn   string     `json:"CurPerEn"`
+	CurFYSt    string     `json:"CurFYSt"`
+	CurFYEn    string     `json:"CurFYEn"`
+	NxtFYSt    string     `json:"NxtFYSt"`
+	NxtFYEn    string     `json:"NxtFYEn"`
 
 	// 連結財務数値
 	Sales  types.NullableFloat64 `json:"Sales"`
@@ -492,10 +493,10 @@ func (s *StatementsResponse) UnmarshalJSON(data []byte) error {
 }
 
 type StatementsParams struct {
-	Code          string // 銘柄コード（4桁または5桁）
-	Date          string // 開示日付（YYYYMMDD または YYYY-MM-DD）
-	Cursor        string // 差分取得用カーソル。前回レスポンスのcursorを指定すると前回リクエスト以降のデータを取得（Premiumプランのみ。pagination_keyと同時指定不可）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（4桁または5桁）
+	Date          types.Date // 開示日付
+	Cursor        string     // 差分取得用カーソル。前回レスポンスのcursorを指定すると前回リクエスト以降のデータを取得（Premiumプランのみ。pagination_keyと同時指定不可）
+	PaginationKey string     // ページネーションキー
 }
 
 // GetStatements は指定された条件で財務諸表データを取得します。
@@ -512,23 +513,16 @@ func (s *StatementsService) GetStatements(ctx context.Context, params Statements
 
 	path := "/fins/summary"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.Cursor != "" {
-		query += fmt.Sprintf("cursor=%s&", params.Cursor)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.set("cursor", params.Cursor)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp StatementsResponse
 	var err error
@@ -545,34 +539,28 @@ func (s *StatementsService) GetStatements(ctx context.Context, params Statements
 	return &resp, nil
 }
 
-// GetAllStatementsByCode は指定銘柄の全期間の財務諸表データを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *StatementsService) GetAllStatementsByCode(ctx context.Context, code string) ([]Statement, error) {
-	var allStatements []Statement
-	paginationKey := ""
-
-	for {
-		resp, err := s.GetStatements(ctx, StatementsParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		})
+// AllStatements は指定条件の財務情報を1件ずつ返すイテレータです。
+func (s *StatementsService) AllStatements(ctx context.Context, params StatementsParams) iter.Seq2[Statement, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]Statement, string, error) {
+		params.PaginationKey = paginationKey
+		resp, err := s.GetStatements(ctx, params)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-		allStatements = append(allStatements, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allStatements, nil
+// GetAllStatementsByCode は指定銘柄の全期間の財務諸表データを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *StatementsService) GetAllStatementsByCode(ctx context.Context, code string) ([]Statement, error) {
+	return collectAll(s.AllStatements(ctx, StatementsParams{
+		Code: code,
+	}))
 }
 
 // GetStatementsByCodeAndDate は指定銘柄の指定日の財務諸表データを取得します。
-func (s *StatementsService) GetStatementsByCodeAndDate(ctx context.Context, code, date string) ([]Statement, error) {
+func (s *StatementsService) GetStatementsByCodeAndDate(ctx context.Context, code string, date types.Date) ([]Statement, error) {
 	resp, err := s.GetStatements(ctx, StatementsParams{
 		Code: code,
 		Date: date,
@@ -585,28 +573,10 @@ func (s *StatementsService) GetStatementsByCodeAndDate(ctx context.Context, code
 
 // GetStatementsByDate は指定日の全銘柄の財務諸表データを取得します。
 // ページネーションを使用して全データを取得します。
-func (s *StatementsService) GetStatementsByDate(ctx context.Context, date string) ([]Statement, error) {
-	var allStatements []Statement
-	paginationKey := ""
-
-	for {
-		resp, err := s.GetStatements(ctx, StatementsParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		allStatements = append(allStatements, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allStatements, nil
+func (s *StatementsService) GetStatementsByDate(ctx context.Context, date types.Date) ([]Statement, error) {
+	return collectAll(s.AllStatements(ctx, StatementsParams{
+		Date: date,
+	}))
 }
 
 // GetLatestStatements は指定銘柄の最新財務諸表を取得します。
@@ -624,7 +594,7 @@ func (s *StatementsService) GetLatestStatements(ctx context.Context, code string
 	// 最新のものを探す（DiscDateでソート）
 	latestStmt := statements[0]
 	for _, stmt := range statements {
-		if stmt.DiscDate > latestStmt.DiscDate {
+		if stmt.DiscDate.After(latestStmt.DiscDate) {
 			latestStmt = stmt
 		}
 	}
@@ -0,0 +1,264 @@
+package jquants
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/utahta/jquants/types"
+)
+
+// ForecastItem は会社予想の項目です。
+type ForecastItem string
+
+// 会社予想の項目の定義
+const (
+	ForecastSales  ForecastItem = "Sales"  // 売上高
+	ForecastOP     ForecastItem = "OP"     // 営業利益
+	ForecastOdP    ForecastItem = "OdP"    // 経常利益
+	ForecastNP     ForecastItem = "NP"     // 当期純利益
+	ForecastEPS    ForecastItem = "EPS"    // 一株あたり当期純利益
+	ForecastDivAnn ForecastItem = "DivAnn" // 一株あたり配当（年間合計）
+)
+
+// RevisionDirection は会社予想の修正の方向です。
+type RevisionDirection int
+
+const (
+	RevisionInitial   RevisionDirection = iota + 1 // 初回の予想
+	RevisionUp                                     // 上方修正
+	RevisionDown                                   // 下方修正
+	RevisionUnchanged                              // 据え置き
+)
+
+// String は修正の方向の名前を返します。
+func (d RevisionDirection) String() string {
+	switch d {
+	case RevisionInitial:
+		return "initial"
+	case RevisionUp:
+		return "up"
+	case RevisionDown:
+		return "down"
+	case RevisionUnchanged:
+		return "unchanged"
+	default:
+		return fmt.Sprintf("RevisionDirection(%d)", int(d))
+	}
+}
+
+// ForecastPoint は1件の開示に記載された会社予想の値です。
+type ForecastPoint struct {
+	DiscDate   types.Date        // 開示日
+	DiscTime   string            // 開示時刻
+	DiscNo     string            // 開示番号
+	DocType    TypeOfDocument    // 開示書類種別
+	Value      float64           // 予想値
+	Change     *float64          // 直前の予想からの変化量（初回はnil）
+	ChangeRate *float64          // 直前の予想からの変化率（0.1は10%増。初回・直前の予想が0の場合はnil）
+	Direction  RevisionDirection // 修正の方向
+}
+
+// ForecastTrack は1つの項目の会社予想の推移です。
+type ForecastTrack struct {
+	Item      ForecastItem    // 項目
+	Points    []ForecastPoint // 予想の推移（開示日時の古い順）
+	Initial   *float64        // 期初の予想（最初の予想）
+	Latest    *float64        // 最新の予想
+	Actual    *float64        // 通期の実績
+	Revisions int             // 修正の回数（据え置きは含まない）
+	Surprise  *float64        // 期初の予想に対する実績の乖離率（(実績-期初予想)/|期初予想|）
+}
+
+// ForecastHistory は1事業年度の会社予想の推移です。
+type ForecastHistory struct {
+	Code    string          // 銘柄コード（5桁）
+	CurFYEn types.Date      // 事業年度終了日
+	Tracks  []ForecastTrack // 項目ごとの推移（Sales, OP, OdP, NP, EPS, DivAnnの順）
+}
+
+// Track は指定した項目の推移を返します。該当する項目がない場合はnilを返します。
+func (h *ForecastHistory) Track(item ForecastItem) *ForecastTrack {
+	for i := range h.Tracks {
+		if h.Tracks[i].Item == item {
+			return &h.Tracks[i]
+		}
+	}
+	return nil
+}
+
+// forecastField は会社予想の項目に対応するStatementのフィールドです。
+// 連結の値がない開示（非連結のみの開示）では非連結（NC）のフィールドを使用します。
+type forecastField struct {
+	item            ForecastItem
+	consolidated    [3]func(*Statement) *float64 // 当期予想・翌期予想・実績
+	nonConsolidated [3]func(*Statement) *float64 // 当期予想・翌期予想・実績（非連結）
+}
+
+// forecastFieldsの各フィールドの添字
+const (
+	forecastCurrent = iota // 当期予想
+	forecastNext           // 翌期予想
+	forecastActual         // 実績
+)
+
+var forecastFields = []forecastField{
+	{ForecastSales,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FSales }, func(s *Statement) *float64 { return s.NxFSales }, func(s *Statement) *float64 { return s.Sales }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNCSales }, func(s *Statement) *float64 { return s.NxFNCSales }, func(s *Statement) *float64 { return s.NCSales }}},
+	{ForecastOP,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FOP }, func(s *Statement) *float64 { return s.NxFOP }, func(s *Statement) *float64 { return s.OP }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNCOP }, func(s *Statement) *float64 { return s.NxFNCOP }, func(s *Statement) *float64 { return s.NCOP }}},
+	{ForecastOdP,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FOdP }, func(s *Statement) *float64 { return s.NxFOdP }, func(s *Statement) *float64 { return s.OdP }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNCOdP }, func(s *Statement) *float64 { return s.NxFNCOdP }, func(s *Statement) *float64 { return s.NCOdP }}},
+	{ForecastNP,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNP }, func(s *Statement) *float64 { return s.NxFNp }, func(s *Statement) *float64 { return s.NP }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNCNP }, func(s *Statement) *float64 { return s.NxFNCNP }, func(s *Statement) *float64 { return s.NCNP }}},
+	{ForecastEPS,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FEPS }, func(s *Statement) *float64 { return s.NxFEPS }, func(s *Statement) *float64 { return s.EPS }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FNCEPS }, func(s *Statement) *float64 { return s.NxFNCEPS }, func(s *Statement) *float64 { return s.NCEPS }}},
+	{ForecastDivAnn,
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FDivAnn }, func(s *Statement) *float64 { return s.NxFDivAnn }, func(s *Statement) *float64 { return s.DivAnn }},
+		[3]func(*Statement) *float64{func(s *Statement) *float64 { return s.FDivAnn }, func(s *Statement) *float64 { return s.NxFDivAnn }, func(s *Statement) *float64 { return s.DivAnn }}},
+}
+
+// forecastValues はstから項目ごとの値（kindは当期予想・翌期予想・実績のいずれか）を取り出します。
+// 連結の業績（配当以外）がすべて空の場合は非連結の値を使用します。
+func forecastValues(st *Statement, kind int) []*float64 {
+	useNC := true
+	for _, f := range forecastFields {
+		if f.item != ForecastDivAnn && f.consolidated[kind](st) != nil {
+			useNC = false
+			break
+		}
+	}
+	values := make([]*float64, len(forecastFields))
+	for i, f := range forecastFields {
+		if useNC {
+			values[i] = f.nonConsolidated[kind](st)
+		} else {
+			values[i] = f.consolidated[kind](st)
+		}
+	}
+	return values
+}
+
+// ForecastHistory は指定銘柄・事業年度の会社予想の推移を返します。
+// fyEndは事業年度終了日です。変換の詳細はBuildForecastHistoryを参照してください。
+func (s *StatementsService) ForecastHistory(ctx context.Context, code string, fyEnd types.Date) (*ForecastHistory, error) {
+	if fyEnd.IsZero() {
+		return nil, fmt.Errorf("fyEnd parameter is required")
+	}
+	statements, err := s.GetAllStatementsByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return BuildForecastHistory(statements, fyEnd)
+}
+
+// BuildForecastHistory は財務情報から、事業年度終了日がfyEndの事業年度の会社予想の推移を作成します。
+//
+// 前事業年度の通期決算短信の翌期予想（NxF〜）を期初の予想とし、当事業年度の四半期決算短信・予想修正の開示の
+// 予想（F〜）を開示日時の古い順に並べます。当事業年度の通期決算短信の値を実績とします。
+// 同じ開示日時・開示番号の重複は1件にまとめます。値が空の開示はその項目の推移に含めません。
+func BuildForecastHistory(statements []Statement, fyEnd types.Date) (*ForecastHistory, error) {
+	if fyEnd.IsZero() {
+		return nil, fmt.Errorf("fyEnd parameter is required")
+	}
+
+	sorted := slices.Clone(statements)
+	slices.SortStableFunc(sorted, func(a, b Statement) int {
+		return cmp.Or(a.DiscDate.Compare(b.DiscDate), cmp.Compare(a.DiscTime, b.DiscTime), cmp.Compare(a.DiscNo, b.DiscNo))
+	})
+	sorted = slices.CompactFunc(sorted, func(a, b Statement) bool {
+		return a.DiscNo != "" && a.DiscNo == b.DiscNo && a.DocType == b.DocType
+	})
+
+	h := &ForecastHistory{CurFYEn: fyEnd}
+	tracks := make([]ForecastTrack, len(forecastFields))
+	for i, f := range forecastFields {
+		tracks[i].Item = f.item
+	}
+
+	for i := range sorted {
+		st := &sorted[i]
+		curFYEn, _ := types.ParseDate(st.CurFYEn)
+		nxtFYEn, _ := types.ParseDate(st.NxtFYEn)
+		annual := st.CurPerType == "FY" && !st.DocType.IsForecastRevision()
+
+		var values []*float64
+		switch {
+		case curFYEn == fyEnd && annual:
+			for j, v := range forecastValues(st, forecastActual) {
+				if v != nil {
+					tracks[j].Actual = v
+				}
+			}
+		case curFYEn == fyEnd:
+			values = forecastValues(st, forecastCurrent)
+		case nxtFYEn == fyEnd && annual:
+			values = forecastValues(st, forecastNext)
+		default:
+			continue
+		}
+		if h.Code == "" {
+			if code, err := types.ParseCode(st.Code); err == nil {
+				h.Code = code.String()
+			}
+		}
+
+		for j, v := range values {
+			if v != nil {
+				tracks[j].add(st, *v)
+			}
+		}
+	}
+
+	for i := range tracks {
+		t := &tracks[i]
+		if t.Initial != nil && t.Actual != nil && *t.Initial != 0 {
+			surprise := (*t.Actual - *t.Initial) / math.Abs(*t.Initial)
+			t.Surprise = &surprise
+		}
+	}
+	h.Tracks = tracks
+	return h, nil
+}
+
+// add は開示stの予想値vを推移に追加します。
+func (t *ForecastTrack) add(st *Statement, v float64) {
+	p := ForecastPoint{
+		DiscDate:  st.DiscDate,
+		DiscTime:  st.DiscTime,
+		DiscNo:    st.DiscNo,
+		DocType:   st.DocType,
+		Value:     v,
+		Direction: RevisionInitial,
+	}
+	if t.Latest != nil {
+		prev := *t.Latest
+		change := v - prev
+		p.Change = &change
+		if prev != 0 {
+			rate := change / math.Abs(prev)
+			p.ChangeRate = &rate
+		}
+		switch {
+		case change > 0:
+			p.Direction = RevisionUp
+			t.Revisions++
+		case change < 0:
+			p.Direction = RevisionDown
+			t.Revisions++
+		default:
+			p.Direction = RevisionUnchanged
+		}
+	} else {
+		t.Initial = &v
+	}
+	t.Points = append(t.Points, p)
+	t.Latest = &v
+}
@@ -0,0 +1,128 @@
+package jquants
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func forecastStatements() []Statement {
+	return []Statement{
+		// 前期の通期決算短信（翌期予想が期初予想）
+		{DiscDate: types.MustParseDate("2024-05-10"), DiscTime: "15:00", Code: "86970", DiscNo: "20240510000001", DocType: TypeOfDocumentFYConsolidatedJP,
+			CurPerType: "FY", CurFYEn: "2024-03-31", NxtFYEn: "2025-03-31",
+			Sales: floatPtr(900), NxFSales: floatPtr(1000), NxFOP: floatPtr(100), NxFDivAnn: floatPtr(50)},
+		// 1Q: 据え置き
+		{DiscDate: types.MustParseDate("2024-07-30"), DiscTime: "15:00", Code: "86970", DiscNo: "20240730000001", DocType: TypeOfDocument1QConsolidatedJP,
+			CurPerType: "1Q", CurFYEn: "2025-03-31", FSales: floatPtr(1000), FOP: floatPtr(100), FDivAnn: floatPtr(50)},
+		// 業績予想の修正（上方修正）。同じ開示の重複は1件にまとめる
+		{DiscDate: types.MustParseDate("2024-09-20"), DiscTime: "15:00", Code: "86970", DiscNo: "20240920000001", DocType: TypeOfDocumentEarningsRevision,
+			CurPerType: "FY", CurFYEn: "2025-03-31", FSales: floatPtr(1100), FOP: floatPtr(120)},
+		{DiscDate: types.MustParseDate("2024-09-20"), DiscTime: "15:00", Code: "86970", DiscNo: "20240920000001", DocType: TypeOfDocumentEarningsRevision,
+			CurPerType: "FY", CurFYEn: "2025-03-31", FSales: floatPtr(1100), FOP: floatPtr(120)},
+		// 2Q: 営業利益のみ下方修正、配当予想は増配
+		{DiscDate: types.MustParseDate("2024-10-30"), DiscTime: "15:00", Code: "86970", DiscNo: "20241030000001", DocType: TypeOfDocument2QConsolidatedJP,
+			CurPerType: "2Q", CurFYEn: "2025-03-31", FSales: floatPtr(1100), FOP: floatPtr(90), FDivAnn: floatPtr(60)},
+		// 当期の通期決算短信（実績）
+		{DiscDate: types.MustParseDate("2025-05-09"), DiscTime: "15:00", Code: "86970", DiscNo: "20250509000001", DocType: TypeOfDocumentFYConsolidatedJP,
+			CurPerType: "FY", CurFYEn: "2025-03-31", NxtFYEn: "2026-03-31",
+			Sales: floatPtr(1150), OP: floatPtr(95), DivAnn: floatPtr(60), NxFSales: floatPtr(1200)},
+	}
+}
+
+func TestBuildForecastHistory(t *testing.T) {
+	h, err := BuildForecastHistory(forecastStatements(), types.NewDate(2025, time.March, 31))
+	if err != nil {
+		t.Fatalf("BuildForecastHistory() error = %v", err)
+	}
+	if h.Code != "86970" || h.CurFYEn != types.NewDate(2025, time.March, 31) || len(h.Tracks) != 6 {
+		t.Fatalf("history = %+v", h)
+	}
+
+	sales := h.Track(ForecastSales)
+	if len(sales.Points) != 4 {
+		t.Fatalf("len(sales.Points) = %d, want 4", len(sales.Points))
+	}
+	wantDirs := []RevisionDirection{RevisionInitial, RevisionUnchanged, RevisionUp, RevisionUnchanged}
+	for i, p := range sales.Points {
+		if p.Direction != wantDirs[i] {
+			t.Errorf("sales.Points[%d].Direction = %v, want %v", i, p.Direction, wantDirs[i])
+		}
+	}
+	if sales.Points[0].Change != nil || sales.Points[0].DiscNo != "20240510000001" {
+		t.Errorf("sales.Points[0] = %+v", sales.Points[0])
+	}
+	approx(t, "sales.Points[2].Change", sales.Points[2].Change, 100)
+	approx(t, "sales.Points[2].ChangeRate", sales.Points[2].ChangeRate, 0.1)
+	if sales.Points[2].DocType != TypeOfDocumentEarningsRevision || sales.Revisions != 1 {
+		t.Errorf("sales.Points[2] = %+v, Revisions = %d", sales.Points[2], sales.Revisions)
+	}
+	approx(t, "sales.Initial", sales.Initial, 1000)
+	approx(t, "sales.Latest", sales.Latest, 1100)
+	approx(t, "sales.Actual", sales.Actual, 1150)
+	approx(t, "sales.Surprise", sales.Surprise, 0.15)
+
+	op := h.Track(ForecastOP)
+	if op.Revisions != 2 || op.Points[3].Direction != RevisionDown {
+		t.Errorf("op = %+v", op)
+	}
+	approx(t, "op.Latest", op.Latest, 90)
+	approx(t, "op.Surprise", op.Surprise, -0.05)
+
+	div := h.Track(ForecastDivAnn)
+	if len(div.Points) != 3 || div.Points[2].Direction != RevisionUp {
+		t.Errorf("div = %+v", div)
+	}
+
+	np := h.Track(ForecastNP)
+	if len(np.Points) != 0 || np.Initial != nil || np.Surprise != nil {
+		t.Errorf("np = %+v", np)
+	}
+	if h.Track("Unknown") != nil {
+		t.Error("Track(Unknown) should be nil")
+	}
+}
+
+func TestBuildForecastHistory_NonConsolidated(t *testing.T) {
+	statements := []Statement{
+		{DiscDate: types.MustParseDate("2024-05-10"), Code: "13010", DiscNo: "1", DocType: TypeOfDocumentFYNonConsolidatedJP, CurPerType: "FY",
+			CurFYEn: "2024-03-31", NxtFYEn: "2025-03-31", NxFNCSales: floatPtr(500)},
+		{DiscDate: types.MustParseDate("2025-05-10"), Code: "13010", DiscNo: "2", DocType: TypeOfDocumentFYNonConsolidatedJP, CurPerType: "FY",
+			CurFYEn: "2025-03-31", NCSales: floatPtr(450)},
+	}
+	h, err := BuildForecastHistory(statements, types.NewDate(2025, time.March, 31))
+	if err != nil {
+		t.Fatalf("BuildForecastHistory() error = %v", err)
+	}
+	sales := h.Track(ForecastSales)
+	approx(t, "sales.Initial", sales.Initial, 500)
+	approx(t, "sales.Actual", sales.Actual, 450)
+	approx(t, "sales.Surprise", sales.Surprise, -0.1)
+}
+
+func TestStatementsService_ForecastHistory(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/fins/summary?code=86970", StatementsResponse{Data: forecastStatements()})
+	service := NewStatementsService(mockClient)
+
+	h, err := service.ForecastHistory(context.Background(), "8697", types.NewDate(2025, time.March, 31))
+	if err != nil {
+		t.Fatalf("ForecastHistory() error = %v", err)
+	}
+	if len(h.Track(ForecastSales).Points) != 4 {
+		t.Errorf("history = %+v", h)
+	}
+
+	if _, err := service.ForecastHistory(context.Background(), "8697", types.Date{}); err == nil {
+		t.Error("ForecastHistory() with zero fyEnd expected error")
+	}
+	if _, err := BuildForecastHistory(forecastStatements(), types.Date{}); err == nil {
+		t.Error("BuildForecastHistory() with zero fyEnd expected error")
+	}
+	if mockClient.RequestCount != 1 {
+		t.Errorf("RequestCount = %d, want 1", mockClient.RequestCount)
+	}
+}
@@ -0,0 +1,216 @@
+package jquants
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+
+	"github.com/utahta/jquants/types"
+)
+
+// StatementSeriesValues は時系列の各期間で算出する財務数値です。値を求められない場合はnilです。
+type StatementSeriesValues struct {
+	Sales *float64 // 売上高
+	OP    *float64 // 営業利益
+	OdP   *float64 // 経常利益
+	NP    *float64 // 当期純利益
+}
+
+// fields は各項目へのポインタを定義順に返します。
+func (v *StatementSeriesValues) fields() []**float64 {
+	return []**float64{&v.Sales, &v.OP, &v.OdP, &v.NP}
+}
+
+// combine は2つの値の各項目にfを適用した結果を返します。いずれかがnilの項目、fがfalseを返した項目はnilです。
+func (v StatementSeriesValues) combine(u StatementSeriesValues, f func(a, b float64) (float64, bool)) StatementSeriesValues {
+	var out StatementSeriesValues
+	af, bf, of := v.fields(), u.fields(), out.fields()
+	for i := range af {
+		if *af[i] == nil || *bf[i] == nil {
+			continue
+		}
+		if x, ok := f(**af[i], **bf[i]); ok {
+			*of[i] = &x
+		}
+	}
+	return out
+}
+
+func (v StatementSeriesValues) isZero() bool {
+	return v.Sales == nil && v.OP == nil && v.OdP == nil && v.NP == nil
+}
+
+// StatementPeriod は財務情報の時系列の1四半期分のレコードです。
+// 累計値（決算短信に記載された期首からの値）に加えて、単独四半期の値、直近4四半期（TTM）の合計、
+// 前年同期比・前四半期比を持ちます。
+type StatementPeriod struct {
+	Code       string     // 銘柄コード（5桁）
+	CurPerType string     // 当会計期間の種類 [1Q, 2Q, 3Q, 4Q, 5Q, FY]
+	Quarter    int        // 事業年度内の四半期の番号（1始まり。通常の決算期ではFYは4）
+	CurFYSt    string     // 当事業年度開始日
+	CurFYEn    string     // 当事業年度終了日
+	PerSt      string     // 単独四半期の開始日（前の四半期がない場合は空文字）
+	PerEn      string     // 単独四半期の終了日（当会計期間終了日）
+	DiscDate   types.Date // 採用した開示のうち最新の開示日
+	DiscNo     string     // 採用した開示のうち最新の開示番号
+
+	Cumulative StatementSeriesValues // 期首からの累計値
+	Quarterly  StatementSeriesValues // 単独四半期の値（累計値から前四半期の累計値を差し引いた値）
+	TTM        StatementSeriesValues // 直近4四半期の単独四半期の値の合計
+	YoY        StatementSeriesValues // 単独四半期の値の前年同期比（0.1は10%増）
+	QoQ        StatementSeriesValues // 単独四半期の値の前四半期比（0.1は10%増）
+}
+
+// BuildSeries は指定銘柄の財務情報を取得し、四半期ごとの時系列を古い順に返します。
+// 変換の詳細はBuildStatementSeriesを参照してください。
+func (s *StatementsService) BuildSeries(ctx context.Context, code string) ([]StatementPeriod, error) {
+	statements, err := s.GetAllStatementsByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return BuildStatementSeries(statements), nil
+}
+
+// BuildStatementSeries は財務情報から四半期ごとの時系列を作成し、当会計期間終了日の古い順に返します。
+//
+// 同じ期間（銘柄・当事業年度終了日・当会計期間の種類）の開示が複数ある場合は、開示日時・開示番号が新しいものを
+// 優先し、新しい開示で値が空の項目は古い開示の値で補います。予想修正の開示（DocType.IsForecastRevision）は
+// 実績値を含まないため除外します。連結の値（Sales・OP・OdP・NP）がすべて空の開示は非連結の値を使用します。
+//
+// 単独四半期の値は同じ事業年度の直前の四半期の累計値が揃っている場合のみ求めます。TTMは連続する4四半期の
+// 単独四半期の値が揃っている場合のみ、成長率は比較対象の値が0でない場合のみ求めます。比較対象が負の場合は
+// 絶対値で割るため、赤字縮小は正の成長率になります。
+func BuildStatementSeries(statements []Statement) []StatementPeriod {
+	sorted := slices.Clone(statements)
+	slices.SortStableFunc(sorted, func(a, b Statement) int {
+		return cmp.Or(a.DiscDate.Compare(b.DiscDate), cmp.Compare(a.DiscTime, b.DiscTime), cmp.Compare(a.DiscNo, b.DiscNo))
+	})
+
+	periods := map[seriesKey]*seriesPeriod{}
+	for _, st := range sorted {
+		if st.DocType.IsForecastRevision() || st.CurPerType == "" {
+			continue
+		}
+		fySt, err1 := types.ParseDate(st.CurFYSt)
+		fyEn, err2 := types.ParseDate(st.CurFYEn)
+		perEn, err3 := types.ParseDate(st.CurPerEn)
+		code, err4 := types.ParseCode(st.Code)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		quarter := (monthsBetween(fySt, perEn) + 2) / 3
+		if quarter < 1 {
+			continue
+		}
+
+		key := seriesKey{code: code.String(), fyEn: fyEn, quarter: quarter}
+		p, ok := periods[key]
+		if !ok {
+			p = &seriesPeriod{StatementPeriod: StatementPeriod{Code: key.code, Quarter: quarter, CurFYSt: st.CurFYSt, CurFYEn: st.CurFYEn}, key: key}
+			periods[key] = p
+		}
+		p.CurPerType = st.CurPerType
+		p.PerEn = st.CurPerEn
+		p.DiscDate = st.DiscDate
+		p.DiscNo = st.DiscNo
+		p.perEn = perEn
+
+		values := StatementSeriesValues{Sales: st.Sales, OP: st.OP, OdP: st.OdP, NP: st.NP}
+		if values.isZero() {
+			values = StatementSeriesValues{Sales: st.NCSales, OP: st.NCOP, OdP: st.NCOdP, NP: st.NCNP}
+		}
+		dst, src := p.Cumulative.fields(), values.fields()
+		for i := range src {
+			if *src[i] != nil {
+				*dst[i] = *src[i]
+			}
+		}
+	}
+
+	series := make([]*seriesPeriod, 0, len(periods))
+	for _, p := range periods {
+		series = append(series, p)
+	}
+	slices.SortFunc(series, func(a, b *seriesPeriod) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), a.perEn.Compare(b.perEn), a.key.fyEn.Compare(b.key.fyEn))
+	})
+
+	sub := func(a, b float64) (float64, bool) { return a - b, true }
+	add := func(a, b float64) (float64, bool) { return a + b, true }
+	growth := func(cur, prev float64) (float64, bool) {
+		if prev == 0 {
+			return 0, false
+		}
+		return (cur - prev) / math.Abs(prev), true
+	}
+
+	for _, p := range series {
+		if p.Quarter == 1 {
+			p.PerSt = p.CurFYSt
+			p.Quarterly = p.Cumulative
+		} else if prev, ok := periods[seriesKey{code: p.Code, fyEn: p.key.fyEn, quarter: p.Quarter - 1}]; ok {
+			p.PerSt = prev.perEn.AddDays(1).String()
+			p.Quarterly = p.Cumulative.combine(prev.Cumulative, sub)
+		}
+	}
+
+	out := make([]StatementPeriod, len(series))
+	for i, p := range series {
+		if j := previousPeriod(series, i, 3); j >= 0 {
+			p.QoQ = p.Quarterly.combine(series[j].Quarterly, growth)
+		}
+		if j := previousPeriod(series, i, 12); j >= 0 {
+			p.YoY = p.Quarterly.combine(series[j].Quarterly, growth)
+		}
+
+		ttm, j := p.Quarterly, i
+		for n := 1; n < 4; n++ {
+			if j = previousPeriod(series, j, 3); j < 0 {
+				ttm = StatementSeriesValues{}
+				break
+			}
+			ttm = ttm.combine(series[j].Quarterly, add)
+		}
+		p.TTM = ttm
+		out[i] = p.StatementPeriod
+	}
+
+	return out
+}
+
+// seriesKey は時系列の期間を識別するキーです。
+type seriesKey struct {
+	code    string
+	fyEn    types.Date
+	quarter int
+}
+
+// seriesPeriod は時系列の作成中に使用する、日付を変換済みのStatementPeriodです。
+type seriesPeriod struct {
+	StatementPeriod
+	key   seriesKey
+	perEn types.Date
+}
+
+// previousPeriod はseries[i]と同じ銘柄で、当会計期間終了日がmonthsヶ月前の期間のインデックスを返します。
+// 該当する期間がない場合は-1を返します。
+func previousPeriod(series []*seriesPeriod, i, months int) int {
+	for j := i - 1; j >= 0; j-- {
+		if series[j].Code != series[i].Code {
+			break
+		}
+		switch d := monthsBetween(series[j].perEn, series[i].perEn) - 1; {
+		case d == months:
+			return j
+		case d > months:
+			return -1
+		}
+	}
+	return -1
+}
+
+// monthsBetween はfromの月からtoの月までの月数を、両端を含めて返します（同じ月の場合は1）。
+func monthsBetween(from, to types.Date) int {
+	return (to.Year*12 + int(to.Month)) - (from.Year*12 + int(from.Month)) + 1
+}
@@ -0,0 +1,143 @@
+package jquants
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func seriesStatement(discDate, perType, perEn, fySt, fyEn string, sales, np float64) Statement {
+	return Statement{
+		DiscDate:   types.MustParseDate(discDate),
+		DiscTime:   "15:00:00",
+		Code:       "86970",
+		DiscNo:     discDate[:4] + discDate[5:7] + discDate[8:] + "000000",
+		DocType:    TypeOfDocumentFYConsolidatedJP,
+		CurPerType: perType,
+		CurPerSt:   fySt,
+		CurPerEn:   perEn,
+		CurFYSt:    fySt,
+		CurFYEn:    fyEn,
+		Sales:      floatPtr(sales),
+		NP:         floatPtr(np),
+	}
+}
+
+func seriesStatements() []Statement {
+	return []Statement{
+		seriesStatement("2023-07-28", "1Q", "2023-06-30", "2023-04-01", "2024-03-31", 100, 10),
+		seriesStatement("2023-10-27", "2Q", "2023-09-30", "2023-04-01", "2024-03-31", 210, 15),
+		seriesStatement("2024-01-31", "3Q", "2023-12-31", "2023-04-01", "2024-03-31", 330, 25),
+		seriesStatement("2024-04-26", "FY", "2024-03-31", "2023-04-01", "2024-03-31", 460, 20),
+		seriesStatement("2024-07-26", "1Q", "2024-06-30", "2024-04-01", "2025-03-31", 120, -5),
+		seriesStatement("2024-10-25", "2Q", "2024-09-30", "2024-04-01", "2025-03-31", 250, 0),
+	}
+}
+
+func approx(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("%s = nil, want %v", name, want)
+		return
+	}
+	if math.Abs(*got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, *got, want)
+	}
+}
+
+func TestBuildStatementSeries(t *testing.T) {
+	statements := seriesStatements()
+	// 予想修正は除外される
+	revision := seriesStatement("2024-02-10", "FY", "2024-03-31", "2023-04-01", "2024-03-31", 999, 999)
+	revision.DocType = TypeOfDocumentEarningsRevision
+	// 2Qの訂正: 売上高のみ修正、当期純利益は空のため元の開示の値を使用
+	restated := seriesStatement("2023-11-10", "2Q", "2023-09-30", "2023-04-01", "2024-03-31", 220, 0)
+	restated.NP = nil
+	statements = append([]Statement{revision, restated}, statements...)
+
+	series := BuildStatementSeries(statements)
+	if len(series) != 6 {
+		t.Fatalf("len(series) = %d, want 6", len(series))
+	}
+
+	q2 := series[1]
+	if q2.CurPerType != "2Q" || q2.Quarter != 2 || q2.PerSt != "2023-07-01" || q2.PerEn != "2023-09-30" || q2.DiscDate.String() != "2023-11-10" {
+		t.Errorf("series[1] = %+v", q2)
+	}
+	approx(t, "2Q Cumulative.Sales", q2.Cumulative.Sales, 220)
+	approx(t, "2Q Cumulative.NP", q2.Cumulative.NP, 15)
+	approx(t, "2Q Quarterly.Sales", q2.Quarterly.Sales, 120)
+	approx(t, "2Q QoQ.Sales", q2.QoQ.Sales, 0.2)
+	if q2.Cumulative.OP != nil {
+		t.Errorf("OP should be nil")
+	}
+
+	fy := series[3]
+	if fy.CurPerType != "FY" || fy.Quarter != 4 || fy.PerSt != "2024-01-01" {
+		t.Errorf("series[3] = %+v", fy)
+	}
+	approx(t, "FY Quarterly.Sales", fy.Quarterly.Sales, 130)
+	approx(t, "FY Quarterly.NP", fy.Quarterly.NP, -5)
+	approx(t, "FY TTM.Sales", fy.TTM.Sales, 460)
+	if fy.YoY.Sales != nil {
+		t.Errorf("FY YoY.Sales = %v, want nil", *fy.YoY.Sales)
+	}
+	for i := range 3 {
+		if series[i].TTM.Sales != nil {
+			t.Errorf("series[%d] TTM.Sales should be nil", i)
+		}
+	}
+
+	q1 := series[4]
+	approx(t, "next 1Q Quarterly.Sales", q1.Quarterly.Sales, 120)
+	approx(t, "next 1Q YoY.Sales", q1.YoY.Sales, 0.2)
+	approx(t, "next 1Q YoY.NP", q1.YoY.NP, -1.5)
+	approx(t, "next 1Q TTM.Sales", q1.TTM.Sales, 480)
+	approx(t, "next 1Q TTM.NP", q1.TTM.NP, 5)
+
+	q2n := series[5]
+	approx(t, "next 2Q Quarterly.NP", q2n.Quarterly.NP, 5)
+	approx(t, "next 2Q QoQ.NP", q2n.QoQ.NP, 2) // -5 → 5 は絶対値で割る
+	approx(t, "next 2Q YoY.Sales", q2n.YoY.Sales, 130.0/120-1)
+	approx(t, "next 2Q TTM.Sales", q2n.TTM.Sales, 120+110+130+130)
+}
+
+func TestBuildStatementSeries_Gap(t *testing.T) {
+	statements := seriesStatements()
+	// 3Qが欠けている場合、FYの単独四半期の値は求めない
+	statements = append(statements[:2], statements[3:]...)
+	series := BuildStatementSeries(statements)
+	if len(series) != 5 {
+		t.Fatalf("len(series) = %d, want 5", len(series))
+	}
+	fy := series[2]
+	if fy.CurPerType != "FY" || fy.Quarterly.Sales != nil || fy.PerSt != "" {
+		t.Errorf("FY = %+v", fy)
+	}
+	if series[3].TTM.Sales != nil || series[3].QoQ.Sales != nil {
+		t.Errorf("next 1Q TTM/QoQ should be nil")
+	}
+}
+
+func TestStatementsService_BuildSeries(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/fins/summary?code=86970", StatementsResponse{Data: seriesStatements()})
+	service := NewStatementsService(mockClient)
+
+	series, err := service.BuildSeries(context.Background(), "8697")
+	if err != nil {
+		t.Fatalf("BuildSeries() error = %v", err)
+	}
+	if len(series) != 6 || series[0].Code != "86970" {
+		t.Errorf("series = %+v", series)
+	}
+
+	mockClient.SetError("GET", "/fins/summary?code=99990", fmt.Errorf("api error"))
+	if _, err := service.BuildSeries(context.Background(), "9999"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestStatementsService_GetStatements(t *testing.T) {
@@ -17,27 +18,27 @@ func TestStatementsService_GetStatements(t *testing.T) {
 	}{
 		{
 			name:     "with code and date",
-			params:   StatementsParams{Code: "7203", Date: "20240101"},
-			wantPath: "/fins/summary?code=7203&date=20240101",
+			params:   StatementsParams{Code: "7203", Date: types.MustParseDate("20240101")},
+			wantPath: "/fins/summary?code=72030&date=20240101",
 		},
 		{
 			name:     "with code only",
 			params:   StatementsParams{Code: "7203"},
-			wantPath: "/fins/summary?code=7203",
+			wantPath: "/fins/summary?code=72030",
 		},
 		{
 			name:     "with date only",
-			params:   StatementsParams{Date: "20240101"},
+			params:   StatementsParams{Date: types.MustParseDate("20240101")},
 			wantPath: "/fins/summary?date=20240101",
 		},
 		{
 			name:     "with pagination key",
-			params:   StatementsParams{Date: "20240101", PaginationKey: "key123"},
+			params:   StatementsParams{Date: types.MustParseDate("20240101"), PaginationKey: "key123"},
 			wantPath: "/fins/summary?date=20240101&pagination_key=key123",
 		},
 		{
 			name:     "with date and cursor",
-			params:   StatementsParams{Date: "20240101", Cursor: "cur123"},
+			params:   StatementsParams{Date: types.MustParseDate("20240101"), Cursor: "cur123"},
 			wantPath: "/fins/summary?date=20240101&cursor=cur123",
 		},
 		{
@@ -58,7 +59,7 @@ func TestStatementsService_GetStatements(t *testing.T) {
 				Data: []Statement{
 					{
 						// 基本情報
-						DiscDate:   "2024-01-15",
+						DiscDate:   types.MustParseDate("2024-01-15"),
 						DiscTime:   "14:30:00",
 						Code:       "72030",
 						DiscNo:     "20240115123456",
@@ -129,7 +130,7 @@ func TestStatementsService_GetStatements_CursorConflict(t *testing.T) {
 	service := NewStatementsService(mockClient)
 
 	_, err := service.GetStatements(context.Background(), StatementsParams{
-		Date:          "20240101",
+		Date:          types.MustParseDate("20240101"),
 		Cursor:        "cur123",
 		PaginationKey: "key123",
 	})
@@ -147,7 +148,7 @@ func TestStatementsService_GetLatestStatements(t *testing.T) {
 	mockResponse := StatementsResponse{
 		Data: []Statement{
 			{
-				DiscDate:   "2024-01-15",
+				DiscDate:   types.MustParseDate("2024-01-15"),
 				DiscTime:   "14:30:00",
 				Code:       "72030",
 				DiscNo:     "20240115123456",
@@ -165,7 +166,7 @@ func TestStatementsService_GetLatestStatements(t *testing.T) {
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/fins/summary?code=7203", mockResponse)
+	mockClient.SetResponse("GET", "/fins/summary?code=72030", mockResponse)
 
 	// Test
 	statement, err := service.GetLatestStatements(context.Background(), "7203")
@@ -197,7 +198,7 @@ func TestStatementsService_GetLatestStatements_NotFound(t *testing.T) {
 	mockResponse := StatementsResponse{
 		Data: []Statement{},
 	}
-	mockClient.SetResponse("GET", "/fins/summary?code=9999", mockResponse)
+	mockClient.SetResponse("GET", "/fins/summary?code=99990", mockResponse)
 
 	// Test
 	_, err := service.GetLatestStatements(context.Background(), "9999")
@@ -212,7 +213,7 @@ func TestStatementsService_GetStatements_Error(t *testing.T) {
 	service := NewStatementsService(mockClient)
 
 	// Mock error
-	mockClient.SetError("GET", "/fins/summary?code=7203", fmt.Errorf("API error"))
+	mockClient.SetError("GET", "/fins/summary?code=72030", fmt.Errorf("API error"))
 
 	// Test
 	_, err := service.GetStatements(context.Background(), StatementsParams{Code: "7203"})
@@ -230,17 +231,17 @@ func TestStatementsService_GetStatementsByCodeAndDate(t *testing.T) {
 	mockResponse := StatementsResponse{
 		Data: []Statement{
 			{
-				DiscDate: "2024-01-15",
+				DiscDate: types.MustParseDate("2024-01-15"),
 				Code:     "72030",
 				Sales:    floatPtr(10000000000),
 				OP:       floatPtr(2000000000),
 			},
 		},
 	}
-	mockClient.SetResponse("GET", "/fins/summary?code=7203&date=2024-01-15", mockResponse)
+	mockClient.SetResponse("GET", "/fins/summary?code=72030&date=20240115", mockResponse)
 
 	// Test
-	statements, err := service.GetStatementsByCodeAndDate(context.Background(), "7203", "2024-01-15")
+	statements, err := service.GetStatementsByCodeAndDate(context.Background(), "7203", types.MustParseDate("2024-01-15"))
 	if err != nil {
 		t.Errorf("GetStatementsByCodeAndDate failed: %v", err)
 	}
@@ -250,12 +251,12 @@ func TestStatementsService_GetStatementsByCodeAndDate(t *testing.T) {
 		t.Errorf("Expected 1 statement, got %d", len(statements))
 	}
 
-	if statements[0].Code != "72030" || statements[0].DiscDate != "2024-01-15" {
+	if statements[0].Code != "72030" || statements[0].DiscDate.String() != "2024-01-15" {
 		t.Errorf("Statement data mismatch: code=%s, date=%s", statements[0].Code, statements[0].DiscDate)
 	}
 
-	if mockClient.LastPath != "/fins/summary?code=7203&date=2024-01-15" {
-		t.Errorf("Expected path /fins/summary?code=7203&date=2024-01-15, got %s", mockClient.LastPath)
+	if mockClient.LastPath != "/fins/summary?code=72030&date=20240115" {
+		t.Errorf("Expected path /fins/summary?code=72030&date=20240115, got %s", mockClient.LastPath)
 	}
 }
 
@@ -268,13 +269,13 @@ func TestStatementsService_GetStatementsByDate(t *testing.T) {
 	mockResponse1 := StatementsResponse{
 		Data: []Statement{
 			{
-				DiscDate: "2024-01-15",
+				DiscDate: types.MustParseDate("2024-01-15"),
 				Code:     "72030",
 				Sales:    floatPtr(10000000000),
 				OP:       floatPtr(2000000000),
 			},
 			{
-				DiscDate: "2024-01-15",
+				DiscDate: types.MustParseDate("2024-01-15"),
 				Code:     "86970",
 				Sales:    floatPtr(5000000000),
 				OP:       floatPtr(1000000000),
@@ -287,7 +288,7 @@ func TestStatementsService_GetStatementsByDate(t *testing.T) {
 	mockResponse2 := StatementsResponse{
 		Data: []Statement{
 			{
-				DiscDate: "2024-01-15",
+				DiscDate: types.MustParseDate("2024-01-15"),
 				Code:     "99830",
 				Sales:    floatPtr(3000000000),
 				OP:       floatPtr(500000000),
@@ -296,11 +297,11 @@ func TestStatementsService_GetStatementsByDate(t *testing.T) {
 		PaginationKey: "",
 	}
 
-	mockClient.SetResponse("GET", "/fins/summary?date=2024-01-15", mockResponse1)
-	mockClient.SetResponse("GET", "/fins/summary?date=2024-01-15&pagination_key=next_page_key", mockResponse2)
+	mockClient.SetResponse("GET", "/fins/summary?date=20240115", mockResponse1)
+	mockClient.SetResponse("GET", "/fins/summary?date=20240115&pagination_key=next_page_key", mockResponse2)
 
 	// Test
-	statements, err := service.GetStatementsByDate(context.Background(), "2024-01-15")
+	statements, err := service.GetStatementsByDate(context.Background(), types.MustParseDate("2024-01-15"))
 	if err != nil {
 		t.Errorf("GetStatementsByDate failed: %v", err)
 	}
@@ -421,7 +422,7 @@ func TestStatementsResponse_UnmarshalJSON(t *testing.T) {
 	s := resp.Data[0]
 
 	// Check basic info
-	if s.DiscDate != "2024-01-15" {
+	if s.DiscDate.String() != "2024-01-15" {
 		t.Errorf("Expected DiscDate 2024-01-15, got %s", s.DiscDate)
 	}
 	if s.Code != "72030" {
@@ -654,7 +655,7 @@ func TestStatementsService_GetStatements_CursorSkipsCache(t *testing.T) {
 	mockClient.SetResponse("GET", "/fins/summary?date=20240101", StatementsResponse{})
 
 	// cursorによるポーリングはキャッシュをバイパスすること
-	if _, err := service.GetStatements(context.Background(), StatementsParams{Date: "20240101", Cursor: "cur123"}); err != nil {
+	if _, err := service.GetStatements(context.Background(), StatementsParams{Date: types.MustParseDate("20240101"), Cursor: "cur123"}); err != nil {
 		t.Fatalf("GetStatements() error = %v", err)
 	}
 	if !mockClient.LastSkipCache {
@@ -662,7 +663,7 @@ func TestStatementsService_GetStatements_CursorSkipsCache(t *testing.T) {
 	}
 
 	// cursorなしは通常どおりキャッシュ対象
-	if _, err := service.GetStatements(context.Background(), StatementsParams{Date: "20240101"}); err != nil {
+	if _, err := service.GetStatements(context.Background(), StatementsParams{Date: types.MustParseDate("20240101")}); err != nil {
 		t.Fatalf("GetStatements() error = %v", err)
 	}
 	if mockClient.LastSkipCache {
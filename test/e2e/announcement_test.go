@@ -34,13 +34,8 @@ func TestAnnouncementEndpoint(t *testing.T) {
 			}
 
 			// 基本情報の検証
-			if ann.Date == "" {
+			if ann.Date.IsZero() {
 				t.Errorf("Announcement[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(ann.Date) != 10 || ann.Date[4] != '-' || ann.Date[7] != '-' {
-					t.Errorf("Announcement[%d]: Date format invalid = %v, want YYYY-MM-DD", i, ann.Date)
-				}
 			}
 
 			if ann.Code == "" {
@@ -105,7 +100,7 @@ func TestAnnouncementEndpoint(t *testing.T) {
 			if i < 5 {
 				t.Logf("Announcement[%d]: %s (%s) - %s %s %s",
 					i, ann.CoName, ann.Code, ann.FY, ann.FQ, ann.SectorNm)
-				if ann.Date != "" {
+				if !ann.Date.IsZero() {
 					t.Logf("  Scheduled Date: %s", ann.Date)
 				}
 				if ann.Section != "" {
@@ -6,7 +6,6 @@ package e2e
 import (
 	"context"
 	"testing"
-	"time"
 
 	"github.com/utahta/jquants"
 )
@@ -30,13 +29,8 @@ func TestBreakdownEndpoint(t *testing.T) {
 		// 各売買内訳データを詳細に検証
 		for i, breakdown := range breakdowns {
 			// 基本情報の検証
-			if breakdown.Date == "" {
+			if breakdown.Date.IsZero() {
 				t.Errorf("Breakdown[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(breakdown.Date) != 10 || breakdown.Date[4] != '-' || breakdown.Date[7] != '-' {
-					t.Errorf("Breakdown[%d]: Date format invalid = %v, want YYYY-MM-DD", i, breakdown.Date)
-				}
 			}
 			if breakdown.Code != "72030" && breakdown.Code != "7203" {
 				t.Errorf("Breakdown[%d]: Code = %v, want 72030 or 7203", i, breakdown.Code)
@@ -159,9 +153,7 @@ func TestBreakdownEndpoint(t *testing.T) {
 	t.Run("GetBreakdown_DateRange", func(t *testing.T) {
 		// 日付範囲でのデータ取得
 		to := getTestDate()
-		fromTime, _ := time.Parse("20060102", to)
-		fromTime = fromTime.AddDate(0, 0, -7)
-		from := fromTime.Format("20060102")
+		from := to.AddDays(-7)
 
 		params := jquants.BreakdownParams{
 			Code: "7203",
@@ -185,7 +177,7 @@ func TestBreakdownEndpoint(t *testing.T) {
 
 		// 日付範囲の確認
 		for _, breakdown := range resp.Data {
-			if breakdown.Date < from || breakdown.Date > to {
+			if breakdown.Date.Before(from) || breakdown.Date.After(to) {
 				t.Errorf("Breakdown date %s is outside requested range %s to %s",
 					breakdown.Date, from, to)
 			}
@@ -7,9 +7,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestDailyQuotesEndpoint は/equities/bars/dailyエンドポイントの完全なテスト
@@ -17,9 +17,7 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 	t.Run("GetDailyQuotes_ByCodeAndDateRange", func(t *testing.T) {
 		// 過去10日間のトヨタ自動車の株価を取得
 		to := getTestDate()
-		fromTime, _ := time.Parse("20060102", to)
-		fromTime = fromTime.AddDate(0, 0, -10)
-		from := fromTime.Format("20060102")
+		from := to.AddDays(-10)
 
 		params := jquants.DailyQuotesParams{
 			Code: "7203",
@@ -45,13 +43,8 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 			if quote.Code != "72030" && quote.Code != "7203" {
 				t.Errorf("Quote[%d]: Code = %v, want 72030 or 7203", i, quote.Code)
 			}
-			if quote.Date == "" {
+			if quote.Date.IsZero() {
 				t.Errorf("Quote[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(quote.Date) != 10 || quote.Date[4] != '-' || quote.Date[7] != '-' {
-					t.Errorf("Quote[%d]: Date format invalid = %v, want YYYY-MM-DD", i, quote.Date)
-				}
 			}
 
 			// 四本値の検証
@@ -170,7 +163,7 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 
 		// データの並び順を確認
 		if len(resp.Data) > 1 {
-			isAscending := resp.Data[0].Date < resp.Data[1].Date
+			isAscending := resp.Data[0].Date.Before(resp.Data[1].Date)
 			t.Logf("Data order: %s", ternary(isAscending, "ascending", "descending"))
 		}
 	})
@@ -204,7 +197,7 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 			}
 
 			// 日付の検証（APIはYYYY-MM-DD形式で返す）
-			expectedDate := getTestDateFormatted()
+			expectedDate := getTestDate()
 			if quote.Date != expectedDate {
 				t.Errorf("Quote[%d]: Date = %v, want %v", i, quote.Date, expectedDate)
 			}
@@ -291,9 +284,7 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 			for i := 1; i < len(quotes); i++ {
 				currDate := quotes[i].Date
 				// 簡易的なギャップチェック（実際には祝日も考慮が必要）
-				prevTime, _ := time.Parse("2006-01-02", prevDate)
-				currTime, _ := time.Parse("2006-01-02", currDate)
-				daysDiff := int(prevTime.Sub(currTime).Hours() / 24)
+				daysDiff := prevDate.DaysSince(currDate)
 				if daysDiff > 3 { // 週末を考慮して3日以上のギャップ
 					gaps++
 				}
@@ -318,7 +309,7 @@ func TestDailyQuotesEndpoint(t *testing.T) {
 		}
 
 		// 未来の日付
-		futureDate := time.Now().AddDate(0, 0, 7).Format("20060102")
+		futureDate := types.Today().AddDays(7)
 		params = jquants.DailyQuotesParams{
 			Code: "7203",
 			Date: futureDate,
@@ -7,7 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -15,12 +15,13 @@ import (
 
 	"github.com/utahta/jquants"
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 var (
 	jq          *jquants.JQuantsAPI
-	testDate    string   // テストで使用する直近営業日（YYYYMMDD形式）
-	tradingDays []string // 直近の営業日一覧（YYYYMMDD形式、新しい順）
+	testDate    types.Date   // テストで使用する直近営業日
+	tradingDays []types.Date // 直近の営業日一覧（新しい順）
 )
 
 func TestMain(m *testing.M) {
@@ -90,17 +91,16 @@ func resolveTestDate() {
 // 失敗した場合は、土日を除いた直近の平日にフォールバックする。
 func resolveTradingDaysWithLag(lagDays int) {
 	tradingDays = nil
-	to := time.Now().AddDate(0, 0, -lagDays)
-	from := to.AddDate(0, 0, -21)
+	to := types.Today().AddDays(-lagDays)
+	from := to.AddDays(-21)
 
-	days, err := jq.TradingCalendar.GetTradingDays(context.Background(),
-		from.Format("20060102"), to.Format("20060102"))
+	days, err := jq.TradingCalendar.GetTradingDays(context.Background(), from, to)
 	if err == nil && len(days) > 0 {
-		dates := make([]string, 0, len(days))
+		dates := make([]types.Date, 0, len(days))
 		for _, d := range days {
-			dates = append(dates, strings.ReplaceAll(d.Date, "-", ""))
+			dates = append(dates, d.Date)
 		}
-		sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+		slices.SortFunc(dates, func(a, b types.Date) int { return b.Compare(a) })
 		tradingDays = dates
 		testDate = tradingDays[0]
 		fmt.Printf("e2e test date: %s (from trading calendar)\n", testDate)
@@ -108,9 +108,9 @@ func resolveTradingDaysWithLag(lagDays int) {
 	}
 
 	// フォールバック: 土日を除いた直近の平日（祝日は考慮できない）
-	for d := to; len(tradingDays) < 10; d = d.AddDate(0, 0, -1) {
+	for d := to; len(tradingDays) < 10; d = d.AddDays(-1) {
 		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
-			tradingDays = append(tradingDays, d.Format("20060102"))
+			tradingDays = append(tradingDays, d)
 		}
 	}
 	testDate = tradingDays[0]
@@ -121,7 +121,7 @@ func resolveTradingDaysWithLag(lagDays int) {
 // 株価四本値APIは全プランで利用可能なため、プローブとして使用する。
 // 有効なクエリでデータがない場合、APIはエラーではなく空データを返すため、
 // 「エラーなし・空データ」だけが取得不可（データ遅延プラン）を意味する。
-func dataAvailable(date string) (bool, error) {
+func dataAvailable(date types.Date) (bool, error) {
 	resp, err := jq.Quotes.GetDailyQuotes(context.Background(),
 		jquants.DailyQuotesParams{Code: "72030", Date: date})
 	if err != nil {
@@ -132,8 +132,8 @@ func dataAvailable(date string) (bool, error) {
 
 // findPopulatedTestDate は直近の営業日から順に最大maxDays日分countFnを呼び、
 // データが存在した最初の営業日を返す。開示・公表がある日にのみデータが存在する
-// イベント駆動のデータセットのテストで使用する。見つからない場合は空文字を返す。
-func findPopulatedTestDate(t *testing.T, maxDays int, countFn func(date string) (int, error)) string {
+// イベント駆動のデータセットのテストで使用する。見つからない場合はゼロ値を返す。
+func findPopulatedTestDate(t *testing.T, maxDays int, countFn func(date types.Date) (int, error)) types.Date {
 	t.Helper()
 	for i, date := range tradingDays {
 		if i >= maxDays {
@@ -150,7 +150,7 @@ func findPopulatedTestDate(t *testing.T, maxDays int, countFn func(date string)
 			return date
 		}
 	}
-	return ""
+	return types.Date{}
 }
 
 // isSubscriptionLimited はサブスクリプションプランによる制限があるかをチェックする
@@ -164,28 +164,14 @@ func isSubscriptionLimited(err error) bool {
 		strings.Contains(errMsg, "not available on your subscription")
 }
 
-// getTestDate はテストで使用する直近営業日をYYYYMMDD形式で取得する
-func getTestDate() string {
+// getTestDate はテストで使用する直近営業日を取得する
+func getTestDate() types.Date {
 	return testDate
 }
 
-// getTestDateFormatted はテストで使用する直近営業日をYYYY-MM-DD形式で取得する
-func getTestDateFormatted() string {
-	return formatDate(testDate)
-}
-
-// formatDate はYYYYMMDD形式の日付をYYYY-MM-DD形式に変換する
-func formatDate(date string) string {
-	return date[:4] + "-" + date[4:6] + "-" + date[6:]
-}
-
-// getTestDateDaysAgo はテスト基準日のn日前をYYYYMMDD形式で取得する
-func getTestDateDaysAgo(n int) string {
-	d, err := time.Parse("20060102", testDate)
-	if err != nil {
-		panic(fmt.Sprintf("invalid testDate %q: %v", testDate, err))
-	}
-	return d.AddDate(0, 0, -n).Format("20060102")
+// getTestDateDaysAgo はテスト基準日のn日前を取得する
+func getTestDateDaysAgo(n int) types.Date {
+	return testDate.AddDays(-n)
 }
 
 // fmtPrice はnil許容の価格フィールドをログ表示用に整形する
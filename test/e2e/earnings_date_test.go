@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestEarningsDateEndpoint は/fins/earnings-dateエンドポイントのテスト
@@ -18,7 +19,7 @@ func TestEarningsDateEndpoint(t *testing.T) {
 	// 公表履歴のうち最後に公表されたレコード。後続のサブテストで使用する
 	var latest struct {
 		PubDate string
-		SchDate string
+		SchDate types.Date
 	}
 
 	t.Run("GetEarningsDates_ByCode", func(t *testing.T) {
@@ -40,10 +41,6 @@ func TestEarningsDateEndpoint(t *testing.T) {
 			if len(d.PubDate) != 10 || d.PubDate[4] != '-' || d.PubDate[7] != '-' {
 				t.Errorf("EarningsDate[%d]: PubDate = %v, want YYYY-MM-DD", i, d.PubDate)
 			}
-			// 未定の場合は空文字
-			if d.SchDate != "" && (len(d.SchDate) != 10 || d.SchDate[4] != '-' || d.SchDate[7] != '-') {
-				t.Errorf("EarningsDate[%d]: SchDate = %v, want YYYY-MM-DD or empty", i, d.SchDate)
-			}
 			if !validQuarters[d.FQName] {
 				t.Errorf("EarningsDate[%d]: FQName = %v, want 1Q/2Q/3Q/FY", i, d.FQName)
 			}
@@ -68,7 +65,11 @@ func TestEarningsDateEndpoint(t *testing.T) {
 			t.Skip("No published record available from GetEarningsDates_ByCode")
 		}
 
-		dates, err := jq.EarningsDate.GetEarningsDatesByDate(context.Background(), latest.PubDate)
+		pubDate, err := types.ParseDate(latest.PubDate)
+		if err != nil {
+			t.Fatalf("Invalid PubDate %q: %v", latest.PubDate, err)
+		}
+		dates, err := jq.EarningsDate.GetEarningsDatesByDate(context.Background(), pubDate)
 		if err != nil {
 			if isSubscriptionLimited(err) {
 				t.Skip("Skipping due to subscription limitation")
@@ -84,7 +85,7 @@ func TestEarningsDateEndpoint(t *testing.T) {
 	})
 
 	t.Run("GetEarningsDates_ByScheduledDate", func(t *testing.T) {
-		if latest.SchDate == "" {
+		if latest.SchDate.IsZero() {
 			t.Skip("Latest scheduled date for 7203 is undetermined")
 		}
 
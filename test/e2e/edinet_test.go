@@ -95,7 +95,7 @@ func TestEdinetCrossShareholdingsEndpoint(t *testing.T) {
 // Standardプラン以上で利用可能。
 func TestEdinetLargeVolumeShareholdersEndpoint(t *testing.T) {
 	t.Run("GetLargeVolumeShareholders_ByDate", func(t *testing.T) {
-		date := getTestDateFormatted()
+		date := getTestDate()
 		docs, err := jq.EdinetLargeVolumeShareholders.GetLargeVolumeShareholdersByDate(context.Background(), date)
 		if err != nil {
 			if isSubscriptionLimited(err) {
@@ -113,7 +113,7 @@ func TestEdinetLargeVolumeShareholdersEndpoint(t *testing.T) {
 			if !strings.HasPrefix(doc.DocId, "S") {
 				t.Errorf("Doc[%d]: DocId = %v, want S-prefixed", i, doc.DocId)
 			}
-			if doc.SubDate != date {
+			if doc.SubDate != date.String() {
 				t.Errorf("Doc[%d]: SubDate = %v, want %v", i, doc.SubDate, date)
 			}
 			// 合計欄はnullの書類がある。値がある場合のみ範囲を検証する
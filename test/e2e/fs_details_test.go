@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestFSDetailsEndpoint は/fins/fs_details（プレミアムプラン専用）エンドポイントの完全なテスト
@@ -38,13 +39,8 @@ func TestFSDetailsEndpoint(t *testing.T) {
 				t.Errorf("Detail[%d]: LocalCode length = %d, want 4 or 5", i, len(detail.Code))
 			}
 
-			if detail.DiscDate == "" {
+			if detail.DiscDate.IsZero() {
 				t.Errorf("Detail[%d]: DisclosedDate is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(detail.DiscDate) != 10 || detail.DiscDate[4] != '-' || detail.DiscDate[7] != '-' {
-					t.Errorf("Detail[%d]: DisclosedDate format invalid = %v, want YYYY-MM-DD", i, detail.DiscDate)
-				}
 			}
 
 			if detail.DiscTime == "" {
@@ -196,7 +192,7 @@ func TestFSDetailsEndpoint(t *testing.T) {
 	t.Run("GetFSDetails_ByDate", func(t *testing.T) {
 		// 開示は毎営業日あるとは限らないため、開示がある営業日を直近から探す
 		var details *jquants.FSDetailsResponse
-		date := findPopulatedTestDate(t, 5, func(d string) (int, error) {
+		date := findPopulatedTestDate(t, 5, func(d types.Date) (int, error) {
 			var err error
 			details, err = jq.FSDetails.GetFSDetails(context.Background(), jquants.FSDetailsParams{Date: d})
 			if details == nil {
@@ -204,7 +200,7 @@ func TestFSDetailsEndpoint(t *testing.T) {
 			}
 			return len(details.Data), err
 		})
-		if date == "" {
+		if date.IsZero() {
 			t.Skip("No FS details found on recent trading days")
 		}
 
@@ -212,11 +208,9 @@ func TestFSDetailsEndpoint(t *testing.T) {
 
 		// 日付の一致確認
 		for i, detail := range details.Data {
-			// 日付形式をYYYY-MM-DDに統一して比較
-			expectedDate := formatDate(date)
-			if detail.DiscDate != expectedDate {
+			if detail.DiscDate != date {
 				t.Errorf("Detail[%d]: DisclosedDate = %v, want %v",
-					i, detail.DiscDate, expectedDate)
+					i, detail.DiscDate, date)
 			}
 			if i >= 10 {
 				break // 最初の10件のみ確認
@@ -333,10 +327,8 @@ func TestFSDetailsEndpoint(t *testing.T) {
 
 	t.Run("GetFSDetails_DateRange", func(t *testing.T) {
 		// 過去1年間の財務詳細を取得
-		to := getTestDateFormatted()
-		fromTime, _ := time.Parse("2006-01-02", to)
-		fromTime = fromTime.AddDate(-1, 0, 0)
-		from := fromTime.Format("2006-01-02")
+		to := getTestDate()
+		from := types.DateOf(to.Time().AddDate(-1, 0, 0))
 
 		params := jquants.FSDetailsParams{
 			Code: "7203",
@@ -358,8 +350,8 @@ func TestFSDetailsEndpoint(t *testing.T) {
 
 		// 期間内の日付確認
 		for _, detail := range details.Data {
-			if detail.DiscDate != "" {
-				if detail.DiscDate < from || detail.DiscDate > to {
+			if !detail.DiscDate.IsZero() {
+				if detail.DiscDate.Before(from) || detail.DiscDate.After(to) {
 					t.Errorf("FS detail disclosed date %s is outside range %s to %s",
 						detail.DiscDate, from, to)
 				}
@@ -375,15 +367,5 @@ func TestFSDetailsEndpoint(t *testing.T) {
 		if err == nil && len(details) > 0 {
 			t.Error("Expected error or empty result for invalid code")
 		}
-
-		// 無効な日付
-		params := jquants.FSDetailsParams{
-			Date: "invalid-date",
-		}
-
-		resp, err := jq.FSDetails.GetFSDetails(context.Background(), params)
-		if err == nil && resp != nil && len(resp.Data) > 0 {
-			t.Error("Expected error or empty result for invalid date")
-		}
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestFuturesEndpoint は/derivatives/futures（プレミアムプラン専用）エンドポイントの完全なテスト
@@ -31,15 +32,11 @@ func TestFuturesEndpoint(t *testing.T) {
 		// 各先物データを詳細に検証
 		for i, future := range futures {
 			// 基本情報の検証
-			if future.Date == "" {
+			if future.Date.IsZero() {
 				t.Errorf("Future[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(future.Date) != 10 || future.Date[4] != '-' || future.Date[7] != '-' {
-					t.Errorf("Future[%d]: Date format invalid = %v, want YYYY-MM-DD", i, future.Date)
-				}
 				// 日付の一致確認
-				expectedDate := getTestDateFormatted()
+				expectedDate := getTestDate()
 				if future.Date != expectedDate {
 					t.Errorf("Future[%d]: Date = %v, want %v", i, future.Date, expectedDate)
 				}
@@ -482,20 +479,14 @@ func TestFuturesEndpoint(t *testing.T) {
 		// エラーケースのテスト
 
 		// 未来の日付
-		futures, err := jq.Futures.GetFuturesByDate(context.Background(), "2030-01-01")
+		futures, err := jq.Futures.GetFuturesByDate(context.Background(), types.MustParseDate("2030-01-01"))
 		if err == nil && len(futures) > 0 {
 			t.Error("Expected error or empty result for future date")
 		}
 
-		// 無効な日付形式
-		futures, err = jq.Futures.GetFuturesByDate(context.Background(), "invalid-date")
-		if err == nil && len(futures) > 0 {
-			t.Error("Expected error or empty result for invalid date format")
-		}
-
 		// 空の日付（必須パラメータ）
 		params := jquants.FuturesParams{
-			Date: "",
+			Date: types.Date{},
 		}
 
 		_, err = jq.Futures.GetFutures(context.Background(), params)
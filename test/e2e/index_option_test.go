@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestIndexOptionEndpoint は日経225オプションエンドポイントの完全なテスト
@@ -33,16 +34,12 @@ func TestIndexOptionEndpoint(t *testing.T) {
 		}
 
 		// 各オプションデータを詳細に検証
-		expectedDate := getTestDateFormatted() // YYYY-MM-DD形式
+		expectedDate := getTestDate() // YYYY-MM-DD形式
 		for i, option := range resp.Data {
 			// 基本情報の検証
-			if option.Date == "" {
+			if option.Date.IsZero() {
 				t.Errorf("Option[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(option.Date) != 10 || option.Date[4] != '-' || option.Date[7] != '-' {
-					t.Errorf("Option[%d]: Date format invalid = %v, want YYYY-MM-DD", i, option.Date)
-				}
 				if option.Date != expectedDate {
 					t.Errorf("Option[%d]: Date = %v, want %v", i, option.Date, expectedDate)
 				}
@@ -451,7 +448,7 @@ func TestIndexOptionEndpoint(t *testing.T) {
 
 		// 未来の日付
 		params := jquants.IndexOptionParams{
-			Date: "2030-01-01",
+			Date: types.MustParseDate("2030-01-01"),
 		}
 
 		resp, err := jq.IndexOption.GetIndexOptions(context.Background(), params)
@@ -459,19 +456,9 @@ func TestIndexOptionEndpoint(t *testing.T) {
 			t.Error("Expected error or empty result for future date")
 		}
 
-		// 無効な日付形式
-		params = jquants.IndexOptionParams{
-			Date: "invalid-date",
-		}
-
-		resp, err = jq.IndexOption.GetIndexOptions(context.Background(), params)
-		if err == nil && resp != nil && len(resp.Data) > 0 {
-			t.Error("Expected error or empty result for invalid date format")
-		}
-
 		// 空の日付（必須パラメータ）
 		params = jquants.IndexOptionParams{
-			Date: "",
+			Date: types.Date{},
 		}
 
 		_, err = jq.IndexOption.GetIndexOptions(context.Background(), params)
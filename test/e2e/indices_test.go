@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestIndicesEndpoint は/markets/indicesエンドポイントの完全なテスト
@@ -35,15 +36,11 @@ func TestIndicesEndpoint(t *testing.T) {
 		// 各指数データを詳細に検証
 		for i, index := range resp.Data {
 			// 基本情報の検証
-			if index.Date == "" {
+			if index.Date.IsZero() {
 				t.Errorf("Index[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(index.Date) != 10 || index.Date[4] != '-' || index.Date[7] != '-' {
-					t.Errorf("Index[%d]: Date format invalid = %v, want YYYY-MM-DD", i, index.Date)
-				}
 				// 日付の一致確認
-				expectedDate := getTestDateFormatted()
+				expectedDate := getTestDate()
 				if index.Date != expectedDate {
 					t.Errorf("Index[%d]: Date = %v, want %v", i, index.Date, expectedDate)
 				}
@@ -257,8 +254,8 @@ func TestIndicesEndpoint(t *testing.T) {
 		// 過去の特定期間のデータ取得（codeまたはdateの指定が必須のためcodeを指定）
 		params := jquants.IndicesParams{
 			Code: jquants.IndexTOPIX,
-			From: "2024-01-01",
-			To:   "2024-01-31",
+			From: types.MustParseDate("2024-01-01"),
+			To:   types.MustParseDate("2024-01-31"),
 		}
 
 		resp, err := jq.Indices.GetIndices(context.Background(), params)
@@ -274,7 +271,7 @@ func TestIndicesEndpoint(t *testing.T) {
 
 			// 日付範囲の検証
 			for _, index := range resp.Data {
-				if index.Date < params.From || index.Date > params.To {
+				if index.Date.Before(params.From) || index.Date.After(params.To) {
 					t.Errorf("Index date %s is outside requested range %s to %s", index.Date, params.From, params.To)
 				}
 			}
@@ -33,13 +33,8 @@ func TestListedEndpoint(t *testing.T) {
 			}
 
 			// 基本情報の検証
-			if company.Date == "" {
+			if company.Date.IsZero() {
 				t.Errorf("Company[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(company.Date) != 10 || company.Date[4] != '-' || company.Date[7] != '-' {
-					t.Errorf("Company[%d]: Date format invalid = %v, want YYYY-MM-DD", i, company.Date)
-				}
 			}
 			if company.Code == "" {
 				t.Errorf("Company[%d]: Code is empty", i)
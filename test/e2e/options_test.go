@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestOptionsEndpoint は/derivatives/options（プレミアムプラン専用）エンドポイントの完全なテスト
@@ -36,15 +37,11 @@ func TestOptionsEndpoint(t *testing.T) {
 		// 各オプションデータを詳細に検証
 		for i, option := range resp.Data {
 			// 基本情報の検証
-			if option.Date == "" {
+			if option.Date.IsZero() {
 				t.Errorf("Option[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(option.Date) != 10 || option.Date[4] != '-' || option.Date[7] != '-' {
-					t.Errorf("Option[%d]: Date format invalid = %v, want YYYY-MM-DD", i, option.Date)
-				}
 				// 日付の一致確認
-				expectedDate := getTestDateFormatted()
+				expectedDate := getTestDate()
 				if option.Date != expectedDate {
 					t.Errorf("Option[%d]: Date = %v, want %v", i, option.Date, expectedDate)
 				}
@@ -524,22 +521,12 @@ func TestOptionsEndpoint(t *testing.T) {
 
 		// 未来の日付
 		params := jquants.OptionsParams{
-			Date: "2030-01-01",
+			Date: types.MustParseDate("2030-01-01"),
 		}
 
 		resp, err := jq.Options.GetOptions(context.Background(), params)
 		if err == nil && resp != nil && len(resp.Data) > 0 {
 			t.Error("Expected error or empty result for future date")
 		}
-
-		// 無効な日付形式
-		params = jquants.OptionsParams{
-			Date: "invalid-date",
-		}
-
-		resp2, err := jq.Options.GetOptions(context.Background(), params)
-		if err == nil && resp2 != nil && len(resp2.Data) > 0 {
-			t.Error("Expected error or empty result for invalid date format")
-		}
 	})
 }
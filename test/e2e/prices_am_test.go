@@ -33,13 +33,8 @@ func TestPricesAMEndpoint(t *testing.T) {
 			if quote.Code != "72030" && quote.Code != "7203" {
 				t.Errorf("Quote[%d]: Code = %v, want 72030 or 7203", i, quote.Code)
 			}
-			if quote.Date == "" {
+			if quote.Date.IsZero() {
 				t.Errorf("Quote[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(quote.Date) != 10 || quote.Date[4] != '-' || quote.Date[7] != '-' {
-					t.Errorf("Quote[%d]: Date format invalid = %v, want YYYY-MM-DD", i, quote.Date)
-				}
 			}
 
 			// 四本値の論理的整合性チェック
@@ -144,13 +139,8 @@ func TestPricesAMEndpoint(t *testing.T) {
 					t.Errorf("Quote[%d]: Code length = %d, want 4 or 5", i, len(quote.Code))
 				}
 			}
-			if quote.Date == "" {
+			if quote.Date.IsZero() {
 				t.Errorf("Quote[%d]: Date is empty", i)
-			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(quote.Date) != 10 || quote.Date[4] != '-' || quote.Date[7] != '-' {
-					t.Errorf("Quote[%d]: Date format invalid = %v, want YYYY-MM-DD", i, quote.Date)
-				}
 			}
 			if i >= 10 {
 				break // 最初の10件のみ確認
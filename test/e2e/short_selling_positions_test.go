@@ -7,6 +7,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/utahta/jquants/types"
 )
 
 // TestShortSellingPositionsEndpoint は/markets/short_selling_positionsエンドポイントの完全なテスト
@@ -31,7 +33,7 @@ func TestShortSellingPositionsEndpoint(t *testing.T) {
 			if position.Code != "72030" && position.Code != "7203" {
 				t.Errorf("Position[%d]: Code = %v, want 72030 or 7203", i, position.Code)
 			}
-			if position.DiscDate == "" {
+			if position.DiscDate.IsZero() {
 				t.Errorf("Position[%d]: DisclosedDate is empty", i)
 			}
 			if position.CalcDate == "" {
@@ -42,7 +44,7 @@ func TestShortSellingPositionsEndpoint(t *testing.T) {
 			}
 
 			// 日付の妥当性チェック
-			if position.DiscDate < position.CalcDate {
+			if position.DiscDate.String() < position.CalcDate {
 				t.Errorf("Position[%d]: DisclosedDate (%s) < CalculatedDate (%s)",
 					i, position.DiscDate, position.CalcDate)
 			}
@@ -222,8 +224,8 @@ func TestShortSellingPositionsEndpoint(t *testing.T) {
 
 	t.Run("GetShortSellingPositions_DateRange", func(t *testing.T) {
 		// 過去1ヶ月の期間でトヨタ自動車の空売り残高を取得
-		to := time.Now().Format("2006-01-02")
-		from := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+		to := types.Today()
+		from := types.DateOf(time.Now().AddDate(0, -1, 0))
 
 		positions, err := jq.ShortSellingPositions.GetShortSellingPositionsByCodeAndDateRange(context.Background(), "7203", from, to)
 		if err != nil {
@@ -241,7 +243,7 @@ func TestShortSellingPositionsEndpoint(t *testing.T) {
 
 		// 期間内の日付確認
 		for _, position := range positions {
-			if position.DiscDate < from || position.DiscDate > to {
+			if position.DiscDate.Before(from) || position.DiscDate.After(to) {
 				t.Errorf("Position disclosed date %s is outside range %s to %s",
 					position.DiscDate, from, to)
 			}
@@ -327,12 +329,6 @@ func TestShortSellingPositionsEndpoint(t *testing.T) {
 		if err == nil && len(positions) > 0 {
 			t.Error("Expected error or empty result for invalid code")
 		}
-
-		// 無効な日付
-		positions, err = jq.ShortSellingPositions.GetShortSellingPositionsByDisclosedDate(context.Background(), "invalid-date")
-		if err == nil && len(positions) > 0 {
-			t.Error("Expected error or empty result for invalid date")
-		}
 	})
 }
 
@@ -345,13 +341,12 @@ func abs(x float64) float64 {
 }
 
 // getRecentFriday は最近の金曜日を取得する
-func getRecentFriday() string {
-	now := time.Now()
+func getRecentFriday() types.Date {
+	d := types.Today()
 	// 今日が金曜日でない場合は、前の金曜日を探す
-	for now.Weekday() != time.Friday {
-		now = now.AddDate(0, 0, -1)
+	for d.Weekday() != time.Friday {
+		d = d.AddDays(-1)
 	}
 	// さらに1週間前にする（当週のデータは利用できない可能性があるため）
-	now = now.AddDate(0, 0, -7)
-	return now.Format("2006-01-02")
+	return d.AddDays(-7)
 }
@@ -6,6 +6,9 @@ package e2e
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/utahta/jquants/types"
 )
 
 // TestShortSellingEndpoint は/markets/short_sellingエンドポイントの完全なテスト
@@ -29,15 +32,11 @@ func TestShortSellingEndpoint(t *testing.T) {
 		// 各業種別空売りデータを詳細に検証
 		for i, short := range shorts {
 			// 基本情報の検証
-			if short.Date == "" {
+			if short.Date.IsZero() {
 				t.Errorf("ShortSelling[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(short.Date) != 10 || short.Date[4] != '-' || short.Date[7] != '-' {
-					t.Errorf("ShortSelling[%d]: Date format invalid = %v, want YYYY-MM-DD", i, short.Date)
-				}
 				// 日付が指定した日付と一致するか確認
-				expectedDate := getTestDateFormatted()
+				expectedDate := getTestDate()
 				if short.Date != expectedDate {
 					t.Errorf("ShortSelling[%d]: Date = %v, want %v", i, short.Date, expectedDate)
 				}
@@ -117,12 +116,6 @@ func TestShortSellingEndpoint(t *testing.T) {
 			if short.S33 != sectorCode {
 				t.Errorf("ShortSelling[%d]: Sector33Code = %v, want %v", i, short.S33, sectorCode)
 			}
-			// 日付フォーマットの検証
-			if short.Date != "" {
-				if len(short.Date) != 10 || short.Date[4] != '-' || short.Date[7] != '-' {
-					t.Errorf("ShortSelling[%d]: Date format invalid = %v, want YYYY-MM-DD", i, short.Date)
-				}
-			}
 		}
 
 		t.Logf("Retrieved %d short selling records for sector %s", len(shorts), sectorCode)
@@ -288,7 +281,7 @@ func TestShortSellingEndpoint(t *testing.T) {
 		}
 
 		// 未来の日付
-		futureDate := "2030-01-01"
+		futureDate := types.NewDate(2030, time.January, 1)
 		shorts, err = jq.ShortSelling.GetShortSellingByDate(context.Background(), futureDate)
 		if err == nil && len(shorts) > 0 {
 			t.Error("Expected error or empty result for future date")
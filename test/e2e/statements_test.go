@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestStatementsEndpoint は/fins/statementsエンドポイントの完全なテスト
@@ -38,13 +39,8 @@ func TestStatementsEndpoint(t *testing.T) {
 				t.Errorf("LocalCode length = %d, want 5", len(latest.Code))
 			}
 		}
-		if latest.DiscDate == "" {
+		if latest.DiscDate.IsZero() {
 			t.Error("DisclosedDate is empty")
-		} else {
-			// 日付フォーマットの検証（YYYY-MM-DD形式）
-			if len(latest.DiscDate) != 10 || latest.DiscDate[4] != '-' || latest.DiscDate[7] != '-' {
-				t.Errorf("DisclosedDate format invalid = %v, want YYYY-MM-DD", latest.DiscDate)
-			}
 		}
 		if latest.DiscTime == "" {
 			t.Error("DisclosedTime is empty")
@@ -299,12 +295,12 @@ func TestStatementsEndpoint(t *testing.T) {
 	t.Run("GetStatementsByDate", func(t *testing.T) {
 		// 開示は毎営業日あるとは限らないため、開示がある営業日を直近から探す
 		var statements []jquants.Statement
-		date := findPopulatedTestDate(t, 5, func(d string) (int, error) {
+		date := findPopulatedTestDate(t, 5, func(d types.Date) (int, error) {
 			var err error
 			statements, err = jq.Statements.GetStatementsByDate(context.Background(), d)
 			return len(statements), err
 		})
-		if date == "" {
+		if date.IsZero() {
 			t.Skip("No statements found on recent trading days")
 		}
 
@@ -324,10 +320,8 @@ func TestStatementsEndpoint(t *testing.T) {
 					t.Errorf("Statement[%d]: Code length = %d, want 5", i, len(stmt.Code))
 				}
 			}
-			// DiscDateの検証（APIはYYYY-MM-DD形式で返す）
-			expectedDate := formatDate(date)
-			if stmt.DiscDate != expectedDate {
-				t.Errorf("Statement[%d]: DiscDate = %v, want %v", i, stmt.DiscDate, expectedDate)
+			if stmt.DiscDate != date {
+				t.Errorf("Statement[%d]: DiscDate = %v, want %v", i, stmt.DiscDate, date)
 			}
 			t.Logf("Statement[%d]: %s - %s", i, stmt.Code, stmt.DocType)
 		}
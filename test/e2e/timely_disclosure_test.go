@@ -14,7 +14,7 @@ import (
 // TDnet/適時開示情報アドオン契約が必要。
 func TestTimelyDisclosureEndpoint(t *testing.T) {
 	t.Run("GetDisclosures_ByDate", func(t *testing.T) {
-		date := getTestDateFormatted()
+		date := getTestDate()
 		disclosures, err := jq.TimelyDisclosure.GetDisclosuresByDate(context.Background(), date)
 		if err != nil {
 			if isSubscriptionLimited(err) {
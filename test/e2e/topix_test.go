@@ -6,9 +6,9 @@ package e2e
 import (
 	"context"
 	"testing"
-	"time"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestTOPIXEndpoint は/markets/topixエンドポイントの完全なテスト
@@ -37,15 +37,11 @@ func TestTOPIXEndpoint(t *testing.T) {
 		// 各TOPIXデータを詳細に検証
 		for i, topix := range resp.Data {
 			// 基本情報の検証
-			if topix.Date == "" {
+			if topix.Date.IsZero() {
 				t.Errorf("TOPIX[%d]: Date is empty", i)
 			} else {
-				// 日付フォーマットの検証（YYYY-MM-DD形式）
-				if len(topix.Date) != 10 || topix.Date[4] != '-' || topix.Date[7] != '-' {
-					t.Errorf("TOPIX[%d]: Date format invalid = %v, want YYYY-MM-DD", i, topix.Date)
-				}
 				// 日付の一致確認（APIはYYYY-MM-DD形式で返す）
-				expectedDate := getTestDateFormatted()
+				expectedDate := getTestDate()
 				if topix.Date != expectedDate {
 					t.Errorf("TOPIX[%d]: Date = %v, want %v", i, topix.Date, expectedDate)
 				}
@@ -91,9 +87,7 @@ func TestTOPIXEndpoint(t *testing.T) {
 	t.Run("GetTOPIXData_MultiDay", func(t *testing.T) {
 		// 過去1週間のTOPIXデータを取得
 		toDate := getTestDate()
-		fromTime, _ := time.Parse("20060102", toDate)
-		fromTime = fromTime.AddDate(0, 0, -7)
-		fromDate := fromTime.Format("20060102")
+		fromDate := toDate.AddDays(-7)
 
 		params := jquants.TOPIXParams{
 			From: fromDate,
@@ -114,15 +108,11 @@ func TestTOPIXEndpoint(t *testing.T) {
 
 		t.Logf("Retrieved %d TOPIX records for period %s to %s", len(resp.Data), fromDate, toDate)
 
-		// 日付の範囲確認（YYYY-MM-DD形式での比較）
+		// 日付の範囲確認
 		for _, topix := range resp.Data {
-			// YYYYMMDD形式をYYYY-MM-DD形式に変換して比較
-			expectedFromDate := fromDate[:4] + "-" + fromDate[4:6] + "-" + fromDate[6:8]
-			expectedToDate := toDate[:4] + "-" + toDate[4:6] + "-" + toDate[6:8]
-
-			if topix.Date < expectedFromDate || topix.Date > expectedToDate {
-				t.Logf("TOPIX date %s is outside requested range %s to %s (converted from %s to %s)",
-					topix.Date, expectedFromDate, expectedToDate, fromDate, toDate)
+			if topix.Date.Before(fromDate) || topix.Date.After(toDate) {
+				t.Logf("TOPIX date %s is outside requested range %s to %s",
+					topix.Date, fromDate, toDate)
 			}
 		}
 
@@ -163,7 +153,7 @@ func TestTOPIXEndpoint(t *testing.T) {
 			// 指定日のデータが取得できたことを確認
 			topix := resp.Data[0]
 			// 日付の検証（APIはYYYY-MM-DD形式で返す）
-			expectedDate := getTestDateFormatted()
+			expectedDate := getTestDate()
 			if topix.Date != expectedDate {
 				t.Errorf("Expected date %s, got %s", expectedDate, topix.Date)
 			}
@@ -174,8 +164,8 @@ func TestTOPIXEndpoint(t *testing.T) {
 	t.Run("GetTOPIXData_Pagination", func(t *testing.T) {
 		// ページネーションのテスト（長期間を指定）
 		params := jquants.TOPIXParams{
-			From: "2024-01-01",
-			To:   "2024-01-31",
+			From: types.MustParseDate("2024-01-01"),
+			To:   types.MustParseDate("2024-01-31"),
 		}
 
 		resp, err := jq.TOPIX.GetTOPIXData(context.Background(), params)
@@ -222,7 +212,7 @@ func TestTOPIXEndpoint(t *testing.T) {
 		}
 
 		// 基本的な検証
-		if topix.Date == "" {
+		if topix.Date.IsZero() {
 			t.Error("Latest TOPIX: Date is empty")
 		}
 		if topix.C <= 0 {
@@ -235,9 +225,7 @@ func TestTOPIXEndpoint(t *testing.T) {
 	t.Run("GetTOPIXData_Statistics", func(t *testing.T) {
 		// 過去30日のTOPIXデータから統計情報を計算
 		toDate := getTestDate()
-		fromTime, _ := time.Parse("20060102", toDate)
-		fromTime = fromTime.AddDate(0, 0, -30)
-		fromDate := fromTime.Format("20060102")
+		fromDate := toDate.AddDays(-30)
 
 		params := jquants.TOPIXParams{
 			From: fromDate,
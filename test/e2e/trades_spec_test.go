@@ -5,11 +5,10 @@ package e2e
 
 import (
 	"context"
-	"strings"
 	"testing"
-	"time"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestTradesSpecEndpoint は/equities/investor-typesエンドポイントの完全なテスト
@@ -35,7 +34,10 @@ func TestTradesSpecEndpoint(t *testing.T) {
 				latestPub = tr.PubDate
 			}
 		}
-		date := strings.ReplaceAll(latestPub, "-", "")
+		date, err := types.ParseDate(latestPub)
+		if err != nil {
+			t.Fatalf("Invalid PubDate %q: %v", latestPub, err)
+		}
 
 		trades, err := jq.TradesSpec.GetTradesSpecByDateRange(context.Background(), date, date)
 		if err != nil {
@@ -194,9 +196,7 @@ func TestTradesSpecEndpoint(t *testing.T) {
 	t.Run("GetTradesSpec_WeeklyData", func(t *testing.T) {
 		// 過去1週間のデータ取得
 		to := getTestDate()
-		fromTime, _ := time.Parse("20060102", to)
-		fromTime = fromTime.AddDate(0, 0, -7)
-		from := fromTime.Format("20060102")
+		from := to.AddDays(-7)
 
 		trades, err := jq.TradesSpec.GetTradesSpecByDateRange(context.Background(), from, to)
 		if err != nil {
@@ -214,7 +214,7 @@ func TestTradesSpecEndpoint(t *testing.T) {
 
 		// 期間内のデータか確認
 		for _, trade := range trades {
-			if trade.StDate < from || trade.EnDate > to {
+			if trade.StDate < from.String() || trade.EnDate > to.String() {
 				t.Logf("Trade period (%s to %s) extends beyond requested range (%s to %s)",
 					trade.StDate, trade.EnDate, from, to)
 			}
@@ -298,7 +298,7 @@ func TestTradesSpecEndpoint(t *testing.T) {
 		}
 
 		// 無効な日付範囲
-		trades, err = jq.TradesSpec.GetTradesSpecByDateRange(context.Background(), "2024-12-31", "2024-01-01")
+		trades, err = jq.TradesSpec.GetTradesSpecByDateRange(context.Background(), types.MustParseDate("2024-12-31"), types.MustParseDate("2024-01-01"))
 		if err == nil && len(trades) > 0 {
 			t.Error("Expected error or empty result for invalid date range")
 		}
@@ -8,14 +8,16 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/utahta/jquants/types"
 )
 
 // TestTradingCalendarEndpoint は/markets/trading_calendarエンドポイントの完全なテスト
 func TestTradingCalendarEndpoint(t *testing.T) {
 	t.Run("GetTradingCalendar_CurrentMonth", func(t *testing.T) {
 		// 今月の取引カレンダーを取得
-		from := "2024-06-01"
-		to := "2024-06-30"
+		from := types.MustParseDate("2024-06-01")
+		to := types.MustParseDate("2024-06-30")
 
 		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), from, to)
 		if err != nil {
@@ -36,20 +38,15 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 		// 各カレンダーエントリを詳細に検証
 		for i, day := range calendar {
 			// 基本情報の検証
-			if day.Date == "" {
+			if day.Date.IsZero() {
 				t.Errorf("Calendar[%d]: Date is empty", i)
 			}
 			if day.HolDiv == "" {
 				t.Errorf("Calendar[%d]: HolidayDivision is empty", i)
 			}
 
-			// 日付フォーマットの検証（YYYY-MM-DD形式）
-			if len(day.Date) != 10 || day.Date[4] != '-' || day.Date[7] != '-' {
-				t.Errorf("Calendar[%d]: Date format invalid = %v, want YYYY-MM-DD", i, day.Date)
-			}
-
 			// 日付範囲の検証
-			if day.Date < from || day.Date > to {
+			if day.Date.Before(from) || day.Date.After(to) {
 				t.Errorf("Calendar[%d]: Date %s is outside requested range %s to %s",
 					i, day.Date, from, to)
 			}
@@ -82,8 +79,8 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 
 	t.Run("GetTradingCalendar_SpecificRange", func(t *testing.T) {
 		// 特定の期間（2024年1月）の取引カレンダーを取得
-		from := "2024-01-01"
-		to := "2024-01-31"
+		from := types.MustParseDate("2024-01-01")
+		to := types.MustParseDate("2024-01-31")
 
 		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), from, to)
 		if err != nil {
@@ -122,7 +119,7 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 
 		// 2024年1月の特定の日をチェック（元日は非営業日のはず）
 		for _, day := range calendar {
-			if day.Date == "2024-01-01" {
+			if day.Date == types.NewDate(2024, time.January, 1) {
 				t.Logf("New Year's Day HolidayDivision: %s", day.HolDiv)
 				if day.HolDiv != "0" {
 					t.Errorf("New Year's Day should be non-business day (0), got: %s", day.HolDiv)
@@ -136,8 +133,8 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 
 	t.Run("GetTradingCalendar_WeekendValidation", func(t *testing.T) {
 		// 週末の扱いを確認
-		from := "2024-05-01"
-		to := "2024-05-31"
+		from := types.MustParseDate("2024-05-01")
+		to := types.MustParseDate("2024-05-31")
 
 		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), from, to)
 		if err != nil {
@@ -158,13 +155,7 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 		weekdayHolidays := 0
 
 		for _, day := range calendar {
-			date, err := time.Parse("2006-01-02", day.Date)
-			if err != nil {
-				t.Errorf("Failed to parse date: %s", day.Date)
-				continue
-			}
-
-			weekday := date.Weekday()
+			weekday := day.Date.Weekday()
 			isWeekend := weekday == time.Saturday || weekday == time.Sunday
 			isBusinessDay := day.HolDiv == "1" || day.HolDiv == "2"
 
@@ -186,8 +177,8 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 
 	t.Run("GetTradingCalendar_YearlyAnalysis", func(t *testing.T) {
 		// 年間の営業日数分析（過去の完全な年）
-		from := "2023-01-01"
-		to := "2023-12-31"
+		from := types.MustParseDate("2023-01-01")
+		to := types.MustParseDate("2023-12-31")
 
 		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), from, to)
 		if err != nil {
@@ -208,7 +199,7 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 		monthlyHolidays := make(map[string]int)
 
 		for _, day := range calendar {
-			month := day.Date[:7] // YYYY-MM
+			month := day.Date.String()[:7] // YYYY-MM
 
 			if day.HolDiv == "0" {
 				monthlyBusinessDays[month]++
@@ -235,7 +226,7 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 
 	t.Run("GetTradingCalendar_SingleDate", func(t *testing.T) {
 		// 単一日付での取得テスト
-		today := time.Now().Format("2006-01-02")
+		today := types.Today()
 
 		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), today, today)
 		if err != nil {
@@ -272,14 +263,14 @@ func TestTradingCalendarEndpoint(t *testing.T) {
 		// エラーケースのテスト
 
 		// 無効な日付範囲
-		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), "2024-12-31", "2024-01-01")
+		calendar, err := jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), types.MustParseDate("2024-12-31"), types.MustParseDate("2024-01-01"))
 		if err == nil && len(calendar) > 0 {
 			t.Error("Expected error or empty result for invalid date range")
 		}
 
 		// 未来の遠い日付
-		futureFrom := time.Now().AddDate(10, 0, 0).Format("2006-01-02")
-		futureTo := time.Now().AddDate(10, 0, 30).Format("2006-01-02")
+		futureFrom := types.DateOf(time.Now().AddDate(10, 0, 0))
+		futureTo := futureFrom.AddDays(30)
 
 		calendar, err = jq.TradingCalendar.GetTradingCalendarByDateRange(context.Background(), futureFrom, futureTo)
 		if err == nil && len(calendar) > 0 {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
 )
 
 // TestWeeklyMarginInterestEndpoint は/markets/weekly_margin_interestエンドポイントの完全なテスト
@@ -35,15 +36,10 @@ func TestWeeklyMarginInterestEndpoint(t *testing.T) {
 			if interest.Code != "72030" && interest.Code != "7203" {
 				t.Errorf("Interest[%d]: Code = %v, want 72030 or 7203", i, interest.Code)
 			}
-			if interest.Date == "" {
+			if interest.Date.IsZero() {
 				t.Errorf("Interest[%d]: Date is empty", i)
 			}
 
-			// 日付フォーマットの検証（YYYY-MM-DD形式）
-			if len(interest.Date) != 10 || interest.Date[4] != '-' || interest.Date[7] != '-' {
-				t.Errorf("Interest[%d]: Date format invalid = %v, want YYYY-MM-DD", i, interest.Date)
-			}
-
 			// IssueTypeの検証（1, 2, 3のいずれか）
 			if interest.IssType != "" {
 				validIssueTypes := map[string]bool{"1": true, "2": true, "3": true}
@@ -53,9 +49,8 @@ func TestWeeklyMarginInterestEndpoint(t *testing.T) {
 			}
 
 			// 日付が金曜日かチェック
-			date, err := time.Parse("2006-01-02", interest.Date)
-			if err == nil && date.Weekday() != time.Friday {
-				t.Logf("Interest[%d]: Date %s is not Friday (%s)", i, interest.Date, date.Weekday().String())
+			if weekday := interest.Date.Weekday(); weekday != time.Friday {
+				t.Logf("Interest[%d]: Date %s is not Friday (%s)", i, interest.Date, weekday.String())
 			}
 
 			// 信用買い残高の検証
@@ -145,11 +140,6 @@ func TestWeeklyMarginInterestEndpoint(t *testing.T) {
 				t.Errorf("Interest[%d]: Date = %v, want %v", i, interest.Date, friday)
 			}
 
-			// 日付フォーマットの検証（YYYY-MM-DD形式）
-			if len(interest.Date) != 10 || interest.Date[4] != '-' || interest.Date[7] != '-' {
-				t.Errorf("Interest[%d]: Date format invalid = %v, want YYYY-MM-DD", i, interest.Date)
-			}
-
 			// IssueTypeの検証
 			if interest.IssType != "" {
 				validIssueTypes := map[string]bool{"1": true, "2": true, "3": true}
@@ -188,9 +178,7 @@ func TestWeeklyMarginInterestEndpoint(t *testing.T) {
 	t.Run("GetWeeklyMarginInterest_TimeSeries", func(t *testing.T) {
 		// 過去数週間のトレンド分析
 		endFriday := getRecentFriday()
-		startDate, _ := time.Parse("2006-01-02", endFriday)
-		startDate = startDate.AddDate(0, 0, -28) // 4週間前
-		startFriday := startDate.Format("2006-01-02")
+		startFriday := endFriday.AddDays(-28) // 4週間前
 
 		interests, err := jq.WeeklyMarginInterest.GetWeeklyMarginInterestByCodeAndDateRange(context.Background(), "7203", startFriday, endFriday)
 		if err != nil {
@@ -330,25 +318,24 @@ func TestWeeklyMarginInterestEndpoint(t *testing.T) {
 		// エラーケースのテスト
 
 		// 存在しない銘柄コード
-		interests, err := jq.WeeklyMarginInterest.GetWeeklyMarginInterestByCodeAndDateRange(context.Background(), "99999", "2024-01-05", "2024-01-05")
+		interests, err := jq.WeeklyMarginInterest.GetWeeklyMarginInterestByCodeAndDateRange(context.Background(), "99999", types.MustParseDate("2024-01-05"), types.MustParseDate("2024-01-05"))
 		if err == nil && len(interests) > 0 {
 			t.Error("Expected error or empty result for invalid code")
 		}
 
 		// 平日の日付（金曜日以外）
-		monday := time.Now()
+		monday := types.Today()
 		for monday.Weekday() != time.Monday {
-			monday = monday.AddDate(0, 0, -1)
+			monday = monday.AddDays(-1)
 		}
-		mondayStr := monday.Format("2006-01-02")
 
 		params := jquants.WeeklyMarginInterestParams{
-			Date: mondayStr,
+			Date: monday,
 		}
 
 		resp, err := jq.WeeklyMarginInterest.GetWeeklyMarginInterest(context.Background(), params)
 		if err == nil && resp != nil && len(resp.Data) > 0 {
-			t.Logf("Warning: Got data for non-Friday date %s", mondayStr)
+			t.Logf("Warning: Got data for non-Friday date %s", monday)
 		}
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"slices"
 
 	"github.com/utahta/jquants/client"
@@ -30,13 +31,13 @@ func NewTimelyDisclosureService(c client.HTTPClient) *TimelyDisclosureService {
 
 // TimelyDisclosureParams は適時開示インデックス一覧のリクエストパラメータです。
 type TimelyDisclosureParams struct {
-	Date          string // 開示日（YYYYMMDD または YYYY-MM-DD）（date、codeのいずれかが必須）
-	Code          string // 4桁もしくは5桁の銘柄コード（date、codeのいずれかが必須）
-	From          string // 取得開始日（YYYYMMDD または YYYY-MM-DD）。codeと組み合わせ、toと必ずペアで指定
-	To            string // 取得終了日（YYYYMMDD または YYYY-MM-DD）。codeと組み合わせ、fromと必ずペアで指定
-	DiscItems     string // 公開項目コードで絞り込む（カンマ区切りで複数指定可能、AND条件）
-	Cursor        string // 差分取得用カーソル（前回レスポンスのcursorを指定）。pagination_keyと同時指定不可
-	PaginationKey string // ページネーションキー。cursorと同時指定不可
+	Date          types.Date // 開示日（date、codeのいずれかが必須）
+	Code          string     // 4桁もしくは5桁の銘柄コード（date、codeのいずれかが必須）
+	From          types.Date // 取得開始日。codeと組み合わせ、toと必ずペアで指定
+	To            types.Date // 取得終了日。codeと組み合わせ、fromと必ずペアで指定
+	DiscItems     string     // 公開項目コードで絞り込む（カンマ区切りで複数指定可能、AND条件。JoinDiscItemsで組み立て可能）
+	Cursor        string     // 差分取得用カーソル（前回レスポンスのcursorを指定）。pagination_keyと同時指定不可
+	PaginationKey string     // ページネーションキー。cursorと同時指定不可
 }
 
 // TimelyDisclosureResponse は適時開示インデックス一覧のレスポンスです。
@@ -49,16 +50,16 @@ type TimelyDisclosureResponse struct {
 // TimelyDisclosure は適時開示インデックスのデータを表します。
 // J-Quants API /td/list エンドポイントのレスポンスデータ。
 type TimelyDisclosure struct {
-	DiscNo     string   `json:"DiscNo"`     // 開示番号（14桁）
-	Code       string   `json:"Code"`       // 銘柄コード
-	Name       string   `json:"Name"`       // 会社名
-	DiscDate   string   `json:"DiscDate"`   // 開示日（YYYY-MM-DD形式）
-	DiscTime   string   `json:"DiscTime"`   // 開示時刻（HH:MM形式）
-	Title      string   `json:"Title"`      // 開示タイトル
-	DiscStatus string   `json:"DiscStatus"` // 取扱属性（""=新規、"revision"=訂正、"delete"=削除。現仕様では常に新規）
-	RevNo      int      `json:"RevNo"`      // 開示履歴番号（1～99。現仕様では常に1）
-	DiscItems  []string `json:"DiscItems"`  // 公開項目コードのリスト
-	Docs       []string `json:"Docs"`       // 書類タイプのリスト（g=全文情報PDF、s=サマリ情報PDF、x=XBRL関連ファイル）
+	DiscNo     string     `json:"DiscNo"`     // 開示番号（14桁）
+	Code       string     `json:"Code"`       // 銘柄コード
+	Name       string     `json:"Name"`       // 会社名
+	DiscDate   types.Date `json:"DiscDate"`   // 開示日
+	DiscTime   string     `json:"DiscTime"`   // 開示時刻（HH:MM形式）
+	Title      string     `json:"Title"`      // 開示タイトル
+	DiscStatus string     `json:"DiscStatus"` // 取扱属性（""=新規、"revision"=訂正、"delete"=削除。現仕様では常に新規）
+	RevNo      int        `json:"RevNo"`      // 開示履歴番号（1～99。現仕様では常に1）
+	DiscItems  []string   `json:"DiscItems"`  // 公開項目コードのリスト
+	Docs       []string   `json:"Docs"`       // 書類タイプのリスト（g=全文情報PDF、s=サマリ情報PDF、x=XBRL関連ファイル）
 }
 
 // RawTimelyDisclosure is used for unmarshaling JSON response with mixed types
@@ -66,7 +67,7 @@ type RawTimelyDisclosure struct {
 	DiscNo     string               `json:"DiscNo"`
 	Code       string               `json:"Code"`
 	Name       string               `json:"Name"`
-	DiscDate   string               `json:"DiscDate"`
+	DiscDate   types.Date           `json:"DiscDate"`
 	DiscTime   string               `json:"DiscTime"`
 	Title      string               `json:"Title"`
 	DiscStatus types.NullableString `json:"DiscStatus"`
@@ -143,8 +144,14 @@ type TimelyDisclosureBulk struct {
 
 // GetDisclosures は適時開示インデックス一覧を取得します。
 func (s *TimelyDisclosureService) GetDisclosures(ctx context.Context, params TimelyDisclosureParams) (*TimelyDisclosureResponse, error) {
+	// cursorによる差分取得はポーリング用途のため、キャッシュを経由しない
+	return s.getDisclosures(ctx, params, params.Cursor != "")
+}
+
+// getDisclosures はGetDisclosuresの本体です。noCacheがtrueのときはキャッシュを経由せずに取得します。
+func (s *TimelyDisclosureService) getDisclosures(ctx context.Context, params TimelyDisclosureParams, noCache bool) (*TimelyDisclosureResponse, error) {
 	// date、codeのいずれかが必須
-	if params.Date == "" && params.Code == "" {
+	if params.Date.IsZero() && params.Code == "" {
 		return nil, fmt.Errorf("either date or code parameter is required")
 	}
 	// cursorとpagination_keyは同時指定不可
@@ -154,37 +161,23 @@ func (s *TimelyDisclosureService) GetDisclosures(ctx context.Context, params Tim
 
 	path := "/td/list"
 
-	query := "?"
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.DiscItems != "" {
-		query += fmt.Sprintf("discItems=%s&", params.DiscItems)
-	}
-	if params.Cursor != "" {
-		query += fmt.Sprintf("cursor=%s&", params.Cursor)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
-
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	query := newQuery()
+	query.setDate("date", params.Date)
+	query.setCode("code", params.Code)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("discItems", params.DiscItems)
+	query.set("cursor", params.Cursor)
+	query.set("pagination_key", params.PaginationKey)
+
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp TimelyDisclosureResponse
 	var err error
-	if params.Cursor != "" {
-		// cursorによる差分取得はポーリング用途のため、キャッシュを経由しない
+	if noCache {
 		err = client.DoRequestNoCache(ctx, s.client, "GET", path, nil, &resp)
 	} else {
 		err = s.client.DoRequest(ctx, "GET", path, nil, &resp)
@@ -196,62 +189,46 @@ func (s *TimelyDisclosureService) GetDisclosures(ctx context.Context, params Tim
 	return &resp, nil
 }
 
-// GetDisclosuresByDate は指定開示日の適時開示インデックス一覧を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *TimelyDisclosureService) GetDisclosuresByDate(ctx context.Context, date string) ([]TimelyDisclosure, error) {
-	var allData []TimelyDisclosure
-	paginationKey := ""
-
-	for {
-		params := TimelyDisclosureParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
+// AllDisclosures は指定条件の適時開示インデックスを1件ずつ返すイテレータです。
+// cursorを指定した場合、2ページ目以降はpagination_keyのみで取得します。
+func (s *TimelyDisclosureService) AllDisclosures(ctx context.Context, params TimelyDisclosureParams) iter.Seq2[TimelyDisclosure, error] {
+	return s.allDisclosures(ctx, params, params.Cursor != "", nil)
+}
 
-		resp, err := s.GetDisclosures(ctx, params)
+// allDisclosures はAllDisclosuresの本体です。noCacheがtrueのときは全ページをキャッシュを経由せずに取得し、
+// cursorがnilでなければ応答に含まれる最後の差分取得用カーソルを設定します。
+func (s *TimelyDisclosureService) allDisclosures(ctx context.Context, params TimelyDisclosureParams, noCache bool, cursor *string) iter.Seq2[TimelyDisclosure, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]TimelyDisclosure, string, error) {
+		params.PaginationKey = paginationKey
+		if paginationKey != "" {
+			// cursorとpagination_keyは同時指定できないため、2ページ目以降はpagination_keyのみで取得する
+			params.Cursor = ""
+		}
+		resp, err := s.getDisclosures(ctx, params, noCache)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+		if cursor != nil && resp.Cursor != "" {
+			*cursor = resp.Cursor
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allData, nil
+// GetDisclosuresByDate は指定開示日の適時開示インデックス一覧を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *TimelyDisclosureService) GetDisclosuresByDate(ctx context.Context, date types.Date) ([]TimelyDisclosure, error) {
+	return collectAll(s.AllDisclosures(ctx, TimelyDisclosureParams{
+		Date: date,
+	}))
 }
 
 // GetDisclosuresByCode は指定銘柄の適時開示インデックス一覧を取得します（直近5年間）。
 // ページネーションを使用して全データを取得します。
 func (s *TimelyDisclosureService) GetDisclosuresByCode(ctx context.Context, code string) ([]TimelyDisclosure, error) {
-	var allData []TimelyDisclosure
-	paginationKey := ""
-
-	for {
-		params := TimelyDisclosureParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetDisclosures(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+	return collectAll(s.AllDisclosures(ctx, TimelyDisclosureParams{
+		Code: code,
+	}))
 }
 
 // GetDisclosureFiles は開示番号に対応する適時開示ファイルのダウンロードURLを取得します。
@@ -264,13 +241,11 @@ func (s *TimelyDisclosureService) GetDisclosureFiles(ctx context.Context, params
 
 	path := "/td/files"
 
-	query := "?"
-	query += fmt.Sprintf("discNo=%s&", params.DiscNo)
-	if params.Docs != "" {
-		query += fmt.Sprintf("docs=%s&", params.Docs)
-	}
+	query := newQuery()
+	query.set("discNo", params.DiscNo)
+	query.set("docs", params.Docs)
 
-	path += query[:len(query)-1] // Remove trailing &
+	path += query.encode()
 
 	var resp TimelyDisclosureFiles
 	// 署名付きURLは15分で失効するため、キャッシュを経由しない
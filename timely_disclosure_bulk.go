@@ -0,0 +1,188 @@
+package jquants
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/utahta/jquants/types"
+)
+
+// TimelyDisclosureBulkFilter は全開示インデックスCSVから読み出す開示の条件です。
+// ゼロ値の項目は絞り込みに使用しません。
+type TimelyDisclosureBulkFilter struct {
+	Codes     []string   // 銘柄コード（4桁または5桁）。いずれかに一致する開示
+	From      types.Date // 開示日の開始日。この日を含む
+	To        types.Date // 開示日の終了日。この日を含む
+	DiscItems []string   // 公開項目コード。すべてを含む開示（APIのdiscItemsと同じくAND条件）
+}
+
+// timelyDisclosureMatcher はTimelyDisclosureBulkFilterを検証・正規化したものです。
+type timelyDisclosureMatcher struct {
+	codes     map[types.Code]bool
+	from, to  types.Date
+	discItems []string
+}
+
+func newTimelyDisclosureMatcher(f TimelyDisclosureBulkFilter) (*timelyDisclosureMatcher, error) {
+	m := &timelyDisclosureMatcher{discItems: f.DiscItems}
+	if len(f.Codes) > 0 {
+		m.codes = map[types.Code]bool{}
+		for _, s := range f.Codes {
+			code, err := types.ParseCode(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid code filter: %w", err)
+			}
+			m.codes[code] = true
+		}
+	}
+	m.from, m.to = f.From, f.To
+	return m, nil
+}
+
+func (m *timelyDisclosureMatcher) match(td *TimelyDisclosure) bool {
+	if m.codes != nil {
+		code, err := types.ParseCode(td.Code)
+		if err != nil || !m.codes[code] {
+			return false
+		}
+	}
+	if !m.from.IsZero() || !m.to.IsZero() {
+		date := td.DiscDate
+		if date.IsZero() || !m.from.IsZero() && date.Before(m.from) || !m.to.IsZero() && date.After(m.to) {
+			return false
+		}
+	}
+	for _, item := range m.discItems {
+		if !slices.Contains(td.DiscItems, item) {
+			return false
+		}
+	}
+	return true
+}
+
+// bulkTimelyDisclosure は全開示インデックスCSVの1行です。
+// DiscItems・DocsはCSVでは1つのセルに複数の値を含むため、文字列として読み込んでから分割します。
+type bulkTimelyDisclosure struct {
+	DiscNo     string     `json:"DiscNo"`
+	Code       string     `json:"Code"`
+	Name       string     `json:"Name"`
+	DiscDate   types.Date `json:"DiscDate"`
+	DiscTime   string     `json:"DiscTime"`
+	Title      string     `json:"Title"`
+	DiscStatus string     `json:"DiscStatus"`
+	RevNo      int        `json:"RevNo"`
+	DiscItems  string     `json:"DiscItems"`
+	Docs       string     `json:"Docs"`
+}
+
+// splitBulkList はCSVの1つのセルに含まれる複数の値を分割します。
+// 区切り文字（カンマ、セミコロン、縦線、空白）と、JSON配列として書かれた場合の括弧・引用符を取り除きます。
+func splitBulkList(cell string) []string {
+	values := strings.FieldsFunc(cell, func(r rune) bool {
+		switch r {
+		case ',', ';', '|', ' ', '\t', '[', ']', '"', '\'':
+			return true
+		}
+		return false
+	})
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// ReadTimelyDisclosures は全開示インデックスCSV（gzip展開後）を読み出し、filterに一致する開示を1件ずつ返すイテレータです。
+// CSVの列はAPIのJSONフィールド名と同じで、DiscItems・Docsの複数の値は分割してスライスに設定します。
+//
+// 行の変換に失敗した場合はその行のエラーを返して次の行に進みます。
+// CSVとして読めない場合やfilterが不正な場合はエラーを返して終了します。
+func ReadTimelyDisclosures(r io.Reader, filter TimelyDisclosureBulkFilter) iter.Seq2[TimelyDisclosure, error] {
+	return func(yield func(TimelyDisclosure, error) bool) {
+		m, err := newTimelyDisclosureMatcher(filter)
+		if err != nil {
+			yield(TimelyDisclosure{}, err)
+			return
+		}
+		for row, err := range BulkRows[bulkTimelyDisclosure](r) {
+			if err != nil {
+				if !yield(TimelyDisclosure{}, err) {
+					return
+				}
+				continue
+			}
+			td := TimelyDisclosure{
+				DiscNo:     row.DiscNo,
+				Code:       row.Code,
+				Name:       row.Name,
+				DiscDate:   row.DiscDate,
+				DiscTime:   row.DiscTime,
+				Title:      row.Title,
+				DiscStatus: row.DiscStatus,
+				RevNo:      row.RevNo,
+				DiscItems:  splitBulkList(row.DiscItems),
+				Docs:       splitBulkList(row.Docs),
+			}
+			if !m.match(&td) {
+				continue
+			}
+			if !yield(td, nil) {
+				return
+			}
+		}
+	}
+}
+
+// OpenBulk は全開示インデックスCSVのダウンロードを開始し、gzipを展開したCSVを読み出すReaderを返します。
+// 署名付きURL（有効期限15分）が失効したり接続が切れた場合は、URLを取得し直して中断した位置から再開します。
+// 呼び出し元はCloseする必要があります。
+func (s *TimelyDisclosureService) OpenBulk(ctx context.Context) (io.ReadCloser, error) {
+	body := &downloadBody{
+		ctx:    ctx,
+		client: s.client,
+		name:   "timely disclosure bulk file",
+		signURL: func(ctx context.Context) (string, error) {
+			bulk, err := s.GetBulkFile(ctx)
+			if err != nil {
+				return "", err
+			}
+			return bulk.URL, nil
+		},
+	}
+	if err := body.open(); err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, fmt.Errorf("failed to read timely disclosure bulk file: %w", err)
+	}
+	return &bulkReader{Reader: zr, body: body}, nil
+}
+
+// AllBulkDisclosures は全開示インデックスCSVをダウンロードしながら、filterに一致する開示を1件ずつ返すイテレータです。
+// 過去5年分の開示を /td/list を日付ごとに呼び出さずに取得できます。ファイル全体をメモリに保持しません。
+func (s *TimelyDisclosureService) AllBulkDisclosures(ctx context.Context, filter TimelyDisclosureBulkFilter) iter.Seq2[TimelyDisclosure, error] {
+	return func(yield func(TimelyDisclosure, error) bool) {
+		if _, err := newTimelyDisclosureMatcher(filter); err != nil {
+			yield(TimelyDisclosure{}, err)
+			return
+		}
+		rc, err := s.OpenBulk(ctx)
+		if err != nil {
+			yield(TimelyDisclosure{}, err)
+			return
+		}
+		defer func() { _ = rc.Close() }()
+
+		for td, err := range ReadTimelyDisclosures(rc, filter) {
+			if !yield(td, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package jquants
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+const testTimelyDisclosureCSV = "DiscNo,Code,Name,DiscDate,DiscTime,Title,DiscStatus,RevNo,DiscItems,Docs\n" +
+	"20250401130100,72030,トヨタ自動車,2025-04-01,15:00,2025年3月期 決算短信〔IFRS〕（連結）,,1,\"11101,11102\",\"g,s,x\"\n" +
+	"20250401130200,99840,ソフトバンクグループ,2025-04-01,15:30,業績予想の修正に関するお知らせ,,1,11102,g\n" +
+	"20250402120100,7203,トヨタ自動車,2025-04-02,12:00,\"自己株式の取得状況に関するお知らせ\",,1,\"[\"\"11101\"\"]\",\n"
+
+func collectDisclosures(t *testing.T, seq func(func(TimelyDisclosure, error) bool)) []TimelyDisclosure {
+	t.Helper()
+	var got []TimelyDisclosure
+	for td, err := range seq {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, td)
+	}
+	return got
+}
+
+func TestReadTimelyDisclosures(t *testing.T) {
+	got := collectDisclosures(t, ReadTimelyDisclosures(strings.NewReader(testTimelyDisclosureCSV), TimelyDisclosureBulkFilter{}))
+	if len(got) != 3 {
+		t.Fatalf("got %d disclosures, want 3", len(got))
+	}
+
+	td := got[0]
+	if td.DiscNo != "20250401130100" || td.RevNo != 1 || td.DiscTime != "15:00" {
+		t.Errorf("got[0] = %+v", td)
+	}
+	if !slices.Equal(td.DiscItems, []string{"11101", "11102"}) || !slices.Equal(td.Docs, []string{"g", "s", "x"}) {
+		t.Errorf("DiscItems = %v, Docs = %v", td.DiscItems, td.Docs)
+	}
+	if !td.HasXBRL() {
+		t.Error("HasXBRL() = false, want true")
+	}
+	// JSON配列として書かれた値・空欄
+	if !slices.Equal(got[2].DiscItems, []string{"11101"}) || got[2].Docs != nil {
+		t.Errorf("got[2] DiscItems = %q, Docs = %q", got[2].DiscItems, got[2].Docs)
+	}
+}
+
+func TestReadTimelyDisclosures_Filter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter TimelyDisclosureBulkFilter
+		want   []string
+	}{
+		{"code (4 and 5 digits match)", TimelyDisclosureBulkFilter{Codes: []string{"7203"}}, []string{"20250401130100", "20250402120100"}},
+		{"date range", TimelyDisclosureBulkFilter{From: types.MustParseDate("20250402"), To: types.MustParseDate("2025-04-30")}, []string{"20250402120100"}},
+		{"to only", TimelyDisclosureBulkFilter{To: types.MustParseDate("2025-04-01")}, []string{"20250401130100", "20250401130200"}},
+		{"discItems (AND)", TimelyDisclosureBulkFilter{DiscItems: []string{"11101", "11102"}}, []string{"20250401130100"}},
+		{"combined", TimelyDisclosureBulkFilter{Codes: []string{"99840", "72030"}, DiscItems: []string{"11102"}, From: types.MustParseDate("2025-04-01")}, []string{"20250401130100", "20250401130200"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, td := range collectDisclosures(t, ReadTimelyDisclosures(strings.NewReader(testTimelyDisclosureCSV), tt.filter)) {
+				got = append(got, td.DiscNo)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, filter := range []TimelyDisclosureBulkFilter{{Codes: []string{"abc"}}} {
+		var errs int
+		for _, err := range ReadTimelyDisclosures(strings.NewReader(testTimelyDisclosureCSV), filter) {
+			if err != nil {
+				errs++
+			}
+		}
+		if errs != 1 {
+			t.Errorf("filter %+v: errs = %d, want 1", filter, errs)
+		}
+	}
+}
+
+func TestTimelyDisclosureService_AllBulkDisclosures(t *testing.T) {
+	data := gzipBytes(t, testTimelyDisclosureCSV)
+	var calls int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			// 最初のURLは失効している
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/td/bulk", TimelyDisclosureBulk{LastUpdated: "2025-04-02T08:00:00Z", URL: server.URL + "/td.csv.gz"})
+	service := NewTimelyDisclosureService(mockClient)
+
+	got := collectDisclosures(t, service.AllBulkDisclosures(context.Background(), TimelyDisclosureBulkFilter{Codes: []string{"99840"}}))
+	if len(got) != 1 || got[0].DiscNo != "20250401130200" {
+		t.Errorf("got %+v", got)
+	}
+	if mockClient.RequestCount != 2 || !mockClient.LastSkipCache {
+		t.Errorf("GetBulkFile called %d times, want 2", mockClient.RequestCount)
+	}
+
+	// 不正な条件ではダウンロードしない
+	mockClient.RequestCount = 0
+	for _, err := range service.AllBulkDisclosures(context.Background(), TimelyDisclosureBulkFilter{Codes: []string{"x"}}) {
+		if err == nil {
+			t.Error("expected error for invalid filter")
+		}
+	}
+	if mockClient.RequestCount != 0 {
+		t.Errorf("GetBulkFile called %d times, want 0", mockClient.RequestCount)
+	}
+}
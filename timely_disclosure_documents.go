@@ -0,0 +1,236 @@
+package jquants
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 取得済みの署名付きURL（有効期限15分）を再利用する期間。これを過ぎたURLは使用前に取得し直します。
+const timelyDisclosureURLReuse = 10 * time.Minute
+
+// DocumentStore は適時開示書類の保存先です。
+// ローカルディレクトリに保存するDirDocumentStoreのほか、オブジェクトストレージなどに保存する実装を指定できます。
+type DocumentStore interface {
+	// Put はrの内容をnameとして保存します。nameは "/" 区切りの相対パスです。
+	// rの読み出しがエラーを返した場合は、書きかけの内容を残さずにそのエラーを返す必要があります。
+	Put(ctx context.Context, name string, r io.Reader) error
+}
+
+// DirDocumentStore はローカルディレクトリに書類を保存するDocumentStoreです。
+// 一時ファイルに書き込んでからリネームするため、中断しても不完全なファイルは残りません。
+type DirDocumentStore struct {
+	dir string
+}
+
+// NewDirDocumentStore はdir以下に書類を保存するDirDocumentStoreを作成します。
+func NewDirDocumentStore(dir string) *DirDocumentStore {
+	return &DirDocumentStore{dir: dir}
+}
+
+// Put はDocumentStoreを実装します。dirの外を指すnameはエラーです。
+func (s *DirDocumentStore) Put(_ context.Context, name string, r io.Reader) error {
+	rel := filepath.FromSlash(name)
+	if name == "" || !filepath.IsLocal(rel) {
+		return fmt.Errorf("invalid document name %q", name)
+	}
+	p := filepath.Join(s.dir, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+	_, err := writeFileAtomic(p, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	return err
+}
+
+// TimelyDisclosureDocument は保存した適時開示書類を表します。
+type TimelyDisclosureDocument struct {
+	Doc  string // 書類タイプ（g=全文情報PDF、s=サマリ情報PDF、x=XBRL関連ファイル）
+	Name string // 保存先の名前（<開示日>/<銘柄コード>/<開示番号>/<ファイル名>）
+	Size int64  // バイト数
+}
+
+// timelyDisclosureDocFormat は書類タイプごとの保存ファイル名と、内容の検証に使用する情報です。
+type timelyDisclosureDocFormat struct {
+	file         string   // 保存ファイル名
+	magic        string   // ファイルの先頭のバイト列
+	contentTypes []string // 許容するContent-Type（application/octet-stream等は常に許容）
+}
+
+var timelyDisclosureDocFormats = map[string]timelyDisclosureDocFormat{
+	TimelyDisclosureDocFullPDF:    {file: "full.pdf", magic: "%PDF-", contentTypes: []string{"application/pdf"}},
+	TimelyDisclosureDocSummaryPDF: {file: "summary.pdf", magic: "%PDF-", contentTypes: []string{"application/pdf"}},
+	TimelyDisclosureDocXBRL:       {file: "xbrl.zip", magic: "PK\x03\x04", contentTypes: []string{"application/zip", "application/x-zip-compressed"}},
+}
+
+// DocumentPath は適時開示書類の保存先の名前（<開示日>/<銘柄コード>/<開示番号>/<ファイル名>）を返します。
+// ファイル名は書類タイプに応じて full.pdf、summary.pdf、xbrl.zip のいずれかです。
+func (td *TimelyDisclosure) DocumentPath(doc string) (string, error) {
+	format, ok := timelyDisclosureDocFormats[doc]
+	if !ok {
+		return "", fmt.Errorf("unknown document type %q", doc)
+	}
+	if td.DiscDate.IsZero() {
+		return "", fmt.Errorf("disclosure date is not available")
+	}
+	for _, s := range []string{td.Code, td.DiscNo} {
+		if s == "" || strings.ContainsAny(s, `/\.`) {
+			return "", fmt.Errorf("invalid code or discNo: %q", s)
+		}
+	}
+	return path.Join(td.DiscDate.String(), td.Code, td.DiscNo, format.file), nil
+}
+
+// DownloadDocuments は適時開示の書類をダウンロードしてdstに保存します。
+// docsには書類タイプ（TimelyDisclosureDocFullPDFなど）を指定し、空の場合はtd.Docsのすべてを保存します。
+// 保存先の名前はDocumentPathの形式で、開示日・銘柄コードを使用するため開示番号ではなくtdを受け取ります。
+//
+// 署名付きURL（有効期限15分）はGetDisclosureFilesで取得し、失効や接続の切断で失敗した場合は
+// URLを取得し直して再試行します。保存前にContent-Typeとファイルの先頭（PDF・ZIPの形式）を確認し、
+// Content-Lengthと受信したサイズが一致しない場合は保存しません。
+//
+// いずれかの書類でエラーが発生した場合は中止し、それまでに保存した書類とエラーを返します。
+func (s *TimelyDisclosureService) DownloadDocuments(ctx context.Context, td TimelyDisclosure, docs []string, dst DocumentStore) ([]TimelyDisclosureDocument, error) {
+	if td.DiscNo == "" {
+		return nil, fmt.Errorf("discNo parameter is required")
+	}
+	if dst == nil {
+		return nil, fmt.Errorf("document store is required")
+	}
+	if len(docs) == 0 {
+		docs = td.Docs
+	}
+	names := make([]string, len(docs))
+	for i, doc := range docs {
+		var err error
+		if names[i], err = td.DocumentPath(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	// 最初は全書類のURLをまとめて取得し、再試行時は書類ごとに取得し直す
+	var urls *TimelyDisclosureFileURLs
+	var fetchedAt time.Time
+	fetchURLs := func(ctx context.Context, docs string) error {
+		files, err := s.GetDisclosureFiles(ctx, TimelyDisclosureFilesParams{DiscNo: td.DiscNo, Docs: docs})
+		if err != nil {
+			return err
+		}
+		urls, fetchedAt = &files.Files, time.Now()
+		return nil
+	}
+
+	if err := fetchURLs(ctx, strings.Join(docs, ",")); err != nil {
+		return nil, err
+	}
+
+	var stored []TimelyDisclosureDocument
+	for i, doc := range docs {
+		used := false
+		body := &downloadBody{
+			ctx:    ctx,
+			client: s.client,
+			name:   fmt.Sprintf("timely disclosure document %s (%s)", td.DiscNo, doc),
+			signURL: func(ctx context.Context) (string, error) {
+				// 再試行時と、取得から時間が経ったURLは取得し直す
+				if used || urls.url(doc) == "" || time.Since(fetchedAt) >= timelyDisclosureURLReuse {
+					if err := fetchURLs(ctx, doc); err != nil {
+						return "", err
+					}
+				}
+				used = true
+				if u := urls.url(doc); u != "" {
+					return u, nil
+				}
+				return "", fmt.Errorf("document %s of %s is not available", doc, td.DiscNo)
+			},
+		}
+
+		n, err := s.storeDocument(ctx, body, doc, names[i], dst)
+		if err != nil {
+			return stored, err
+		}
+		stored = append(stored, TimelyDisclosureDocument{Doc: doc, Name: names[i], Size: n})
+	}
+	return stored, nil
+}
+
+// storeDocument はbodyの内容を検証しながらdstのnameに保存し、保存したバイト数を返します。
+func (s *TimelyDisclosureService) storeDocument(ctx context.Context, body *downloadBody, doc, name string, dst DocumentStore) (int64, error) {
+	if err := body.open(); err != nil {
+		return 0, err
+	}
+	defer func() { _ = body.Close() }()
+
+	format := timelyDisclosureDocFormats[doc]
+	if err := checkDocumentContentType(body.contentType, format.contentTypes); err != nil {
+		return 0, fmt.Errorf("failed to download %s: %w", body.name, err)
+	}
+	br := bufio.NewReader(body)
+	head, err := br.Peek(len(format.magic))
+	if err != nil || !bytes.Equal(head, []byte(format.magic)) {
+		return 0, fmt.Errorf("failed to download %s: content is not a %s file", body.name, path.Ext(format.file)[1:])
+	}
+
+	r := &sizeCheckReader{r: br, want: body.size}
+	if err := dst.Put(ctx, name, r); err != nil {
+		return 0, fmt.Errorf("failed to store %s: %w", body.name, err)
+	}
+	return r.n, nil
+}
+
+// url は書類タイプに対応するダウンロードURLを返します。
+func (u *TimelyDisclosureFileURLs) url(doc string) string {
+	switch doc {
+	case TimelyDisclosureDocFullPDF:
+		return u.PDF
+	case TimelyDisclosureDocSummaryPDF:
+		return u.SummaryPDF
+	case TimelyDisclosureDocXBRL:
+		return u.XBRL
+	}
+	return ""
+}
+
+// checkDocumentContentType はContent-Typeが書類の形式として妥当かを確認します。
+// 空、またはオブジェクトストレージが既定で返す汎用的な型の場合は許容します。
+func checkDocumentContentType(contentType string, allowed []string) error {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid content type %q", contentType)
+	}
+	if mediaType == "application/octet-stream" || mediaType == "binary/octet-stream" || slices.Contains(allowed, mediaType) {
+		return nil
+	}
+	return fmt.Errorf("unexpected content type %q", contentType)
+}
+
+// sizeCheckReader は読み終えた時点でサイズがwantと一致しなければエラーを返します（wantが負の場合は確認しません）。
+type sizeCheckReader struct {
+	r    io.Reader
+	want int64
+	n    int64
+}
+
+// Read implements io.Reader.
+func (r *sizeCheckReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if err == io.EOF && r.want >= 0 && r.n != r.want {
+		return n, fmt.Errorf("size mismatch: got %d bytes, want %d", r.n, r.want)
+	}
+	return n, err
+}
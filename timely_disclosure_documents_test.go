@@ -0,0 +1,198 @@
+package jquants
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+const (
+	testPDF  = "%PDF-1.7\n...\n%%EOF\n"
+	testXBRL = "PK\x03\x04 xbrl"
+)
+
+func newDocumentsTestService(t *testing.T, handler http.HandlerFunc) (*TimelyDisclosureService, *client.MockClient) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mockClient := client.NewMockClient()
+	files := func(docs ...string) TimelyDisclosureFiles {
+		f := TimelyDisclosureFiles{DiscNo: "20250401130100"}
+		for _, doc := range docs {
+			switch doc {
+			case "g":
+				f.Files.PDF = server.URL + "/full.pdf"
+			case "s":
+				f.Files.SummaryPDF = server.URL + "/summary.pdf"
+			case "x":
+				f.Files.XBRL = server.URL + "/xbrl.zip"
+			}
+		}
+		return f
+	}
+	mockClient.SetResponse("GET", "/td/files?discNo=20250401130100&docs=g%2Cs%2Cx", files("g", "s", "x"))
+	mockClient.SetResponse("GET", "/td/files?discNo=20250401130100&docs=g", files("g"))
+	return NewTimelyDisclosureService(mockClient), mockClient
+}
+
+func testDisclosure() TimelyDisclosure {
+	return TimelyDisclosure{DiscNo: "20250401130100", Code: "72030", DiscDate: types.MustParseDate("2025-04-01"), Docs: []string{"g", "s", "x"}}
+}
+
+func serveDocument(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/xbrl.zip":
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = io.WriteString(w, testXBRL)
+	default:
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = io.WriteString(w, testPDF)
+	}
+}
+
+func TestTimelyDisclosureService_DownloadDocuments(t *testing.T) {
+	service, _ := newDocumentsTestService(t, serveDocument)
+	dir := t.TempDir()
+
+	docs, err := service.DownloadDocuments(context.Background(), testDisclosure(), nil, NewDirDocumentStore(dir))
+	if err != nil {
+		t.Fatalf("DownloadDocuments() error = %v", err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("got %d documents, want 3", len(docs))
+	}
+
+	want := map[string]string{
+		"2025-04-01/72030/20250401130100/full.pdf":    testPDF,
+		"2025-04-01/72030/20250401130100/summary.pdf": testPDF,
+		"2025-04-01/72030/20250401130100/xbrl.zip":    testXBRL,
+	}
+	for _, doc := range docs {
+		content, ok := want[doc.Name]
+		if !ok {
+			t.Errorf("unexpected document %+v", doc)
+			continue
+		}
+		if doc.Size != int64(len(content)) {
+			t.Errorf("%s: Size = %d, want %d", doc.Name, doc.Size, len(content))
+		}
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(doc.Name)))
+		if err != nil || string(got) != content {
+			t.Errorf("%s = (%q, %v), want %q", doc.Name, got, err, content)
+		}
+	}
+}
+
+func TestTimelyDisclosureService_DownloadDocuments_ExpiredURL(t *testing.T) {
+	var calls int64
+	service, mockClient := newDocumentsTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, "<Error><Code>AccessDenied</Code><Message>Request has expired</Message></Error>")
+			return
+		}
+		serveDocument(w, r)
+	})
+
+	docs, err := service.DownloadDocuments(context.Background(), testDisclosure(), []string{"g"}, NewDirDocumentStore(t.TempDir()))
+	if err != nil {
+		t.Fatalf("DownloadDocuments() error = %v", err)
+	}
+	if len(docs) != 1 || docs[0].Size != int64(len(testPDF)) {
+		t.Errorf("docs = %+v", docs)
+	}
+	// 失効したURLは取得し直す
+	if mockClient.RequestCount != 2 || !mockClient.LastSkipCache {
+		t.Errorf("GetDisclosureFiles called %d times, want 2", mockClient.RequestCount)
+	}
+}
+
+func TestTimelyDisclosureService_DownloadDocuments_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"unexpected content type", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, testPDF)
+		}},
+		{"not a pdf", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = io.WriteString(w, "<html>maintenance</html>")
+		}},
+		{"empty", func(w http.ResponseWriter, r *http.Request) {}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newDocumentsTestService(t, tt.handler)
+			dir := t.TempDir()
+			docs, err := service.DownloadDocuments(context.Background(), testDisclosure(), []string{"g"}, NewDirDocumentStore(dir))
+			if err == nil {
+				t.Fatal("DownloadDocuments() expected error")
+			}
+			if len(docs) != 0 {
+				t.Errorf("docs = %+v, want none", docs)
+			}
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 0 {
+				t.Errorf("nothing must be stored, found %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestTimelyDisclosureService_DownloadDocuments_Validation(t *testing.T) {
+	service := NewTimelyDisclosureService(client.NewMockClient())
+	store := NewDirDocumentStore(t.TempDir())
+
+	td := testDisclosure()
+	td.DiscNo = ""
+	if _, err := service.DownloadDocuments(context.Background(), td, nil, store); err == nil {
+		t.Error("expected error for empty discNo")
+	}
+	if _, err := service.DownloadDocuments(context.Background(), testDisclosure(), []string{"pdf"}, store); err == nil {
+		t.Error("expected error for unknown document type")
+	}
+	if _, err := service.DownloadDocuments(context.Background(), testDisclosure(), nil, nil); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
+
+func TestTimelyDisclosure_DocumentPath(t *testing.T) {
+	td := testDisclosure()
+	got, err := td.DocumentPath(TimelyDisclosureDocSummaryPDF)
+	if err != nil || got != "2025-04-01/72030/20250401130100/summary.pdf" {
+		t.Errorf("DocumentPath() = (%q, %v)", got, err)
+	}
+
+	for _, td := range []TimelyDisclosure{
+		{DiscNo: "20250401130100", Code: "72030"},
+		{DiscNo: "20250401130100", Code: "../x", DiscDate: types.MustParseDate("2025-04-01")},
+		{DiscNo: "", Code: "72030", DiscDate: types.MustParseDate("2025-04-01")},
+	} {
+		if _, err := td.DocumentPath(TimelyDisclosureDocFullPDF); err == nil {
+			t.Errorf("DocumentPath(%+v) expected error", td)
+		}
+	}
+}
+
+func TestSizeCheckReader(t *testing.T) {
+	r := &sizeCheckReader{r: strings.NewReader("abc"), want: 4}
+	if _, err := io.ReadAll(r); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	r = &sizeCheckReader{r: strings.NewReader("abc"), want: -1}
+	if b, err := io.ReadAll(r); err != nil || string(b) != "abc" {
+		t.Errorf("ReadAll() = (%q, %v)", b, err)
+	}
+}
@@ -0,0 +1,183 @@
+package jquants
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DiscItem は適時開示の公開項目コード（DiscItems・discItemsパラメータで使用する値）を表す型です。
+type DiscItem string
+
+// String はDiscItemを文字列として返します。
+func (d DiscItem) String() string {
+	return string(d)
+}
+
+// DiscItemCategory は公開項目の分類です。
+type DiscItemCategory string
+
+// 公開項目の分類の定義
+const (
+	DiscItemCategoryEarnings         DiscItemCategory = "earnings"          // 決算情報（決算短信など）
+	DiscItemCategoryForecastRevision DiscItemCategory = "forecast_revision" // 業績予想・配当予想の修正
+	DiscItemCategoryDividend         DiscItemCategory = "dividend"          // 剰余金の配当
+	DiscItemCategoryShareBuyback     DiscItemCategory = "share_buyback"     // 自己株式の取得
+	DiscItemCategoryTenderOffer      DiscItemCategory = "tender_offer"      // 公開買付け
+	DiscItemCategoryOther            DiscItemCategory = "other"             // その他
+)
+
+// 公開項目コードの定義
+// 公式ドキュメントのサンプルで用途を確認できたコードのみを定義しています。
+// その他のコードは公式の公開項目コード一覧を参照し、DiscItemCatalogに追加して使用してください。
+const (
+	DiscItemEarningsReport DiscItem = "11101" // 決算短信
+)
+
+// DiscItemInfo は公開項目コードの名称と分類です。
+type DiscItemInfo struct {
+	Item     DiscItem         // 公開項目コード
+	NameJA   string           // 名称（日本語）
+	NameEN   string           // 名称（英語）
+	Category DiscItemCategory // 分類
+}
+
+// DiscItemCatalog は公開項目コードから名称と分類への対応です。
+// DefaultDiscItemCatalogで組み込みのカタログを複製し、必要なコードを追加して使用します。
+type DiscItemCatalog map[DiscItem]DiscItemInfo
+
+// defaultDiscItemCatalog は組み込みのカタログです。変更しないでください。
+var defaultDiscItemCatalog = DiscItemCatalog{
+	DiscItemEarningsReport: {Item: DiscItemEarningsReport, NameJA: "決算短信", NameEN: "Earnings report", Category: DiscItemCategoryEarnings},
+}
+
+// DefaultDiscItemCatalog は組み込みのカタログの複製を返します。
+func DefaultDiscItemCatalog() DiscItemCatalog {
+	return maps.Clone(defaultDiscItemCatalog)
+}
+
+// Add は公開項目コードをカタログに追加します。登録済みのコードは上書きします。
+func (c DiscItemCatalog) Add(info DiscItemInfo) {
+	c[info.Item] = info
+}
+
+// Lookup は公開項目コードの名称と分類を返します。カタログにない場合はfalseを返します。
+func (c DiscItemCatalog) Lookup(item DiscItem) (DiscItemInfo, bool) {
+	info, ok := c[item]
+	return info, ok
+}
+
+// ItemsOf は指定した分類に属する公開項目コードを昇順で返します。
+func (c DiscItemCatalog) ItemsOf(category DiscItemCategory) []DiscItem {
+	var items []DiscItem
+	for item, info := range c {
+		if info.Category == category {
+			items = append(items, item)
+		}
+	}
+	slices.Sort(items)
+	return items
+}
+
+// HasCategory は開示が指定した分類に該当するかを判定します。
+//
+// 開示の公開項目コードのいずれかがカタログにある場合は、コードの分類だけで判定します
+// （DiscItemCategoryOtherに登録したコードは、どの分類にも該当しないことを表します）。
+// カタログにあるコードがない場合に限り、開示タイトルに分類ごとの定型の語句
+// （「決算短信」「予想の修正」「剰余金の配当」「自己株式の取得」「公開買付」）を含むかで判定します。
+// ただし訂正の開示（タイトルに「訂正」を含むもの）は、訂正の対象の開示と区別するため該当とみなしません。
+func (c DiscItemCatalog) HasCategory(td *TimelyDisclosure, category DiscItemCategory) bool {
+	known := false
+	for _, item := range td.DiscItems {
+		info, ok := c[DiscItem(item)]
+		if !ok {
+			continue
+		}
+		if info.Category == category {
+			return true
+		}
+		known = true
+	}
+	if known || strings.Contains(td.Title, discItemCorrectionKeyword) {
+		return false
+	}
+	for _, keyword := range discItemTitleKeywords[category] {
+		if strings.Contains(td.Title, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupDiscItem は組み込みのカタログから公開項目コードの名称と分類を返します。
+// カタログにない場合はfalseを返します。
+func LookupDiscItem(item DiscItem) (DiscItemInfo, bool) {
+	return defaultDiscItemCatalog.Lookup(item)
+}
+
+// DiscItemsOf は組み込みのカタログで指定した分類に属する公開項目コードを昇順で返します。
+func DiscItemsOf(category DiscItemCategory) []DiscItem {
+	return defaultDiscItemCatalog.ItemsOf(category)
+}
+
+// JoinDiscItems は公開項目コードをTimelyDisclosureParams.DiscItemsに指定する形式（カンマ区切り）に変換します。
+// APIのdiscItemsはAND条件のため、指定したすべての項目を含む開示に絞り込まれます。
+func JoinDiscItems(items ...DiscItem) string {
+	s := make([]string, len(items))
+	for i, item := range items {
+		s[i] = string(item)
+	}
+	return strings.Join(s, ",")
+}
+
+// discItemCorrectionKeyword は訂正の開示のタイトルに含まれる語句です（「（訂正）…」「「…」の一部訂正について」など）。
+const discItemCorrectionKeyword = "訂正"
+
+// discItemTitleKeywords はカタログにあるコードを持たない開示を判定するため、分類ごとに開示タイトルに含まれる語句です。
+var discItemTitleKeywords = map[DiscItemCategory][]string{
+	DiscItemCategoryEarnings:         {"決算短信"},
+	DiscItemCategoryForecastRevision: {"予想の修正", "予想修正"},
+	DiscItemCategoryDividend:         {"剰余金の配当"},
+	DiscItemCategoryShareBuyback:     {"自己株式の取得", "自己株式取得"},
+	DiscItemCategoryTenderOffer:      {"公開買付"},
+}
+
+// Items は開示の公開項目コードをDiscItemとして返します。
+func (td *TimelyDisclosure) Items() []DiscItem {
+	items := make([]DiscItem, len(td.DiscItems))
+	for i, item := range td.DiscItems {
+		items[i] = DiscItem(item)
+	}
+	return items
+}
+
+// HasCategory は組み込みのカタログで開示が指定した分類に該当するかを判定します。
+// 判定の方法はDiscItemCatalog.HasCategoryと同じです。
+func (td *TimelyDisclosure) HasCategory(category DiscItemCategory) bool {
+	return defaultDiscItemCatalog.HasCategory(td, category)
+}
+
+// IsEarningsReport は決算短信の開示かを判定します。
+func (td *TimelyDisclosure) IsEarningsReport() bool {
+	return td.HasCategory(DiscItemCategoryEarnings)
+}
+
+// IsForecastRevision は業績予想・配当予想の修正の開示かを判定します。
+func (td *TimelyDisclosure) IsForecastRevision() bool {
+	return td.HasCategory(DiscItemCategoryForecastRevision)
+}
+
+// IsDividend は剰余金の配当に関する開示かを判定します。
+func (td *TimelyDisclosure) IsDividend() bool {
+	return td.HasCategory(DiscItemCategoryDividend)
+}
+
+// IsShareBuyback は自己株式の取得に関する開示かを判定します。
+func (td *TimelyDisclosure) IsShareBuyback() bool {
+	return td.HasCategory(DiscItemCategoryShareBuyback)
+}
+
+// IsTenderOffer は公開買付けに関する開示かを判定します。
+func (td *TimelyDisclosure) IsTenderOffer() bool {
+	return td.HasCategory(DiscItemCategoryTenderOffer)
+}
@@ -0,0 +1,136 @@
+package jquants
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func TestDiscItemCatalog(t *testing.T) {
+	info, ok := LookupDiscItem("11101")
+	if !ok || info.Category != DiscItemCategoryEarnings || info.NameJA != "決算短信" {
+		t.Errorf("LookupDiscItem(11101) = %+v, %v", info, ok)
+	}
+	if _, ok := LookupDiscItem("11102"); ok {
+		t.Error("LookupDiscItem(11102) should not be registered")
+	}
+	if got := DiscItemsOf(DiscItemCategoryEarnings); !slices.Equal(got, []DiscItem{DiscItemEarningsReport}) {
+		t.Errorf("DiscItemsOf(earnings) = %v", got)
+	}
+
+	// 追加したコードは複製したカタログだけに反映される
+	catalog := DefaultDiscItemCatalog()
+	catalog.Add(DiscItemInfo{Item: "99901", NameJA: "テスト", NameEN: "Test", Category: DiscItemCategoryShareBuyback})
+	if got := catalog.ItemsOf(DiscItemCategoryShareBuyback); !slices.Equal(got, []DiscItem{"99901"}) {
+		t.Errorf("ItemsOf(share_buyback) = %v", got)
+	}
+	if _, ok := LookupDiscItem("99901"); ok {
+		t.Error("Add should not modify the default catalog")
+	}
+	td := TimelyDisclosure{Title: "お知らせ", DiscItems: []string{"99901"}}
+	if !catalog.HasCategory(&td, DiscItemCategoryShareBuyback) || td.IsShareBuyback() {
+		t.Errorf("catalog.HasCategory = %v, IsShareBuyback = %v",
+			catalog.HasCategory(&td, DiscItemCategoryShareBuyback), td.IsShareBuyback())
+	}
+}
+
+func TestDiscItemCatalog_HasCategory(t *testing.T) {
+	categories := []DiscItemCategory{
+		DiscItemCategoryEarnings,
+		DiscItemCategoryForecastRevision,
+		DiscItemCategoryDividend,
+		DiscItemCategoryShareBuyback,
+		DiscItemCategoryTenderOffer,
+		DiscItemCategoryOther,
+	}
+	catalog := DefaultDiscItemCatalog()
+	for i, category := range categories[1:] {
+		catalog.Add(DiscItemInfo{Item: DiscItem(fmt.Sprintf("9990%d", i+1)), Category: category})
+	}
+	for _, category := range categories {
+		items := catalog.ItemsOf(category)
+		if len(items) != 1 {
+			t.Fatalf("ItemsOf(%s) = %v, want 1 item", category, items)
+		}
+		td := TimelyDisclosure{Title: "お知らせ", DiscItems: []string{string(items[0])}}
+		for _, other := range categories {
+			if got := catalog.HasCategory(&td, other); got != (other == category) {
+				t.Errorf("code %s: HasCategory(%s) = %v", items[0], other, got)
+			}
+		}
+	}
+}
+
+func TestDiscItemCatalog_HasCategory_Other(t *testing.T) {
+	catalog := DefaultDiscItemCatalog()
+	catalog.Add(DiscItemInfo{Item: "99901", NameJA: "テスト", NameEN: "Test", Category: DiscItemCategoryOther})
+
+	// その他に登録したコードを持つ開示は、タイトルの語句があってもどの分類にも該当しない
+	td := TimelyDisclosure{Title: "自己株式の取得に関するお知らせ", DiscItems: []string{"99901"}}
+	if catalog.HasCategory(&td, DiscItemCategoryShareBuyback) || !catalog.HasCategory(&td, DiscItemCategoryOther) {
+		t.Errorf("HasCategory(share_buyback) = %v, HasCategory(other) = %v",
+			catalog.HasCategory(&td, DiscItemCategoryShareBuyback), catalog.HasCategory(&td, DiscItemCategoryOther))
+	}
+	// 組み込みのカタログでは未登録のコードのためタイトルで判定する
+	if !td.IsShareBuyback() {
+		t.Error("IsShareBuyback() should fall back to the title for unknown codes")
+	}
+}
+
+func TestTimelyDisclosure_Classification(t *testing.T) {
+	tests := []struct {
+		name                                            string
+		td                                              TimelyDisclosure
+		earnings, revision, dividend, buyback, takeover bool
+	}{
+		{"earnings by code", TimelyDisclosure{Title: "2025年3月期 決算説明", DiscItems: []string{"11101"}}, true, false, false, false, false},
+		{"earnings by title", TimelyDisclosure{Title: "2025年3月期 第1四半期決算短信〔日本基準〕（連結）"}, true, false, false, false, false},
+		{"forecast revision", TimelyDisclosure{Title: "業績予想及び配当予想の修正に関するお知らせ"}, false, true, false, false, false},
+		{"dividend", TimelyDisclosure{Title: "剰余金の配当（増配）に関するお知らせ"}, false, false, true, false, false},
+		{"buyback", TimelyDisclosure{Title: "自己株式の取得状況に関するお知らせ"}, false, false, false, true, false},
+		{"buyback via tender offer", TimelyDisclosure{Title: "自己株式の公開買付けの結果及び自己株式の取得終了に関するお知らせ"}, false, false, false, true, true},
+		{"tender offer", TimelyDisclosure{Title: "当社株式に対する公開買付けに関する意見表明のお知らせ"}, false, false, false, false, true},
+		{"other", TimelyDisclosure{Title: "代表取締役の異動に関するお知らせ", DiscItems: []string{"11102"}}, false, false, false, false, false},
+		// カタログにあるコードを持つ開示は、タイトルによらずコードの分類で判定する
+		{"code takes precedence over title", TimelyDisclosure{Title: "決算短信及び自己株式の取得に関するお知らせ", DiscItems: []string{"11101"}}, true, false, false, false, false},
+		// 訂正の開示は訂正の対象と同じ分類とみなさない
+		{"earnings correction", TimelyDisclosure{Title: "「2025年3月期 決算短信〔日本基準〕（連結）」の一部訂正について"}, false, false, false, false, false},
+		{"forecast revision correction", TimelyDisclosure{Title: "（訂正）業績予想の修正に関するお知らせ"}, false, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.td.IsEarningsReport(); got != tt.earnings {
+				t.Errorf("IsEarningsReport() = %v, want %v", got, tt.earnings)
+			}
+			if got := tt.td.IsForecastRevision(); got != tt.revision {
+				t.Errorf("IsForecastRevision() = %v, want %v", got, tt.revision)
+			}
+			if got := tt.td.IsDividend(); got != tt.dividend {
+				t.Errorf("IsDividend() = %v, want %v", got, tt.dividend)
+			}
+			if got := tt.td.IsShareBuyback(); got != tt.buyback {
+				t.Errorf("IsShareBuyback() = %v, want %v", got, tt.buyback)
+			}
+			if got := tt.td.IsTenderOffer(); got != tt.takeover {
+				t.Errorf("IsTenderOffer() = %v, want %v", got, tt.takeover)
+			}
+		})
+	}
+}
+
+func TestJoinDiscItems(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/td/list?date=20250401&discItems=11101%2C11102", TimelyDisclosureResponse{})
+	service := NewTimelyDisclosureService(mockClient)
+
+	params := TimelyDisclosureParams{Date: types.MustParseDate("20250401"), DiscItems: JoinDiscItems(DiscItemEarningsReport, "11102")}
+	if _, err := service.GetDisclosures(t.Context(), params); err != nil {
+		t.Fatalf("GetDisclosures() error = %v", err)
+	}
+	if got := JoinDiscItems(); got != "" {
+		t.Errorf("JoinDiscItems() = %q, want empty", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
@@ -19,16 +20,16 @@ func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
 		{
 			name: "with date only",
 			params: TimelyDisclosureParams{
-				Date: "20250401",
+				Date: types.MustParseDate("20250401"),
 			},
 			wantPath: "/td/list?date=20250401",
 		},
 		{
 			name: "with hyphenated date",
 			params: TimelyDisclosureParams{
-				Date: "2025-04-01",
+				Date: types.MustParseDate("2025-04-01"),
 			},
-			wantPath: "/td/list?date=2025-04-01",
+			wantPath: "/td/list?date=20250401",
 		},
 		{
 			name: "with code only",
@@ -41,23 +42,23 @@ func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
 			name: "with code and date range",
 			params: TimelyDisclosureParams{
 				Code: "86970",
-				From: "20250301",
-				To:   "20250401",
+				From: types.MustParseDate("20250301"),
+				To:   types.MustParseDate("20250401"),
 			},
 			wantPath: "/td/list?code=86970&from=20250301&to=20250401",
 		},
 		{
 			name: "with date and discItems",
 			params: TimelyDisclosureParams{
-				Date:      "20250401",
+				Date:      types.MustParseDate("20250401"),
 				DiscItems: "11101,11102",
 			},
-			wantPath: "/td/list?date=20250401&discItems=11101,11102",
+			wantPath: "/td/list?date=20250401&discItems=11101%2C11102",
 		},
 		{
 			name: "with date and cursor",
 			params: TimelyDisclosureParams{
-				Date:   "20250401",
+				Date:   types.MustParseDate("20250401"),
 				Cursor: "cursor123",
 			},
 			wantPath: "/td/list?date=20250401&cursor=cursor123",
@@ -65,7 +66,7 @@ func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
 		{
 			name: "with date and pagination key",
 			params: TimelyDisclosureParams{
-				Date:          "20250401",
+				Date:          types.MustParseDate("20250401"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/td/list?date=20250401&pagination_key=key123",
@@ -79,7 +80,7 @@ func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
 		{
 			name: "with cursor and pagination key",
 			params: TimelyDisclosureParams{
-				Date:          "20250401",
+				Date:          types.MustParseDate("20250401"),
 				Cursor:        "cursor123",
 				PaginationKey: "key123",
 			},
@@ -101,7 +102,7 @@ func TestTimelyDisclosureService_GetDisclosures(t *testing.T) {
 						DiscNo:     "20250401130100",
 						Code:       "86970",
 						Name:       "日本取引所グループ",
-						DiscDate:   "2025-04-01",
+						DiscDate:   types.MustParseDate("2025-04-01"),
 						DiscTime:   "08:00",
 						Title:      "2025年3月期 決算短信〔日本基準〕（連結）",
 						DiscStatus: "",
@@ -169,7 +170,7 @@ func TestTimelyDisclosureService_GetDisclosures_CursorConflictsWithPaginationKey
 
 	// Execute with cursor and pagination_key
 	_, err := service.GetDisclosures(context.Background(), TimelyDisclosureParams{
-		Date:          "20250401",
+		Date:          types.MustParseDate("20250401"),
 		Cursor:        "cursor123",
 		PaginationKey: "key123",
 	})
@@ -278,13 +279,13 @@ func TestTimelyDisclosureService_GetDisclosuresByDate(t *testing.T) {
 			{
 				DiscNo:   "20250401130100",
 				Code:     "86970",
-				DiscDate: "2025-04-01",
+				DiscDate: types.MustParseDate("2025-04-01"),
 				RevNo:    1,
 			},
 			{
 				DiscNo:   "20250401130200",
 				Code:     "13010",
-				DiscDate: "2025-04-01",
+				DiscDate: types.MustParseDate("2025-04-01"),
 				RevNo:    1,
 			},
 		},
@@ -297,7 +298,7 @@ func TestTimelyDisclosureService_GetDisclosuresByDate(t *testing.T) {
 			{
 				DiscNo:   "20250401130300",
 				Code:     "72030",
-				DiscDate: "2025-04-01",
+				DiscDate: types.MustParseDate("2025-04-01"),
 				RevNo:    1,
 			},
 		},
@@ -308,7 +309,7 @@ func TestTimelyDisclosureService_GetDisclosuresByDate(t *testing.T) {
 	mockClient.SetResponse("GET", "/td/list?date=20250401&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	data, err := service.GetDisclosuresByDate(context.Background(), "20250401")
+	data, err := service.GetDisclosuresByDate(context.Background(), types.MustParseDate("20250401"))
 
 	// Verify
 	if err != nil {
@@ -318,7 +319,7 @@ func TestTimelyDisclosureService_GetDisclosuresByDate(t *testing.T) {
 		t.Errorf("GetDisclosuresByDate() returned %d items, want 3", len(data))
 	}
 	for _, item := range data {
-		if item.DiscDate != "2025-04-01" {
+		if item.DiscDate.String() != "2025-04-01" {
 			t.Errorf("GetDisclosuresByDate() returned date %v, want 2025-04-01", item.DiscDate)
 		}
 	}
@@ -335,7 +336,7 @@ func TestTimelyDisclosureService_GetDisclosuresByCode(t *testing.T) {
 			{
 				DiscNo:   "20250401130100",
 				Code:     "86970",
-				DiscDate: "2025-04-01",
+				DiscDate: types.MustParseDate("2025-04-01"),
 				RevNo:    1,
 			},
 		},
@@ -348,7 +349,7 @@ func TestTimelyDisclosureService_GetDisclosuresByCode(t *testing.T) {
 			{
 				DiscNo:   "20250301120100",
 				Code:     "86970",
-				DiscDate: "2025-03-01",
+				DiscDate: types.MustParseDate("2025-03-01"),
 				RevNo:    1,
 			},
 		},
@@ -395,7 +396,7 @@ func TestTimelyDisclosureService_GetDisclosureFiles(t *testing.T) {
 				DiscNo: "20250401130100",
 				Docs:   "g,s",
 			},
-			wantPath: "/td/files?discNo=20250401130100&docs=g,s",
+			wantPath: "/td/files?discNo=20250401130100&docs=g%2Cs",
 		},
 		{
 			name: "with docs constant",
@@ -720,11 +721,11 @@ func TestTimelyDisclosureService_SignedURLMethods_SkipCache(t *testing.T) {
 func TestTimelyDisclosureService_GetDisclosures_CursorSkipsCache(t *testing.T) {
 	mockClient := client.NewMockClient()
 	service := NewTimelyDisclosureService(mockClient)
-	mockClient.SetResponse("GET", "/td/list?date=2025-04-01&cursor=cur123", TimelyDisclosureResponse{})
-	mockClient.SetResponse("GET", "/td/list?date=2025-04-01", TimelyDisclosureResponse{})
+	mockClient.SetResponse("GET", "/td/list?date=20250401&cursor=cur123", TimelyDisclosureResponse{})
+	mockClient.SetResponse("GET", "/td/list?date=20250401", TimelyDisclosureResponse{})
 
 	// cursorによるポーリングはキャッシュをバイパスすること
-	if _, err := service.GetDisclosures(context.Background(), TimelyDisclosureParams{Date: "2025-04-01", Cursor: "cur123"}); err != nil {
+	if _, err := service.GetDisclosures(context.Background(), TimelyDisclosureParams{Date: types.MustParseDate("2025-04-01"), Cursor: "cur123"}); err != nil {
 		t.Fatalf("GetDisclosures() error = %v", err)
 	}
 	if !mockClient.LastSkipCache {
@@ -732,10 +733,35 @@ func TestTimelyDisclosureService_GetDisclosures_CursorSkipsCache(t *testing.T) {
 	}
 
 	// cursorなしは通常どおりキャッシュ対象
-	if _, err := service.GetDisclosures(context.Background(), TimelyDisclosureParams{Date: "2025-04-01"}); err != nil {
+	if _, err := service.GetDisclosures(context.Background(), TimelyDisclosureParams{Date: types.MustParseDate("2025-04-01")}); err != nil {
 		t.Fatalf("GetDisclosures() error = %v", err)
 	}
 	if mockClient.LastSkipCache {
 		t.Error("GetDisclosures() without cursor should use the session cache")
 	}
 }
+
+func TestTimelyDisclosureService_AllDisclosures_CursorPagination(t *testing.T) {
+	mockClient := client.NewMockClient()
+	service := NewTimelyDisclosureService(mockClient)
+	mockClient.SetResponse("GET", "/td/list?date=20250401&cursor=cur123", TimelyDisclosureResponse{
+		Data:          []TimelyDisclosure{{DiscNo: "A"}},
+		PaginationKey: "p1",
+	})
+	mockClient.SetResponse("GET", "/td/list?date=20250401&pagination_key=p1", TimelyDisclosureResponse{
+		Data:   []TimelyDisclosure{{DiscNo: "B"}},
+		Cursor: "cur456",
+	})
+
+	// 2ページ目はcursorを外してpagination_keyのみで取得すること
+	got, err := collectAll(service.AllDisclosures(context.Background(), TimelyDisclosureParams{Date: types.MustParseDate("2025-04-01"), Cursor: "cur123"}))
+	if err != nil {
+		t.Fatalf("AllDisclosures() error = %v", err)
+	}
+	if len(got) != 2 || got[0].DiscNo != "A" || got[1].DiscNo != "B" {
+		t.Errorf("AllDisclosures() = %+v, want [A B]", got)
+	}
+	if mockClient.LastPath != "/td/list?date=20250401&pagination_key=p1" {
+		t.Errorf("last path = %q", mockClient.LastPath)
+	}
+}
@@ -0,0 +1,199 @@
+package jquants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+// デフォルトのバックオフの上限
+const defaultWatchMaxBackoff = 5 * time.Minute
+
+// WatchOption はWatchの動作を設定するオプションです。
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	store      CursorStore
+	discItems  string
+	maxBackoff time.Duration
+	now        func() time.Time
+}
+
+// WithWatchCursorStore はカーソルの保存先を設定します。
+// 永続化するストア（FileCursorStoreなど）を指定すると、再起動後も前回の続きから取得します。
+// 指定しない場合はメモリに保持します。
+func WithWatchCursorStore(store CursorStore) WatchOption {
+	return func(c *watchConfig) {
+		c.store = store
+	}
+}
+
+// WithWatchDiscItems は公開項目コードで絞り込みます（カンマ区切りで複数指定可能、AND条件）。
+func WithWatchDiscItems(discItems string) WatchOption {
+	return func(c *watchConfig) {
+		c.discItems = discItems
+	}
+}
+
+// WithWatchMaxBackoff はレートリミット超過やエラー時に待機する時間の上限を設定します（デフォルト: 5分）。
+func WithWatchMaxBackoff(d time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		if d > 0 {
+			c.maxBackoff = d
+		}
+	}
+}
+
+// Watch は当日（日本時間）の適時開示をinterval間隔でポーリングし、新しい開示を1件ずつ返すイテレータです。
+// ctxをキャンセルするかbreakするまで終了しません。
+//
+// 差分取得用カーソル（cursor）を使用し、カーソルは開示日ごとにCursorStoreへ保存します
+// （キーは "td/list/YYYY-MM-DD"）。カーソルがない場合はその日の全件を取得するため、
+// 起動直後にはその日のそれまでの開示も返されます。カーソルは返した開示を処理した後に保存するため、
+// 処理中に停止した場合は再起動後に同じ開示が再度返されることがあります。
+//
+// 日本時間の0時を過ぎると、前日分を最後に1度取得してから翌日の開示に切り替えます。
+// 前日分の取得に失敗した場合は、レートリミット超過（429）を除きエラーを返して翌日に切り替えます。
+// 同じ開示（DiscNoとRevNoが同じもの）は1日の中で重複して返しません。
+//
+// レートリミット超過（429）の場合はRetry-Afterまたは指数バックオフで待機して再試行します。
+// その他のエラーはエラーとして返し、呼び出し側が続行した場合は同様に待機して再試行します。
+func (s *TimelyDisclosureService) Watch(ctx context.Context, interval time.Duration, opts ...WatchOption) iter.Seq2[TimelyDisclosure, error] {
+	cfg := watchConfig{maxBackoff: defaultWatchMaxBackoff, now: time.Now}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.store == nil {
+		cfg.store = NewMemoryCursorStore()
+	}
+
+	return func(yield func(TimelyDisclosure, error) bool) {
+		w := &disclosureWatcher{service: s, cfg: &cfg}
+		failures := 0
+		for {
+			ok, err := w.poll(ctx, yield)
+			if !ok {
+				return
+			}
+
+			wait := interval
+			switch {
+			case err == nil:
+				failures = 0
+			case ctx.Err() != nil:
+				return
+			default:
+				if !client.IsRateLimitExceeded(err) && !yield(TimelyDisclosure{}, err) {
+					return
+				}
+				failures++
+				wait = watchBackoff(interval, failures, cfg.maxBackoff, err)
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// watchBackoff は連続failures回目の失敗後の待機時間を返します。
+// Retry-Afterが指定されていればそれに従い、なければintervalから倍々に伸ばします。
+func watchBackoff(interval time.Duration, failures int, maxBackoff time.Duration, err error) time.Duration {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, maxBackoff)
+	}
+	d := interval
+	for i := 0; i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// disclosureWatcher はWatchの1日分の状態（開示日、カーソル、返却済みの開示）を保持します。
+type disclosureWatcher struct {
+	service *TimelyDisclosureService
+	cfg     *watchConfig
+
+	date   types.Date
+	cursor string
+	seen   map[string]struct{}
+}
+
+// poll は当日の新しい開示を取得して返します。日付が変わっていれば前日分を取得してから切り替えます。
+// 呼び出し側がbreakした場合はokにfalseを返します。
+func (w *disclosureWatcher) poll(ctx context.Context, yield func(TimelyDisclosure, error) bool) (ok bool, err error) {
+	today := types.DateOf(w.cfg.now())
+	if today != w.date {
+		if !w.date.IsZero() {
+			// 日付が変わる直前の開示を取りこぼさないよう、前日分を最後に取得する
+			ok, err := w.fetch(ctx, yield)
+			if !ok {
+				return false, nil
+			}
+			if err != nil {
+				if client.IsRateLimitExceeded(err) || ctx.Err() != nil {
+					return true, err
+				}
+				// 前日分の取得に失敗し続けても当日の監視を止めないよう、エラーを返して当日に切り替える
+				if !yield(TimelyDisclosure{}, fmt.Errorf("failed to fetch disclosures for %s: %w", w.date, err)) {
+					return false, nil
+				}
+			}
+		}
+		cursor, err := w.cfg.store.LoadCursor(ctx, w.key(today))
+		if err != nil {
+			return true, err
+		}
+		w.date, w.cursor, w.seen = today, cursor, map[string]struct{}{}
+	}
+	return w.fetch(ctx, yield)
+}
+
+// fetch はw.dateの開示をカーソル以降について取得し、未返却のものを返してカーソルを保存します。
+func (w *disclosureWatcher) fetch(ctx context.Context, yield func(TimelyDisclosure, error) bool) (ok bool, err error) {
+	params := TimelyDisclosureParams{Date: w.date, DiscItems: w.cfg.discItems, Cursor: w.cursor}
+	var items []TimelyDisclosure
+	cursor := w.cursor
+	// 同じパスを繰り返し取得するため、キャッシュを経由せずに最新の一覧を取得する
+	for td, err := range w.service.allDisclosures(ctx, params, true, &cursor) {
+		if err != nil {
+			return true, err
+		}
+		items = append(items, td)
+	}
+
+	for _, td := range items {
+		id := td.DiscNo + "/" + strconv.Itoa(td.RevNo)
+		if _, dup := w.seen[id]; dup {
+			continue
+		}
+		w.seen[id] = struct{}{}
+		if !yield(td, nil) {
+			return false, nil
+		}
+	}
+
+	if cursor != w.cursor {
+		if err := w.cfg.store.SaveCursor(ctx, w.key(w.date), cursor); err != nil {
+			return true, err
+		}
+		w.cursor = cursor
+	}
+	return true, nil
+}
+
+func (w *disclosureWatcher) key(d types.Date) string {
+	return "td/list/" + d.String()
+}
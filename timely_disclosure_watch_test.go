@@ -0,0 +1,311 @@
+package jquants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+// scriptClient はリクエストごとに応答を返す関数でHTTPClientを実装します。
+type scriptClient struct {
+	mu    sync.Mutex
+	paths []string
+	fn    func(path string) (interface{}, error)
+}
+
+func (c *scriptClient) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.paths = append(c.paths, path)
+	c.mu.Unlock()
+	resp, err := c.fn(path)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func disclosure(discNo string) TimelyDisclosure {
+	return TimelyDisclosure{DiscNo: discNo, Code: "72030", RevNo: 1}
+}
+
+func collectWatch(t *testing.T, seq func(func(TimelyDisclosure, error) bool), n int) []string {
+	t.Helper()
+	var got []string
+	for td, err := range seq {
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		got = append(got, td.DiscNo)
+		if len(got) == n {
+			break
+		}
+	}
+	return got
+}
+
+func TestTimelyDisclosureService_Watch(t *testing.T) {
+	c := &scriptClient{fn: func(path string) (interface{}, error) {
+		switch {
+		case strings.Contains(path, "cursor=c1"):
+			// 既に返した開示は重複して返さない
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("B"), disclosure("C")}, Cursor: "c2"}, nil
+		case strings.Contains(path, "pagination_key=p1"):
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("B")}, Cursor: "c1"}, nil
+		default:
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}, PaginationKey: "p1"}, nil
+		}
+	}}
+	store := NewMemoryCursorStore()
+	service := NewTimelyDisclosureService(c)
+	now := func() time.Time { return time.Date(2025, 1, 6, 15, 0, 0, 0, types.JST) }
+
+	seq := service.Watch(context.Background(), time.Millisecond, WithWatchCursorStore(store), func(c *watchConfig) { c.now = now })
+	got := collectWatch(t, seq, 3)
+	if strings.Join(got, ",") != "A,B,C" {
+		t.Errorf("got %v, want [A B C]", got)
+	}
+
+	want := []string{
+		"/td/list?date=20250106",
+		"/td/list?date=20250106&pagination_key=p1",
+		"/td/list?date=20250106&cursor=c1",
+	}
+	if strings.Join(c.paths, " ") != strings.Join(want, " ") {
+		t.Errorf("paths = %v, want %v", c.paths, want)
+	}
+	// Cの処理中にbreakしたため、保存済みのカーソルはc1のまま
+	if cursor, _ := store.LoadCursor(context.Background(), "td/list/2025-01-06"); cursor != "c1" {
+		t.Errorf("saved cursor = %q, want c1", cursor)
+	}
+
+	// 保存済みのカーソルから再開する
+	c.paths = nil
+	got = collectWatch(t, service.Watch(context.Background(), time.Millisecond, WithWatchCursorStore(store), func(c *watchConfig) { c.now = now }), 2)
+	if strings.Join(got, ",") != "B,C" {
+		t.Errorf("resumed: got %v, want [B C]", got)
+	}
+	if c.paths[0] != "/td/list?date=20250106&cursor=c1" {
+		t.Errorf("resumed first path = %q", c.paths[0])
+	}
+}
+
+func TestTimelyDisclosureService_Watch_DateRollover(t *testing.T) {
+	var mu sync.Mutex
+	clock := time.Date(2025, 1, 6, 23, 59, 0, 0, types.JST)
+	c := &scriptClient{fn: func(path string) (interface{}, error) {
+		switch path {
+		case "/td/list?date=20250106":
+			mu.Lock()
+			clock = clock.Add(2 * time.Minute) // 次のポーリングまでに日付が変わる
+			mu.Unlock()
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}, Cursor: "d1"}, nil
+		case "/td/list?date=20250106&cursor=d1":
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A"), disclosure("A2")}, Cursor: "d1-2"}, nil
+		case "/td/list?date=20250107":
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("B")}, Cursor: "d2"}, nil
+		}
+		return TimelyDisclosureResponse{}, nil
+	}}
+	store := NewMemoryCursorStore()
+	service := NewTimelyDisclosureService(c)
+	seq := service.Watch(context.Background(), time.Millisecond, WithWatchCursorStore(store), func(c *watchConfig) {
+		c.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return clock
+		}
+	})
+
+	got := collectWatch(t, seq, 3)
+	if strings.Join(got, ",") != "A,A2,B" {
+		t.Errorf("got %v, want [A A2 B]", got)
+	}
+	if cursor, _ := store.LoadCursor(context.Background(), "td/list/2025-01-06"); cursor != "d1-2" {
+		t.Errorf("previous day cursor = %q, want d1-2", cursor)
+	}
+}
+
+func TestTimelyDisclosureService_Watch_DateRolloverError(t *testing.T) {
+	var mu sync.Mutex
+	clock := time.Date(2025, 1, 6, 23, 59, 0, 0, types.JST)
+	c := &scriptClient{fn: func(path string) (interface{}, error) {
+		switch path {
+		case "/td/list?date=20250106":
+			mu.Lock()
+			clock = clock.Add(2 * time.Minute) // 次のポーリングまでに日付が変わる
+			mu.Unlock()
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}, Cursor: "d1"}, nil
+		case "/td/list?date=20250106&cursor=d1":
+			// 前日分の最後の取得は失敗し続ける
+			return nil, &client.APIError{StatusCode: http.StatusInternalServerError}
+		case "/td/list?date=20250107":
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("B")}, Cursor: "d2"}, nil
+		}
+		return TimelyDisclosureResponse{}, nil
+	}}
+	service := NewTimelyDisclosureService(c)
+	seq := service.Watch(context.Background(), time.Millisecond, func(c *watchConfig) {
+		c.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return clock
+		}
+	})
+
+	var errs []error
+	var got []string
+	for td, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, td.DiscNo)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if strings.Join(got, ",") != "A,B" {
+		t.Errorf("got %v, want [A B]", got)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "2025-01-06") {
+		t.Errorf("errors = %v, want 1 error for 2025-01-06", errs)
+	}
+}
+
+func TestTimelyDisclosureService_Watch_CachingClient(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	sc := &scriptClient{fn: func(path string) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		// 開示が出るまではcursorが付かないため、同じパスを繰り返し取得する
+		if polls < 2 {
+			return TimelyDisclosureResponse{}, nil
+		}
+		return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}, Cursor: "c1"}, nil
+	}}
+	service := NewTimelyDisclosureService(newCachingClient(sc))
+	now := func(c *watchConfig) {
+		c.now = func() time.Time { return time.Date(2025, 1, 6, 9, 0, 0, 0, types.JST) }
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got := collectWatch(t, service.Watch(ctx, time.Millisecond, now), 1)
+	if strings.Join(got, ",") != "A" {
+		t.Errorf("got %v, want [A]", got)
+	}
+}
+
+func TestTimelyDisclosureService_Watch_Errors(t *testing.T) {
+	now := func(c *watchConfig) {
+		c.now = func() time.Time { return time.Date(2025, 1, 6, 15, 0, 0, 0, types.JST) }
+	}
+
+	t.Run("rate limit is retried silently", func(t *testing.T) {
+		calls := 0
+		c := &scriptClient{fn: func(path string) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				return nil, &client.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Millisecond}
+			}
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}}, nil
+		}}
+		got := collectWatch(t, NewTimelyDisclosureService(c).Watch(context.Background(), time.Millisecond, now), 1)
+		if len(got) != 1 || calls != 2 {
+			t.Errorf("got %v after %d calls", got, calls)
+		}
+	})
+
+	t.Run("other errors are returned and retried", func(t *testing.T) {
+		calls := 0
+		c := &scriptClient{fn: func(path string) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				return nil, &client.APIError{StatusCode: http.StatusInternalServerError}
+			}
+			return TimelyDisclosureResponse{Data: []TimelyDisclosure{disclosure("A")}}, nil
+		}}
+		var errs int
+		var got []string
+		for td, err := range NewTimelyDisclosureService(c).Watch(context.Background(), time.Millisecond, now, WithWatchMaxBackoff(time.Millisecond)) {
+			if err != nil {
+				errs++
+				continue
+			}
+			got = append(got, td.DiscNo)
+			break
+		}
+		if errs != 1 || len(got) != 1 {
+			t.Errorf("errs = %d, got = %v", errs, got)
+		}
+	})
+
+	t.Run("stops when context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c := &scriptClient{fn: func(path string) (interface{}, error) {
+			cancel()
+			return TimelyDisclosureResponse{}, nil
+		}}
+		for _, err := range NewTimelyDisclosureService(c).Watch(ctx, time.Hour, now) {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("unexpected item or error: %v", err)
+			}
+		}
+	})
+}
+
+func TestWatchBackoff(t *testing.T) {
+	if got := watchBackoff(time.Second, 3, time.Minute, errors.New("boom")); got != 8*time.Second {
+		t.Errorf("backoff = %v, want 8s", got)
+	}
+	if got := watchBackoff(time.Second, 10, time.Minute, errors.New("boom")); got != time.Minute {
+		t.Errorf("backoff = %v, want 1m", got)
+	}
+	retryAfter := &client.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 30 * time.Second}
+	if got := watchBackoff(time.Second, 1, time.Minute, retryAfter); got != 30*time.Second {
+		t.Errorf("backoff = %v, want 30s", got)
+	}
+}
+
+func TestFileCursorStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state", "cursors.json")
+
+	store := NewFileCursorStore(path)
+	if cursor, err := store.LoadCursor(ctx, "td/list/2025-01-06"); err != nil || cursor != "" {
+		t.Fatalf("LoadCursor() = (%q, %v), want empty", cursor, err)
+	}
+	if err := store.SaveCursor(ctx, "td/list/2025-01-06", "c1"); err != nil {
+		t.Fatalf("SaveCursor() error = %v", err)
+	}
+	if err := store.SaveCursor(ctx, "td/list/2025-01-07", "c2"); err != nil {
+		t.Fatalf("SaveCursor() error = %v", err)
+	}
+
+	// 別のインスタンスからも読める
+	reopened := NewFileCursorStore(path)
+	if cursor, err := reopened.LoadCursor(ctx, "td/list/2025-01-06"); err != nil || cursor != "c1" {
+		t.Errorf("LoadCursor() = (%q, %v), want c1", cursor, err)
+	}
+	if cursor, _ := reopened.LoadCursor(ctx, "td/list/2025-01-07"); cursor != "c2" {
+		t.Errorf("LoadCursor() = %q, want c2", cursor)
+	}
+}
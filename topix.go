@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -23,16 +24,16 @@ func NewTOPIXService(c client.HTTPClient) *TOPIXService {
 // TOPIXData はTOPIX指数の四本値データを表します。
 // J-Quants API /indices/bars/daily/topix エンドポイントのレスポンスデータ。
 type TOPIXData struct {
-	Date string  `json:"Date"` // 日付（YYYY-MM-DD形式）
-	O    float64 `json:"O"`    // 始値
-	H    float64 `json:"H"`    // 高値
-	L    float64 `json:"L"`    // 安値
-	C    float64 `json:"C"`    // 終値
+	Date types.Date `json:"Date"` // 日付
+	O    float64    `json:"O"`    // 始値
+	H    float64    `json:"H"`    // 高値
+	L    float64    `json:"L"`    // 安値
+	C    float64    `json:"C"`    // 終値
 }
 
 // RawTOPIXData is used for unmarshaling JSON response with mixed types
 type RawTOPIXData struct {
-	Date string                `json:"Date"`
+	Date types.Date            `json:"Date"`
 	O    types.NullableFloat64 `json:"O"`
 	H    types.NullableFloat64 `json:"H"`
 	L    types.NullableFloat64 `json:"L"`
@@ -78,9 +79,9 @@ func (t *TOPIXResponse) UnmarshalJSON(data []byte) error {
 
 // TOPIXParams はTOPIX指数のリクエストパラメータです。
 type TOPIXParams struct {
-	From          string // 開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	From          types.Date // 開始日
+	To            types.Date // 終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // GetTOPIXData は指定された条件でTOPIX指数四本値データを取得します。
@@ -91,20 +92,15 @@ type TOPIXParams struct {
 func (s *TOPIXService) GetTOPIXData(ctx context.Context, params TOPIXParams) (*TOPIXResponse, error) {
 	path := "/indices/bars/daily/topix"
 
-	query := "?"
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp TOPIXResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -114,62 +110,31 @@ func (s *TOPIXService) GetTOPIXData(ctx context.Context, params TOPIXParams) (*T
 	return &resp, nil
 }
 
-// GetTOPIXByDateRange は指定した期間のTOPIX指数データを取得します。
-// ページネーションを使用して全データを取得します。
-func (s *TOPIXService) GetTOPIXByDateRange(ctx context.Context, from, to string) ([]TOPIXData, error) {
-	var allTOPIX []TOPIXData
-	paginationKey := ""
-
-	for {
-		params := TOPIXParams{
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
+// AllTOPIXData は指定条件のTOPIX四本値を1件ずつ返すイテレータです。
+func (s *TOPIXService) AllTOPIXData(ctx context.Context, params TOPIXParams) iter.Seq2[TOPIXData, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]TOPIXData, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetTOPIXData(ctx, params)
 		if err != nil {
-			return nil, err
-		}
-
-		allTOPIX = append(allTOPIX, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+			return nil, "", err
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allTOPIX, nil
+// GetTOPIXByDateRange は指定した期間のTOPIX指数データを取得します。
+// ページネーションを使用して全データを取得します。
+func (s *TOPIXService) GetTOPIXByDateRange(ctx context.Context, from, to types.Date) ([]TOPIXData, error) {
+	return collectAll(s.AllTOPIXData(ctx, TOPIXParams{
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetAllTOPIXData は全期間のTOPIX指数データを取得します。
 // ページネーションを使用して大量データを分割取得します。
 func (s *TOPIXService) GetAllTOPIXData(ctx context.Context) ([]TOPIXData, error) {
-	var allTOPIX []TOPIXData
-	paginationKey := ""
-
-	for {
-		params := TOPIXParams{
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetTOPIXData(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allTOPIX = append(allTOPIX, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allTOPIX, nil
+	return collectAll(s.AllTOPIXData(ctx, TOPIXParams{}))
 }
 
 // GetLatestTOPIX は最新のTOPIX指数データを取得します。
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestTOPIXService_GetTOPIXData(t *testing.T) {
@@ -17,8 +18,8 @@ func TestTOPIXService_GetTOPIXData(t *testing.T) {
 		{
 			name: "with all parameters",
 			params: TOPIXParams{
-				From:          "20240101",
-				To:            "20240131",
+				From:          types.MustParseDate("20240101"),
+				To:            types.MustParseDate("20240131"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/indices/bars/daily/topix?from=20240101&to=20240131&pagination_key=key123",
@@ -26,15 +27,15 @@ func TestTOPIXService_GetTOPIXData(t *testing.T) {
 		{
 			name: "with date range only",
 			params: TOPIXParams{
-				From: "20240101",
-				To:   "20240131",
+				From: types.MustParseDate("20240101"),
+				To:   types.MustParseDate("20240131"),
 			},
 			wantPath: "/indices/bars/daily/topix?from=20240101&to=20240131",
 		},
 		{
 			name: "with from date only",
 			params: TOPIXParams{
-				From: "20240101",
+				From: types.MustParseDate("20240101"),
 			},
 			wantPath: "/indices/bars/daily/topix?from=20240101",
 		},
@@ -55,7 +56,7 @@ func TestTOPIXService_GetTOPIXData(t *testing.T) {
 			mockResponse := TOPIXResponse{
 				Data: []TOPIXData{
 					{
-						Date: "2022-06-28",
+						Date: types.MustParseDate("2022-06-28"),
 						O:    1885.52,
 						H:    1907.38,
 						L:    1885.32,
@@ -96,12 +97,12 @@ func TestTOPIXService_GetTOPIXByDateRange(t *testing.T) {
 	mockResponse1 := TOPIXResponse{
 		Data: []TOPIXData{
 			{
-				Date: "2024-01-01",
+				Date: types.MustParseDate("2024-01-01"),
 				O:    2400.0,
 				C:    2420.0,
 			},
 			{
-				Date: "2024-01-02",
+				Date: types.MustParseDate("2024-01-02"),
 				O:    2420.0,
 				C:    2435.0,
 			},
@@ -113,7 +114,7 @@ func TestTOPIXService_GetTOPIXByDateRange(t *testing.T) {
 	mockResponse2 := TOPIXResponse{
 		Data: []TOPIXData{
 			{
-				Date: "2024-01-03",
+				Date: types.MustParseDate("2024-01-03"),
 				O:    2435.0,
 				C:    2450.0,
 			},
@@ -125,7 +126,7 @@ func TestTOPIXService_GetTOPIXByDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", "/indices/bars/daily/topix?from=20240101&to=20240103&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	topixData, err := service.GetTOPIXByDateRange(context.Background(), "20240101", "20240103")
+	topixData, err := service.GetTOPIXByDateRange(context.Background(), types.MustParseDate("20240101"), types.MustParseDate("20240103"))
 
 	// Verify
 	if err != nil {
@@ -145,18 +146,18 @@ func TestTOPIXService_GetLatestTOPIX(t *testing.T) {
 	mockResponse := TOPIXResponse{
 		Data: []TOPIXData{
 			{
-				Date: "2024-02-01",
+				Date: types.MustParseDate("2024-02-01"),
 				O:    2480.0,
 				H:    2490.0,
 				L:    2475.0,
 				C:    2485.0,
 			},
 			{
-				Date: "2024-01-31",
+				Date: types.MustParseDate("2024-01-31"),
 				C:    2470.0,
 			},
 			{
-				Date: "2024-01-30",
+				Date: types.MustParseDate("2024-01-30"),
 				C:    2450.0,
 			},
 		},
@@ -175,7 +176,7 @@ func TestTOPIXService_GetLatestTOPIX(t *testing.T) {
 		t.Fatal("GetLatestTOPIX() returned nil")
 		return
 	}
-	if latest.Date != "2024-02-01" {
+	if latest.Date.String() != "2024-02-01" {
 		t.Errorf("GetLatestTOPIX() returned date %v, want 2024-02-01", latest.Date)
 	}
 	if latest.C != 2485.0 {
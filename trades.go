@@ -0,0 +1,402 @@
+package jquants
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/utahta/jquants/types"
+)
+
+// Trade は株式ティックデータ（歩み値）の1約定を表します。
+// 株式ティックデータはAPIでの提供がなく、Bulk API（/equities/trades）のCSVでのみ取得できます。
+// CSVの列はヘッダーの列名（tradeColumns）でフィールドに対応付けます。
+type Trade struct {
+	Date  types.Date `json:"Date"`  // 日付
+	Time  string     `json:"Time"`  // 約定時刻（HH:MM:SS形式。小数点以下の秒を含む場合があります）
+	Code  string     `json:"Code"`  // 銘柄コード
+	Price float64    `json:"Price"` // 約定値段
+	Vo    float64    `json:"Vo"`    // 約定数量
+}
+
+// tradeColumns はティックデータCSVの列名と、その列の値をTradeに設定する関数です。
+// ReadTradesはヘッダーにこれらの列がすべてあることを確認します（列の順序は問わず、その他の列は読み飛ばします）。
+var tradeColumns = map[string]func(t *Trade, v string) error{
+	"Date": func(t *Trade, v string) (err error) {
+		t.Date, err = types.ParseDate(v)
+		return err
+	},
+	"Time": func(t *Trade, v string) error {
+		t.Time = v
+		return nil
+	},
+	"Code": func(t *Trade, v string) error {
+		t.Code = v
+		return nil
+	},
+	"Price": func(t *Trade, v string) (err error) {
+		t.Price, err = strconv.ParseFloat(v, 64)
+		return err
+	},
+	"Vo": func(t *Trade, v string) (err error) {
+		t.Vo, err = strconv.ParseFloat(v, 64)
+		return err
+	},
+}
+
+// tradeTimeLayouts は約定時刻として受け付ける形式です。
+// "15:04:05.999999999" は小数点以下の秒があってもなくても解釈できます。
+var tradeTimeLayouts = []string{"15:04:05.999999999", "15:04"}
+
+// Timestamp は約定日時を日本時間で返します。
+func (t *Trade) Timestamp() (time.Time, error) {
+	d := t.Date
+	if d.IsZero() {
+		return time.Time{}, fmt.Errorf("trade date is not available")
+	}
+	for _, layout := range tradeTimeLayouts {
+		if tod, err := time.Parse(layout, t.Time); err == nil {
+			return time.Date(d.Year, d.Month, d.Day, tod.Hour(), tod.Minute(), tod.Second(), tod.Nanosecond(), types.JST), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid trade time %q", t.Time)
+}
+
+// ReadTrades はBulk APIのティックデータCSV（BulkService.Openで展開したもの）を1約定ずつ返すイテレータです。
+//
+// 列はヘッダーの列名で対応付け、tradeColumns にない列は読み飛ばします。tradeColumns の列が足りない場合や
+// 重複する場合は、想定と異なるファイルとしてエラーを返して終了します。
+// 値を解釈できない行・約定日時が不正な行・約定値段が正でない行は、その行のエラーを返して次の行に進みます。
+// CSVとして読めない場合はエラーを返して終了します。
+func ReadTrades(r io.Reader) iter.Seq2[Trade, error] {
+	return func(yield func(Trade, error) bool) {
+		cr := csv.NewReader(r)
+		cr.ReuseRecord = true
+		header, err := cr.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			yield(Trade{}, fmt.Errorf("failed to read trades CSV header: %w", err))
+			return
+		}
+		dec, err := newTradeDecoder(header)
+		if err != nil {
+			yield(Trade{}, err)
+			return
+		}
+
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Trade{}, fmt.Errorf("failed to read trades CSV: %w", err))
+				return
+			}
+			t, err := dec.decode(record)
+			if err != nil {
+				line, _ := cr.FieldPos(0)
+				err = fmt.Errorf("failed to decode trades CSV line %d: %w", line, err)
+			}
+			if !yield(t, err) {
+				return
+			}
+		}
+	}
+}
+
+// tradeDecoder はCSVの1行を、ヘッダーの列名に対応するtradeColumnsの関数でTradeに変換します。
+type tradeDecoder struct {
+	names   []string
+	setters []func(*Trade, string) error
+}
+
+// newTradeDecoder はヘッダーを検証してtradeDecoderを作成します。
+func newTradeDecoder(header []string) (*tradeDecoder, error) {
+	d := &tradeDecoder{names: make([]string, len(header)), setters: make([]func(*Trade, string) error, len(header))}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // UTF-8 BOM
+		}
+		set, ok := tradeColumns[name]
+		if !ok {
+			continue // 列が追加されても読めるよう、未知の列は読み飛ばす
+		}
+		if slices.Contains(d.names[:i], name) {
+			return nil, fmt.Errorf("duplicate trades CSV column %q", name)
+		}
+		d.names[i], d.setters[i] = name, set
+	}
+	for _, name := range slices.Sorted(maps.Keys(tradeColumns)) {
+		if !slices.Contains(d.names, name) {
+			return nil, fmt.Errorf("missing trades CSV column %q", name)
+		}
+	}
+	return d, nil
+}
+
+func (d *tradeDecoder) decode(record []string) (Trade, error) {
+	var t Trade
+	for i, v := range record {
+		if d.setters[i] == nil {
+			continue
+		}
+		if err := d.setters[i](&t, v); err != nil {
+			return Trade{}, fmt.Errorf("invalid %s %q", d.names[i], v)
+		}
+	}
+	if _, err := t.Timestamp(); err != nil {
+		return Trade{}, err
+	}
+	if t.Price <= 0 {
+		return Trade{}, fmt.Errorf("invalid trade price for %s %s %s", t.Code, t.Date, t.Time)
+	}
+	return t, nil
+}
+
+// TradeBar は約定を一定の時間間隔で集計した足です。
+type TradeBar struct {
+	Code  string    // 銘柄コード
+	Start time.Time // 足の開始日時（日本時間）
+
+	// 四本値
+	O float64 // 始値
+	H float64 // 高値
+	L float64 // 安値
+	C float64 // 終値
+
+	Vo    float64 // 出来高
+	Va    float64 // 売買代金（約定値段×約定数量の合計）
+	Count int     // 約定回数
+}
+
+// VWAP は足の出来高加重平均価格を返します。出来高が0の場合は0を返します。
+func (b *TradeBar) VWAP() float64 {
+	if b.Vo == 0 {
+		return 0
+	}
+	return b.Va / b.Vo
+}
+
+// MinuteQuote は足をMinuteQuote（株価分足）の形式に変換します。
+// Timeは足の開始時刻（HH:mm形式）です。
+func (b *TradeBar) MinuteQuote() MinuteQuote {
+	return MinuteQuote{
+		Date: types.DateOf(b.Start),
+		Time: b.Start.Format("15:04"),
+		Code: b.Code,
+		O:    b.O,
+		H:    b.H,
+		L:    b.L,
+		C:    b.C,
+		Vo:   b.Vo,
+		Va:   b.Va,
+	}
+}
+
+func (b *TradeBar) add(t *Trade) {
+	if b.Count == 0 {
+		b.O, b.H, b.L = t.Price, t.Price, t.Price
+	}
+	b.H = max(b.H, t.Price)
+	b.L = min(b.L, t.Price)
+	b.C = t.Price
+	b.Vo += t.Vo
+	b.Va += t.Price * t.Vo
+	b.Count++
+}
+
+// TradeBars は約定をintervalごとの足に集計するイテレータです。足の区切りは日本時間の0時を起点とします。
+// 約定のない区間の足は返しません。
+//
+// 約定は銘柄ごとに時刻順に並んでいる必要があります（銘柄間で入り混じっていても構いません）。
+// 足はその銘柄の次の区間の約定が現れた時点で確定して返され、入力の終わりで残りの足を
+// 銘柄コード順に返します。
+//
+// ReadTradesと同じく、1行の誤りで集計全体を止めないよう、入力のエラー・約定日時が不正な約定・
+// 時刻順でない約定はそのエラーを返し、その約定を集計から除いて次の約定に進みます。
+func TradeBars(trades iter.Seq2[Trade, error], interval time.Duration) iter.Seq2[TradeBar, error] {
+	return func(yield func(TradeBar, error) bool) {
+		if interval <= 0 {
+			yield(TradeBar{}, fmt.Errorf("interval must be positive: %s", interval))
+			return
+		}
+
+		open := map[string]*TradeBar{}
+		for t, err := range trades {
+			if err != nil {
+				if !yield(TradeBar{}, err) {
+					return
+				}
+				continue
+			}
+			ts, err := t.Timestamp()
+			if err != nil {
+				if !yield(TradeBar{}, err) {
+					return
+				}
+				continue
+			}
+			midnight := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, types.JST)
+			start := midnight.Add(ts.Sub(midnight).Truncate(interval))
+
+			bar := open[t.Code]
+			switch {
+			case bar == nil:
+			case start.Equal(bar.Start):
+				bar.add(&t)
+				continue
+			case start.Before(bar.Start):
+				if !yield(TradeBar{}, fmt.Errorf("trades for %s are not in time order: %s %s", t.Code, t.Date, t.Time)) {
+					return
+				}
+				continue
+			default:
+				if !yield(*bar, nil) {
+					return
+				}
+			}
+			bar = &TradeBar{Code: t.Code, Start: start}
+			bar.add(&t)
+			open[t.Code] = bar
+		}
+
+		for _, code := range slices.Sorted(maps.Keys(open)) {
+			if !yield(*open[code], nil) {
+				return
+			}
+		}
+	}
+}
+
+// TradeMinuteQuotes は約定を1分足に集計し、MinuteQuote（株価分足）の形式で返すイテレータです。
+// 集計方法、入力の前提、エラーの扱いはTradeBarsと同じです。
+func TradeMinuteQuotes(trades iter.Seq2[Trade, error]) iter.Seq2[MinuteQuote, error] {
+	return func(yield func(MinuteQuote, error) bool) {
+		for bar, err := range TradeBars(trades, time.Minute) {
+			q := MinuteQuote{}
+			if err == nil {
+				q = bar.MinuteQuote()
+			}
+			if !yield(q, err) {
+				return
+			}
+		}
+	}
+}
+
+// TradingSession は東証の立会時間の区分です。
+type TradingSession int
+
+const (
+	SessionMorning   TradingSession = iota + 1 // 前場（9:00〜11:30）
+	SessionAfternoon                           // 後場（12:30〜15:30）
+	SessionOffHours                            // 立会時間外
+)
+
+// String は区分名を返します。
+func (s TradingSession) String() string {
+	switch s {
+	case SessionMorning:
+		return "morning"
+	case SessionAfternoon:
+		return "afternoon"
+	case SessionOffHours:
+		return "off-hours"
+	default:
+		return fmt.Sprintf("TradingSession(%d)", int(s))
+	}
+}
+
+// SessionOf は日本時間の時刻tが属する立会時間の区分を返します。
+// 前場・後場の終了時刻ちょうどの約定（引けの板寄せ）はその立会に含めます。
+// 立会時間は2024年11月5日以降の東証の現物市場（前場 9:00〜11:30、後場 12:30〜15:30）に基づきます。
+func SessionOf(t time.Time) TradingSession {
+	t = t.In(types.JST)
+	hm := t.Hour()*60 + t.Minute()
+	atBoundary := t.Second() == 0 && t.Nanosecond() == 0
+	switch {
+	case hm >= 9*60 && (hm < 11*60+30 || hm == 11*60+30 && atBoundary):
+		return SessionMorning
+	case hm >= 12*60+30 && (hm < 15*60+30 || hm == 15*60+30 && atBoundary):
+		return SessionAfternoon
+	default:
+		return SessionOffHours
+	}
+}
+
+// TradeSessionStats は銘柄・日付・立会ごとの約定の統計です。
+type TradeSessionStats struct {
+	Date    types.Date     // 日付
+	Code    string         // 銘柄コード
+	Session TradingSession // 立会時間の区分
+
+	Count int     // 約定回数
+	Vo    float64 // 出来高
+	Va    float64 // 売買代金（約定値段×約定数量の合計）
+	VWAP  float64 // 出来高加重平均価格（出来高が0の場合は0）
+
+	First time.Time // 最初の約定日時
+	Last  time.Time // 最後の約定日時
+}
+
+// SummarizeTradeSessions は約定を銘柄・日付・立会ごとに集計し、日付・銘柄コード・立会の順に並べて返します。
+// 入力の順序は問いません。TradeBarsと同じく、入力のエラーや約定日時が不正な約定は集計から除き、
+// それらのエラーをerrors.Joinでまとめて集計結果とともに返します。
+func SummarizeTradeSessions(trades iter.Seq2[Trade, error]) ([]TradeSessionStats, error) {
+	type key struct {
+		date    types.Date
+		code    string
+		session TradingSession
+	}
+	stats := map[key]*TradeSessionStats{}
+	var errs []error
+	for t, err := range trades {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ts, err := t.Timestamp()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		k := key{t.Date, t.Code, SessionOf(ts)}
+		s := stats[k]
+		if s == nil {
+			s = &TradeSessionStats{Date: k.date, Code: k.code, Session: k.session, First: ts, Last: ts}
+			stats[k] = s
+		}
+		s.Count++
+		s.Vo += t.Vo
+		s.Va += t.Price * t.Vo
+		if ts.Before(s.First) {
+			s.First = ts
+		}
+		if ts.After(s.Last) {
+			s.Last = ts
+		}
+	}
+
+	result := make([]TradeSessionStats, 0, len(stats))
+	for _, s := range stats {
+		if s.Vo != 0 {
+			s.VWAP = s.Va / s.Vo
+		}
+		result = append(result, *s)
+	}
+	slices.SortFunc(result, func(a, b TradeSessionStats) int {
+		return cmp.Or(a.Date.Compare(b.Date), strings.Compare(a.Code, b.Code), cmp.Compare(a.Session, b.Session))
+	})
+	return result, errors.Join(errs...)
+}
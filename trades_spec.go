@@ -3,8 +3,10 @@ package jquants
 import (
 	"context"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // TradesSpecService は投資部門別情報を取得するサービスです。
@@ -96,10 +98,10 @@ type TradesSpecResponse struct {
 
 // TradesSpecParams は投資部門別情報のリクエストパラメータです。
 type TradesSpecParams struct {
-	Section       string // セクション（市場）（例: TSEPrime, TSEStandard, TSEGrowth）
-	From          string // 開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Section       string     // セクション（市場）（例: TSEPrime, TSEStandard, TSEGrowth）
+	From          types.Date // 開始日
+	To            types.Date // 終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // 市場コード定数
@@ -123,23 +125,16 @@ const (
 func (s *TradesSpecService) GetTradesSpec(ctx context.Context, params TradesSpecParams) (*TradesSpecResponse, error) {
 	path := "/equities/investor-types"
 
-	query := "?"
-	if params.Section != "" {
-		query += fmt.Sprintf("section=%s&", params.Section)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.set("section", params.Section)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp TradesSpecResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -149,121 +144,49 @@ func (s *TradesSpecService) GetTradesSpec(ctx context.Context, params TradesSpec
 	return &resp, nil
 }
 
-// GetTradesSpecByDateRange は指定期間の投資部門別情報を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *TradesSpecService) GetTradesSpecByDateRange(ctx context.Context, from, to string) ([]TradesSpec, error) {
-	var allTradesSpec []TradesSpec
-	paginationKey := ""
-
-	for {
-		params := TradesSpecParams{
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
+// AllTradesSpec は指定条件の投資部門別売買状況を1件ずつ返すイテレータです。
+func (s *TradesSpecService) AllTradesSpec(ctx context.Context, params TradesSpecParams) iter.Seq2[TradesSpec, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]TradesSpec, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetTradesSpec(ctx, params)
 		if err != nil {
-			return nil, err
-		}
-
-		allTradesSpec = append(allTradesSpec, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+			return nil, "", err
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allTradesSpec, nil
+// GetTradesSpecByDateRange は指定期間の投資部門別情報を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *TradesSpecService) GetTradesSpecByDateRange(ctx context.Context, from, to types.Date) ([]TradesSpec, error) {
+	return collectAll(s.AllTradesSpec(ctx, TradesSpecParams{
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetTradesSpecBySection は指定セクションの投資部門別情報を取得します。
 // ページネーションを使用して全データを取得します。
 func (s *TradesSpecService) GetTradesSpecBySection(ctx context.Context, section string) ([]TradesSpec, error) {
-	var allTradesSpec []TradesSpec
-	paginationKey := ""
-
-	for {
-		params := TradesSpecParams{
-			Section:       section,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetTradesSpec(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allTradesSpec = append(allTradesSpec, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allTradesSpec, nil
+	return collectAll(s.AllTradesSpec(ctx, TradesSpecParams{
+		Section: section,
+	}))
 }
 
 // GetAllTradesSpec は全セクション・全期間の投資部門別情報を取得します。
 // ページネーションを使用して大量データを分割取得します。
 func (s *TradesSpecService) GetAllTradesSpec(ctx context.Context) ([]TradesSpec, error) {
-	var allTradesSpec []TradesSpec
-	paginationKey := ""
-
-	for {
-		params := TradesSpecParams{
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetTradesSpec(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allTradesSpec = append(allTradesSpec, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allTradesSpec, nil
+	return collectAll(s.AllTradesSpec(ctx, TradesSpecParams{}))
 }
 
 // GetTradesSpecBySectionAndDateRange は指定セクション・期間の投資部門別情報を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *TradesSpecService) GetTradesSpecBySectionAndDateRange(ctx context.Context, section, from, to string) ([]TradesSpec, error) {
-	var allTradesSpec []TradesSpec
-	paginationKey := ""
-
-	for {
-		params := TradesSpecParams{
-			Section:       section,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetTradesSpec(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allTradesSpec = append(allTradesSpec, resp.Data...)
-
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allTradesSpec, nil
+func (s *TradesSpecService) GetTradesSpecBySectionAndDateRange(ctx context.Context, section string, from, to types.Date) ([]TradesSpec, error) {
+	return collectAll(s.AllTradesSpec(ctx, TradesSpecParams{
+		Section: section,
+		From:    from,
+		To:      to,
+	}))
 }
 
 // IsBuyerDominant は買い手が優勢かどうかを判定します（差引がプラス）。
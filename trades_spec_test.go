@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestTradesSpecService_GetTradesSpec(t *testing.T) {
@@ -18,8 +19,8 @@ func TestTradesSpecService_GetTradesSpec(t *testing.T) {
 			name: "with all parameters",
 			params: TradesSpecParams{
 				Section:       "TSEPrime",
-				From:          "20230324",
-				To:            "20230403",
+				From:          types.MustParseDate("20230324"),
+				To:            types.MustParseDate("20230403"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/equities/investor-types?section=TSEPrime&from=20230324&to=20230403&pagination_key=key123",
@@ -28,8 +29,8 @@ func TestTradesSpecService_GetTradesSpec(t *testing.T) {
 			name: "with section and date range",
 			params: TradesSpecParams{
 				Section: "TSEPrime",
-				From:    "20230324",
-				To:      "20230403",
+				From:    types.MustParseDate("20230324"),
+				To:      types.MustParseDate("20230403"),
 			},
 			wantPath: "/equities/investor-types?section=TSEPrime&from=20230324&to=20230403",
 		},
@@ -43,8 +44,8 @@ func TestTradesSpecService_GetTradesSpec(t *testing.T) {
 		{
 			name: "with date range only",
 			params: TradesSpecParams{
-				From: "20230324",
-				To:   "20230403",
+				From: types.MustParseDate("20230324"),
+				To:   types.MustParseDate("20230403"),
 			},
 			wantPath: "/equities/investor-types?from=20230324&to=20230403",
 		},
@@ -166,7 +167,7 @@ func TestTradesSpecService_GetTradesSpecByDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/investor-types?from=20170104&to=20170120&pagination_key=next_page_key", mockResponse2)
 
 	// Execute
-	tradesSpec, err := service.GetTradesSpecByDateRange(context.Background(), "20170104", "20170120")
+	tradesSpec, err := service.GetTradesSpecByDateRange(context.Background(), types.MustParseDate("20170104"), types.MustParseDate("20170120"))
 
 	// Verify
 	if err != nil {
@@ -251,7 +252,7 @@ func TestTradesSpecService_GetTradesSpecBySectionAndDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", "/equities/investor-types?section=TSEPrime&from=20230324&to=20230403", mockResponse)
 
 	// Execute
-	tradesSpec, err := service.GetTradesSpecBySectionAndDateRange(context.Background(), "TSEPrime", "20230324", "20230403")
+	tradesSpec, err := service.GetTradesSpecBySectionAndDateRange(context.Background(), "TSEPrime", types.MustParseDate("20230324"), types.MustParseDate("20230403"))
 
 	// Verify
 	if err != nil {
@@ -0,0 +1,297 @@
+package jquants
+
+import (
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/utahta/jquants/types"
+)
+
+const testTradesCSV = "Date,Time,Code,Price,Vo\n" +
+	"2025-01-06,09:00:00.000000,72030,2900,1000\n" +
+	"2025-01-06,09:00:00.000000,99840,9000,100\n" +
+	"2025-01-06,09:00:12.345678,72030,2910,500\n" +
+	"2025-01-06,09:00:59.999999,72030,2890,500\n" +
+	"2025-01-06,09:01:30.000000,72030,2920,2000\n" +
+	"2025-01-06,11:30:00.000000,72030,2950,1000\n" +
+	"2025-01-06,12:30:00.000000,72030,2940,1000\n" +
+	"2025-01-06,15:30:00.000000,99840,9100,300\n"
+
+func collectTrades(t *testing.T, csv string) []Trade {
+	t.Helper()
+	var trades []Trade
+	for trade, err := range ReadTrades(strings.NewReader(csv)) {
+		if err != nil {
+			t.Fatalf("ReadTrades() error = %v", err)
+		}
+		trades = append(trades, trade)
+	}
+	return trades
+}
+
+func tradeSeq(trades []Trade) iter.Seq2[Trade, error] {
+	return func(yield func(Trade, error) bool) {
+		for _, t := range trades {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestReadTrades(t *testing.T) {
+	trades := collectTrades(t, testTradesCSV)
+	if len(trades) != 8 {
+		t.Fatalf("got %d trades, want 8", len(trades))
+	}
+	if trades[2].Code != "72030" || trades[2].Price != 2910 || trades[2].Vo != 500 {
+		t.Errorf("trades[2] = %+v", trades[2])
+	}
+	ts, err := trades[2].Timestamp()
+	if err != nil {
+		t.Fatalf("Timestamp() error = %v", err)
+	}
+	if want := time.Date(2025, 1, 6, 9, 0, 12, 345678000, types.JST); !ts.Equal(want) {
+		t.Errorf("Timestamp() = %v, want %v", ts, want)
+	}
+}
+
+func TestReadTrades_Errors(t *testing.T) {
+	csv := "Date,Time,Code,Price,Vo\n" +
+		"2025-01-06,9時,72030,2900,100\n" +
+		"2025-01-06,09:00:01,72030,-,100\n" +
+		"2025-01-06,09:00:02,72030,2900,100\n"
+	var rows, errs int
+	for _, err := range ReadTrades(strings.NewReader(csv)) {
+		if err != nil {
+			errs++
+			continue
+		}
+		rows++
+	}
+	if rows != 1 || errs != 2 {
+		t.Errorf("rows = %d, errs = %d, want 1, 2", rows, errs)
+	}
+}
+
+func TestReadTrades_Header(t *testing.T) {
+	// 列はヘッダーの列名で対応付けるため、順序は問わない
+	trades := collectTrades(t, "\ufeffCode,Vo,Price,Time,Date\n72030,100,2900,09:00:00,2025-01-06\n")
+	want := Trade{Date: types.NewDate(2025, time.January, 6), Time: "09:00:00", Code: "72030", Price: 2900, Vo: 100}
+	if len(trades) != 1 || trades[0] != want {
+		t.Errorf("trades = %+v, want [%+v]", trades, want)
+	}
+
+	// 未知の列は読み飛ばす
+	trades = collectTrades(t, "Date,Time,TradeID,Code,Price,Vo\n2025-01-06,09:00:00,T1,72030,2900,100\n")
+	if len(trades) != 1 || trades[0] != want {
+		t.Errorf("extra column: trades = %+v, want [%+v]", trades, want)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "missing column", header: "Date,Time,Code,Price", want: `missing trades CSV column "Vo"`},
+		{name: "duplicate column", header: "Date,Time,Code,Price,Price", want: `duplicate trades CSV column "Price"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs []error
+			for _, err := range ReadTrades(strings.NewReader(tt.header + "\n2025-01-06,09:00:00,72030,2900,100\n")) {
+				errs = append(errs, err)
+			}
+			if len(errs) != 1 || errs[0] == nil || errs[0].Error() != tt.want {
+				t.Errorf("errors = %v, want [%s]", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestTradeMinuteQuotes(t *testing.T) {
+	trades := collectTrades(t, testTradesCSV)
+	var quotes []MinuteQuote
+	for q, err := range TradeMinuteQuotes(tradeSeq(trades)) {
+		if err != nil {
+			t.Fatalf("TradeMinuteQuotes() error = %v", err)
+		}
+		quotes = append(quotes, q)
+	}
+
+	want := []MinuteQuote{
+		{Date: types.MustParseDate("2025-01-06"), Time: "09:00", Code: "72030", O: 2900, H: 2910, L: 2890, C: 2890, Vo: 2000, Va: 2900*1000 + 2910*500 + 2890*500},
+		{Date: types.MustParseDate("2025-01-06"), Time: "09:01", Code: "72030", O: 2920, H: 2920, L: 2920, C: 2920, Vo: 2000, Va: 2920 * 2000},
+		{Date: types.MustParseDate("2025-01-06"), Time: "11:30", Code: "72030", O: 2950, H: 2950, L: 2950, C: 2950, Vo: 1000, Va: 2950 * 1000},
+		{Date: types.MustParseDate("2025-01-06"), Time: "09:00", Code: "99840", O: 9000, H: 9000, L: 9000, C: 9000, Vo: 100, Va: 9000 * 100},
+		{Date: types.MustParseDate("2025-01-06"), Time: "12:30", Code: "72030", O: 2940, H: 2940, L: 2940, C: 2940, Vo: 1000, Va: 2940 * 1000},
+		{Date: types.MustParseDate("2025-01-06"), Time: "15:30", Code: "99840", O: 9100, H: 9100, L: 9100, C: 9100, Vo: 300, Va: 9100 * 300},
+	}
+	if len(quotes) != len(want) {
+		t.Fatalf("got %d quotes, want %d: %+v", len(quotes), len(want), quotes)
+	}
+	for i := range want {
+		if quotes[i] != want[i] {
+			t.Errorf("quotes[%d] = %+v, want %+v", i, quotes[i], want[i])
+		}
+	}
+}
+
+func TestTradeBars(t *testing.T) {
+	trades := collectTrades(t, testTradesCSV)
+
+	var bars []TradeBar
+	for bar, err := range TradeBars(tradeSeq(trades[:5]), 30*time.Second) {
+		if err != nil {
+			t.Fatalf("TradeBars() error = %v", err)
+		}
+		bars = append(bars, bar)
+	}
+	// 72030: 09:00:00, 09:00:30, 09:01:30 の3本、99840: 09:00:00 の1本
+	if len(bars) != 4 {
+		t.Fatalf("got %d bars, want 4: %+v", len(bars), bars)
+	}
+	first := bars[0]
+	if first.Code != "72030" || first.Count != 2 || first.O != 2900 || first.C != 2910 || first.Vo != 1500 {
+		t.Errorf("first bar = %+v", first)
+	}
+	if want := (2900.0*1000 + 2910*500) / 1500; first.VWAP() != want {
+		t.Errorf("VWAP() = %v, want %v", first.VWAP(), want)
+	}
+	if want := time.Date(2025, 1, 6, 9, 0, 30, 0, types.JST); !bars[1].Start.Equal(want) {
+		t.Errorf("bars[1].Start = %v, want %v", bars[1].Start, want)
+	}
+
+	t.Run("invalid interval", func(t *testing.T) {
+		for _, err := range TradeBars(tradeSeq(trades), 0) {
+			if err == nil {
+				t.Fatal("expected error for zero interval")
+			}
+		}
+	})
+
+	// エラーの約定は報告して集計から除き、残りの約定の集計を続ける
+	t.Run("out of order", func(t *testing.T) {
+		var errs int
+		var got []TradeBar
+		for bar, err := range TradeBars(tradeSeq([]Trade{trades[4], trades[0], trades[5]}), time.Minute) {
+			if err != nil {
+				errs++
+				continue
+			}
+			got = append(got, bar)
+		}
+		if errs != 1 || len(got) != 2 || got[0].Count != 1 || got[1].C != 2950 {
+			t.Errorf("errs = %d, bars = %+v, want 1 error and bars at 09:01, 11:30", errs, got)
+		}
+	})
+
+	t.Run("input error", func(t *testing.T) {
+		want := errors.New("boom")
+		seq := func(yield func(Trade, error) bool) {
+			_ = yield(Trade{}, want) && yield(trades[0], nil)
+		}
+		var errs, bars int
+		for _, err := range TradeBars(seq, time.Minute) {
+			switch {
+			case err == nil:
+				bars++
+			case errors.Is(err, want):
+				errs++
+			default:
+				t.Fatalf("error = %v, want %v", err, want)
+			}
+		}
+		if errs != 1 || bars != 1 {
+			t.Errorf("errs = %d, bars = %d, want 1, 1", errs, bars)
+		}
+	})
+
+	t.Run("bad row in CSV", func(t *testing.T) {
+		csv := "Date,Time,Code,Price,Vo\n" +
+			"2025-01-06,09:00:00,72030,2900,100\n" +
+			"2025-01-06,09:00:10,72030,abc,100\n" +
+			"2025-01-06,09:00:20,72030,2910,100\n"
+		var errs int
+		var got []TradeBar
+		for bar, err := range TradeBars(ReadTrades(strings.NewReader(csv)), time.Minute) {
+			if err != nil {
+				errs++
+				continue
+			}
+			got = append(got, bar)
+		}
+		if errs != 1 || len(got) != 1 || got[0].Count != 2 || got[0].C != 2910 {
+			t.Errorf("errs = %d, bars = %+v, want 1 error and 1 bar of 2 trades", errs, got)
+		}
+	})
+}
+
+func TestSessionOf(t *testing.T) {
+	tests := []struct {
+		clock string
+		want  TradingSession
+	}{
+		{"08:59:59", SessionOffHours},
+		{"09:00:00", SessionMorning},
+		{"11:29:59.999", SessionMorning},
+		{"11:30:00", SessionMorning},
+		{"11:30:00.001", SessionOffHours},
+		{"12:29:59", SessionOffHours},
+		{"12:30:00", SessionAfternoon},
+		{"15:30:00", SessionAfternoon},
+		{"15:30:01", SessionOffHours},
+	}
+	for _, tt := range tests {
+		trade := Trade{Date: types.MustParseDate("2025-01-06"), Time: tt.clock}
+		ts, err := trade.Timestamp()
+		if err != nil {
+			t.Fatalf("Timestamp(%q) error = %v", tt.clock, err)
+		}
+		if got := SessionOf(ts); got != tt.want {
+			t.Errorf("SessionOf(%s) = %v, want %v", tt.clock, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeTradeSessions(t *testing.T) {
+	stats, err := SummarizeTradeSessions(ReadTrades(strings.NewReader(testTradesCSV)))
+	if err != nil {
+		t.Fatalf("SummarizeTradeSessions() error = %v", err)
+	}
+	if len(stats) != 4 {
+		t.Fatalf("got %d stats, want 4: %+v", len(stats), stats)
+	}
+
+	am := stats[0]
+	if am.Code != "72030" || am.Session != SessionMorning || am.Count != 5 || am.Vo != 5000 {
+		t.Errorf("stats[0] = %+v", am)
+	}
+	wantVa := 2900.0*1000 + 2910*500 + 2890*500 + 2920*2000 + 2950*1000
+	if am.Va != wantVa || am.VWAP != wantVa/5000 {
+		t.Errorf("Va = %v, VWAP = %v, want %v, %v", am.Va, am.VWAP, wantVa, wantVa/5000)
+	}
+	if am.First.Format("15:04:05") != "09:00:00" || am.Last.Format("15:04:05") != "11:30:00" {
+		t.Errorf("First = %v, Last = %v", am.First, am.Last)
+	}
+
+	if stats[1].Code != "72030" || stats[1].Session != SessionAfternoon || stats[1].Count != 1 {
+		t.Errorf("stats[1] = %+v", stats[1])
+	}
+	if stats[2].Code != "99840" || stats[2].Session != SessionMorning || stats[3].Session != SessionAfternoon {
+		t.Errorf("stats[2:] = %+v", stats[2:])
+	}
+
+	// エラーの行は除いて集計し、エラーも返す
+	csv := testTradesCSV + "2025-01-06,25:00:00,72030,2900,100\n"
+	stats, err = SummarizeTradeSessions(ReadTrades(strings.NewReader(csv)))
+	if err == nil || !strings.Contains(err.Error(), "line 10") {
+		t.Errorf("error = %v, want error for line 10", err)
+	}
+	if len(stats) != 4 || stats[0].Count != 5 {
+		t.Errorf("stats = %+v, want the 4 sessions without the bad row", stats)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 // TradingCalendarService は取引カレンダーを取得するサービスです。
@@ -20,9 +21,9 @@ func NewTradingCalendarService(c client.HTTPClient) *TradingCalendarService {
 
 // TradingCalendarParams は取引カレンダーのリクエストパラメータです。
 type TradingCalendarParams struct {
-	HolidayDivision string // 休日区分（0: 非営業日, 1: 営業日, 2: 東証半日立会日, 3: 非営業日(祝日取引あり)）
-	From            string // 開始日（YYYYMMDD または YYYY-MM-DD）
-	To              string // 終了日（YYYYMMDD または YYYY-MM-DD）
+	HolidayDivision string     // 休日区分（0: 非営業日, 1: 営業日, 2: 東証半日立会日, 3: 非営業日(祝日取引あり)）
+	From            types.Date // 開始日
+	To              types.Date // 終了日
 }
 
 // TradingCalendarResponse は取引カレンダーのレスポンスです。
@@ -33,8 +34,8 @@ type TradingCalendarResponse struct {
 // TradingCalendar は取引カレンダーのデータです。
 // J-Quants API /markets/calendar エンドポイントのレスポンスデータ。
 type TradingCalendar struct {
-	Date   string `json:"Date"`   // 日付（YYYY-MM-DD形式）
-	HolDiv string `json:"HolDiv"` // 休日区分（0: 非営業日, 1: 営業日, 2: 東証半日立会日, 3: 非営業日(祝日取引あり)）
+	Date   types.Date `json:"Date"`   // 日付
+	HolDiv string     `json:"HolDiv"` // 休日区分（0: 非営業日, 1: 営業日, 2: 東証半日立会日, 3: 非営業日(祝日取引あり)）
 }
 
 // 休日区分定数
@@ -54,20 +55,15 @@ const (
 func (s *TradingCalendarService) GetTradingCalendar(ctx context.Context, params TradingCalendarParams) (*TradingCalendarResponse, error) {
 	path := "/markets/calendar"
 
-	query := "?"
-	if params.HolidayDivision != "" {
-		query += fmt.Sprintf("hol_div=%s&", params.HolidayDivision)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
+	query := newQuery()
+	query.set("hol_div", params.HolidayDivision)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp TradingCalendarResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -98,7 +94,7 @@ func (s *TradingCalendarService) GetTradingCalendarByHolidayDivision(ctx context
 }
 
 // GetTradingCalendarByHolidayDivisionAndDateRange は指定した休日区分・期間の取引カレンダーを取得します。
-func (s *TradingCalendarService) GetTradingCalendarByHolidayDivisionAndDateRange(ctx context.Context, holDiv, from, to string) ([]TradingCalendar, error) {
+func (s *TradingCalendarService) GetTradingCalendarByHolidayDivisionAndDateRange(ctx context.Context, holDiv string, from, to types.Date) ([]TradingCalendar, error) {
 	resp, err := s.GetTradingCalendar(ctx, TradingCalendarParams{
 		HolidayDivision: holDiv,
 		From:            from,
@@ -111,7 +107,7 @@ func (s *TradingCalendarService) GetTradingCalendarByHolidayDivisionAndDateRange
 }
 
 // GetTradingCalendarByDateRange は指定した期間の取引カレンダーを取得します。
-func (s *TradingCalendarService) GetTradingCalendarByDateRange(ctx context.Context, from, to string) ([]TradingCalendar, error) {
+func (s *TradingCalendarService) GetTradingCalendarByDateRange(ctx context.Context, from, to types.Date) ([]TradingCalendar, error) {
 	resp, err := s.GetTradingCalendar(ctx, TradingCalendarParams{
 		From: from,
 		To:   to,
@@ -123,7 +119,7 @@ func (s *TradingCalendarService) GetTradingCalendarByDateRange(ctx context.Conte
 }
 
 // GetTradingDays は指定した期間の営業日のみを取得します。
-func (s *TradingCalendarService) GetTradingDays(ctx context.Context, from, to string) ([]TradingCalendar, error) {
+func (s *TradingCalendarService) GetTradingDays(ctx context.Context, from, to types.Date) ([]TradingCalendar, error) {
 	resp, err := s.GetTradingCalendar(ctx, TradingCalendarParams{
 		HolidayDivision: HolidayDivisionTradingDay,
 		From:            from,
@@ -136,7 +132,7 @@ func (s *TradingCalendarService) GetTradingDays(ctx context.Context, from, to st
 }
 
 // GetNonTradingDays は指定した期間の非営業日のみを取得します。
-func (s *TradingCalendarService) GetNonTradingDays(ctx context.Context, from, to string) ([]TradingCalendar, error) {
+func (s *TradingCalendarService) GetNonTradingDays(ctx context.Context, from, to types.Date) ([]TradingCalendar, error) {
 	resp, err := s.GetTradingCalendar(ctx, TradingCalendarParams{
 		HolidayDivision: HolidayDivisionNonTradingDay,
 		From:            from,
@@ -149,7 +145,7 @@ func (s *TradingCalendarService) GetNonTradingDays(ctx context.Context, from, to
 }
 
 // GetOSEHolidayTradingDays はOSEで祝日取引を実施する日を取得します。
-func (s *TradingCalendarService) GetOSEHolidayTradingDays(ctx context.Context, from, to string) ([]TradingCalendar, error) {
+func (s *TradingCalendarService) GetOSEHolidayTradingDays(ctx context.Context, from, to types.Date) ([]TradingCalendar, error) {
 	resp, err := s.GetTradingCalendar(ctx, TradingCalendarParams{
 		HolidayDivision: HolidayDivisionOSEHolidayTrading,
 		From:            from,
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestTradingCalendarService_GetTradingCalendar(t *testing.T) {
@@ -18,16 +19,16 @@ func TestTradingCalendarService_GetTradingCalendar(t *testing.T) {
 			name: "with all parameters",
 			params: TradingCalendarParams{
 				HolidayDivision: "1",
-				From:            "20240101",
-				To:              "20240131",
+				From:            types.MustParseDate("20240101"),
+				To:              types.MustParseDate("20240131"),
 			},
 			wantPath: "/markets/calendar?hol_div=1&from=20240101&to=20240131",
 		},
 		{
 			name: "with date range only",
 			params: TradingCalendarParams{
-				From: "20240101",
-				To:   "20240107",
+				From: types.MustParseDate("20240101"),
+				To:   types.MustParseDate("20240107"),
 			},
 			wantPath: "/markets/calendar?from=20240101&to=20240107",
 		},
@@ -55,11 +56,11 @@ func TestTradingCalendarService_GetTradingCalendar(t *testing.T) {
 			mockResponse := TradingCalendarResponse{
 				Data: []TradingCalendar{
 					{
-						Date:   "2015-04-01",
+						Date:   types.MustParseDate("2015-04-01"),
 						HolDiv: "1",
 					},
 					{
-						Date:   "2015-04-02",
+						Date:   types.MustParseDate("2015-04-02"),
 						HolDiv: "1",
 					},
 				},
@@ -95,9 +96,9 @@ func TestTradingCalendarService_GetAllTradingCalendar(t *testing.T) {
 	// Mock response
 	mockResponse := TradingCalendarResponse{
 		Data: []TradingCalendar{
-			{Date: "2024-01-01", HolDiv: "0"},
-			{Date: "2024-01-02", HolDiv: "1"},
-			{Date: "2024-01-03", HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-01"), HolDiv: "0"},
+			{Date: types.MustParseDate("2024-01-02"), HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-03"), HolDiv: "1"},
 		},
 	}
 	mockClient.SetResponse("GET", "/markets/calendar", mockResponse)
@@ -122,8 +123,8 @@ func TestTradingCalendarService_GetTradingCalendarByHolidayDivision(t *testing.T
 	// Mock response
 	mockResponse := TradingCalendarResponse{
 		Data: []TradingCalendar{
-			{Date: "2024-01-02", HolDiv: "1"},
-			{Date: "2024-01-03", HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-02"), HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-03"), HolDiv: "1"},
 		},
 	}
 	mockClient.SetResponse("GET", "/markets/calendar?hol_div=1", mockResponse)
@@ -153,14 +154,14 @@ func TestTradingCalendarService_GetTradingCalendarByHolidayDivisionAndDateRange(
 	// Mock response
 	mockResponse := TradingCalendarResponse{
 		Data: []TradingCalendar{
-			{Date: "2024-01-02", HolDiv: "1"},
-			{Date: "2024-01-03", HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-02"), HolDiv: "1"},
+			{Date: types.MustParseDate("2024-01-03"), HolDiv: "1"},
 		},
 	}
 	mockClient.SetResponse("GET", "/markets/calendar?hol_div=1&from=20240101&to=20240131", mockResponse)
 
 	// Execute
-	data, err := service.GetTradingCalendarByHolidayDivisionAndDateRange(context.Background(), "1", "20240101", "20240131")
+	data, err := service.GetTradingCalendarByHolidayDivisionAndDateRange(context.Background(), "1", types.MustParseDate("20240101"), types.MustParseDate("20240131"))
 
 	// Verify
 	if err != nil {
@@ -185,11 +186,11 @@ func TestTradingCalendarService_GetTradingCalendarByDateRange(t *testing.T) {
 	mockResponse := TradingCalendarResponse{
 		Data: []TradingCalendar{
 			{
-				Date:   "2024-01-01",
+				Date:   types.MustParseDate("2024-01-01"),
 				HolDiv: "0",
 			},
 			{
-				Date:   "2024-01-02",
+				Date:   types.MustParseDate("2024-01-02"),
 				HolDiv: "1",
 			},
 		},
@@ -197,7 +198,7 @@ func TestTradingCalendarService_GetTradingCalendarByDateRange(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/calendar?from=20240101&to=20240107", mockResponse)
 
 	// Execute
-	resp, err := service.GetTradingCalendarByDateRange(context.Background(), "20240101", "20240107")
+	resp, err := service.GetTradingCalendarByDateRange(context.Background(), types.MustParseDate("20240101"), types.MustParseDate("20240107"))
 
 	// Verify
 	if err != nil {
@@ -206,7 +207,7 @@ func TestTradingCalendarService_GetTradingCalendarByDateRange(t *testing.T) {
 	if len(resp) != 2 {
 		t.Errorf("GetTradingCalendarByDateRange() returned %d items, want 2", len(resp))
 	}
-	if resp[0].Date != "2024-01-01" {
+	if resp[0].Date.String() != "2024-01-01" {
 		t.Errorf("GetTradingCalendarByDateRange() returned date %v, want 2024-01-01", resp[0].Date)
 	}
 	if resp[0].HolDiv != "0" {
@@ -223,11 +224,11 @@ func TestTradingCalendarService_GetTradingDays(t *testing.T) {
 	mockResponse := TradingCalendarResponse{
 		Data: []TradingCalendar{
 			{
-				Date:   "2024-01-02",
+				Date:   types.MustParseDate("2024-01-02"),
 				HolDiv: "1",
 			},
 			{
-				Date:   "2024-01-03",
+				Date:   types.MustParseDate("2024-01-03"),
 				HolDiv: "1",
 			},
 		},
@@ -235,7 +236,7 @@ func TestTradingCalendarService_GetTradingDays(t *testing.T) {
 	mockClient.SetResponse("GET", "/markets/calendar?hol_div=1&from=20240101&to=20240107", mockResponse)
 
 	// Execute
-	tradingDays, err := service.GetTradingDays(context.Background(), "20240101", "20240107")
+	tradingDays, err := service.GetTradingDays(context.Background(), types.MustParseDate("20240101"), types.MustParseDate("20240107"))
 
 	// Verify
 	if err != nil {
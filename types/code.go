@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// codeLetters は銘柄コードの2桁目・4桁目に使用される英字です。
+// 数字と紛らわしいB、E、I、O、Q、V、Zは使用されません。
+const codeLetters = "ACDFGHJKLMNPRSTUWXY"
+
+// Code は銘柄コード（証券コード）です。
+// 4桁（例: "7203"、"130A"）と5桁（例: "72030"、"130A0"）のどちらからも作成でき、
+// 内部では5桁の形式で保持するため、表記の違う同じ銘柄は == で一致します。
+//
+// 証券コードの1桁目と3桁目は数字、2桁目と4桁目は数字または英字（B、E、I、O、Q、V、Zを除く）です。
+// 5桁目は普通株式では0で、優先株式など同じ発行体の別銘柄を区別します。
+// 証券コードにチェックディジットはないため、検証は形式のみです。
+//
+// ゼロ値は「コードなし」を表し、JSONでは空文字になります。
+type Code struct {
+	long string
+}
+
+// ParseCode は4桁または5桁の銘柄コードをCodeに変換します。
+// 英字の小文字は大文字として扱います。
+func ParseCode(s string) (Code, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if err := validateCode(s); err != nil {
+		return Code{}, err
+	}
+	if len(s) == 4 {
+		s += "0"
+	}
+	return Code{long: s}, nil
+}
+
+// MustParseCode はParseCodeと同様ですが、変換できない場合はpanicします。
+func MustParseCode(s string) Code {
+	c, err := ParseCode(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ValidateCode は文字列が銘柄コードの形式（4桁または5桁）かどうかを検証します。
+func ValidateCode(s string) error {
+	_, err := ParseCode(s)
+	return err
+}
+
+func validateCode(s string) error {
+	if len(s) != 4 && len(s) != 5 {
+		return fmt.Errorf("invalid code %q: must be 4 or 5 characters", s)
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		isDigit := '0' <= c && c <= '9'
+		switch i {
+		case 0:
+			if c < '1' || c > '9' {
+				return fmt.Errorf("invalid code %q: first character must be 1-9", s)
+			}
+		case 1, 3:
+			if !isDigit && strings.IndexByte(codeLetters, c) < 0 {
+				return fmt.Errorf("invalid code %q: character %q at position %d is not allowed", s, c, i+1)
+			}
+		default:
+			if !isDigit {
+				return fmt.Errorf("invalid code %q: character at position %d must be a digit", s, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Long は5桁の銘柄コード（例: "72030"）を返します。APIレスポンスのCodeと同じ形式です。
+func (c Code) Long() string {
+	return c.long
+}
+
+// Short は4桁の銘柄コード（例: "7203"）を返します。
+// 5桁目が0以外の銘柄（優先株式など）は4桁では区別できないため、5桁のまま返します。
+func (c Code) Short() string {
+	if strings.HasSuffix(c.long, "0") {
+		return c.long[:4]
+	}
+	return c.long
+}
+
+// String は5桁の銘柄コードを返します。ゼロ値の場合は空文字です。
+func (c Code) String() string {
+	return c.long
+}
+
+// IsZero はコードなし（ゼロ値）かどうかを返します。
+func (c Code) IsZero() bool {
+	return c.long == ""
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Code) MarshalText() ([]byte, error) {
+	return []byte(c.long), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// 空文字はゼロ値として扱います。
+func (c *Code) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = Code{}
+		return nil
+	}
+	v, err := ParseCode(string(data))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// 5桁の形式で出力し、ゼロ値は空文字として出力します。
+func (c Code) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.long)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// 4桁・5桁の文字列を受けます。null・空文字はゼロ値です。
+func (c *Code) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = Code{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid code %s: %w", data, err)
+	}
+	return c.UnmarshalText([]byte(s))
+}
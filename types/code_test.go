@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantLong  string
+		wantShort string
+		wantErr   bool
+	}{
+		{input: "7203", wantLong: "72030", wantShort: "7203"},
+		{input: "72030", wantLong: "72030", wantShort: "7203"},
+		{input: "130A", wantLong: "130A0", wantShort: "130A"},
+		{input: "130A0", wantLong: "130A0", wantShort: "130A"},
+		{input: "130a", wantLong: "130A0", wantShort: "130A"},
+		{input: "1A2C", wantLong: "1A2C0", wantShort: "1A2C"},
+		{input: " 7203 ", wantLong: "72030", wantShort: "7203"},
+		// 5桁目が0以外の銘柄は4桁にすると普通株式と区別できない
+		{input: "25935", wantLong: "25935", wantShort: "25935"},
+		{input: "", wantErr: true},
+		{input: "720", wantErr: true},
+		{input: "720300", wantErr: true},
+		{input: "0203", wantErr: true},
+		{input: "A203", wantErr: true},
+		{input: "72A3", wantErr: true},
+		{input: "130B", wantErr: true},
+		{input: "130I", wantErr: true},
+		{input: "130O", wantErr: true},
+		{input: "130Z", wantErr: true},
+		{input: "130AA", wantErr: true},
+		{input: "13-A", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got.Long() != tt.wantLong {
+				t.Errorf("Long() = %q, want %q", got.Long(), tt.wantLong)
+			}
+			if got.Short() != tt.wantShort {
+				t.Errorf("Short() = %q, want %q", got.Short(), tt.wantShort)
+			}
+			if (ValidateCode(tt.input) != nil) != tt.wantErr {
+				t.Errorf("ValidateCode(%q) disagrees with ParseCode", tt.input)
+			}
+		})
+	}
+}
+
+func TestCode_Equal(t *testing.T) {
+	// 4桁と5桁の表記は同じ銘柄として一致する
+	if MustParseCode("7203") != MustParseCode("72030") {
+		t.Error("4-digit and 5-digit codes of the same issue must be equal")
+	}
+	m := map[Code]string{MustParseCode("72030"): "トヨタ自動車"}
+	if m[MustParseCode("7203")] != "トヨタ自動車" {
+		t.Error("map lookup with 4-digit code failed")
+	}
+}
+
+func TestCode_JSON(t *testing.T) {
+	var v struct {
+		Code  Code `json:"Code"`
+		Empty Code `json:"Empty"`
+		Nil   Code `json:"Nil"`
+	}
+	if err := json.Unmarshal([]byte(`{"Code":"7203","Empty":"","Nil":null}`), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if v.Code.Long() != "72030" {
+		t.Errorf("Code = %q, want 72030", v.Code)
+	}
+	if !v.Empty.IsZero() || !v.Nil.IsZero() {
+		t.Errorf("Empty = %q, Nil = %q, want zero", v.Empty, v.Nil)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := string(b); got != `{"Code":"72030","Empty":"","Nil":""}` {
+		t.Errorf("Marshal = %s", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Code":"ABCDE"}`), &v); err == nil {
+		t.Error("expected error for invalid code")
+	}
+}
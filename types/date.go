@@ -0,0 +1,176 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JST はJ-Quants APIが日付・時刻に使用するタイムゾーン（日本標準時）です。
+// 日本には夏時間がないため固定オフセットで表します。
+var JST = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// 日付のレイアウト
+const (
+	DateLayout        = "2006-01-02" // APIレスポンスの形式（YYYY-MM-DD）
+	CompactDateLayout = "20060102"   // リクエストパラメータの短縮形式（YYYYMMDD）
+)
+
+// Date は時刻・タイムゾーンを持たない暦日（日本時間の日付）です。
+// ゼロ値は「日付なし」を表し、JSONでは空文字になります。
+//
+// Date同士は == で比較でき、mapのキーにも使用できます。
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate はDateを作成します。範囲外の値は time.Date と同様に正規化されます
+// （例: 2024-02-30 は 2024-03-01）。
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, JST))
+}
+
+// DateOf は時刻tの日本時間での日付を返します。
+func DateOf(t time.Time) Date {
+	y, m, d := t.In(JST).Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// Today は現在の日本時間での日付を返します。
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate は YYYY-MM-DD または YYYYMMDD 形式の文字列をDateに変換します。
+// 存在しない日付（例: 2024-02-30）はエラーになります。
+func ParseDate(s string) (Date, error) {
+	for _, layout := range []string{DateLayout, CompactDateLayout} {
+		if len(s) != len(layout) {
+			continue
+		}
+		t, err := time.ParseInLocation(layout, s, JST)
+		if err != nil {
+			return Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+		}
+		return DateOf(t), nil
+	}
+	return Date{}, fmt.Errorf("invalid date %q: must be YYYY-MM-DD or YYYYMMDD", s)
+}
+
+// MustParseDate はParseDateと同様ですが、変換できない場合はpanicします。
+// テストや定数的な日付の定義に使用します。
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String は YYYY-MM-DD 形式の文字列を返します。ゼロ値の場合は空文字です。
+// APIのリクエストパラメータにもそのまま使用できます。
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Compact は YYYYMMDD 形式の文字列を返します。ゼロ値の場合は空文字です。
+func (d Date) Compact() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero は日付なし（ゼロ値）かどうかを返します。
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time は日本時間でのその日の0時を返します。
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, JST)
+}
+
+// Weekday は曜日を返します。
+func (d Date) Weekday() time.Weekday {
+	return d.Time().Weekday()
+}
+
+// AddDays はn日後の日付を返します。nが負の場合はn日前です。
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time().AddDate(0, 0, n))
+}
+
+// DaysSince はuからdまでの日数（d - u）を返します。
+func (d Date) DaysSince(u Date) int {
+	// 固定オフセットのため1日は常に24時間
+	return int(d.Time().Sub(u.Time()) / (24 * time.Hour))
+}
+
+// Before はdがuより前の日付かどうかを返します。
+func (d Date) Before(u Date) bool {
+	return d.Compare(u) < 0
+}
+
+// After はdがuより後の日付かどうかを返します。
+func (d Date) After(u Date) bool {
+	return d.Compare(u) > 0
+}
+
+// Compare はdがuより前なら-1、同じなら0、後なら+1を返します。
+// slices.SortFunc などの比較関数として使用できます。
+func (d Date) Compare(u Date) int {
+	if c := cmp.Compare(d.Year, u.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(d.Month, u.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(d.Day, u.Day)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// 空文字はゼロ値として扱います。
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = Date{}
+		return nil
+	}
+	v, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// ゼロ値は空文字として出力します。
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// APIの日付文字列（YYYY-MM-DD、YYYYMMDD）を受けます。null・空文字はゼロ値です。
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date %s: %w", data, err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Date
+		wantErr bool
+	}{
+		{input: "2024-01-15", want: Date{2024, time.January, 15}},
+		{input: "20240115", want: Date{2024, time.January, 15}},
+		{input: "2024-02-29", want: Date{2024, time.February, 29}},
+		{input: "2023-02-29", wantErr: true},
+		{input: "2024-13-01", wantErr: true},
+		{input: "2024/01/15", wantErr: true},
+		{input: "202401", wantErr: true},
+		{input: "2024-1-5", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Format(t *testing.T) {
+	d := NewDate(2024, time.March, 5)
+	if got := d.String(); got != "2024-03-05" {
+		t.Errorf("String() = %q, want %q", got, "2024-03-05")
+	}
+	if got := d.Compact(); got != "20240305" {
+		t.Errorf("Compact() = %q, want %q", got, "20240305")
+	}
+
+	var zero Date
+	if !zero.IsZero() || zero.String() != "" || zero.Compact() != "" {
+		t.Errorf("zero Date: IsZero() = %v, String() = %q, Compact() = %q", zero.IsZero(), zero.String(), zero.Compact())
+	}
+}
+
+func TestDate_Time(t *testing.T) {
+	d := NewDate(2024, time.January, 15)
+	want := time.Date(2024, 1, 14, 15, 0, 0, 0, time.UTC) // 2024-01-15 00:00 JST
+	if got := d.Time(); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+
+	// UTCでは前日でも日本時間の日付になる
+	if got := DateOf(time.Date(2024, 1, 14, 15, 30, 0, 0, time.UTC)); got != d {
+		t.Errorf("DateOf() = %v, want %v", got, d)
+	}
+	if got := DateOf(time.Date(2024, 1, 14, 14, 59, 0, 0, time.UTC)); got != d.AddDays(-1) {
+		t.Errorf("DateOf() = %v, want %v", got, d.AddDays(-1))
+	}
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	d := MustParseDate("2024-02-28")
+
+	if got := d.AddDays(1); got != MustParseDate("2024-02-29") {
+		t.Errorf("AddDays(1) = %v", got)
+	}
+	if got := d.AddDays(2); got != MustParseDate("2024-03-01") {
+		t.Errorf("AddDays(2) = %v", got)
+	}
+	if got := d.AddDays(-59); got != MustParseDate("2023-12-31") {
+		t.Errorf("AddDays(-59) = %v", got)
+	}
+	if got := MustParseDate("2025-01-01").DaysSince(d); got != 308 {
+		t.Errorf("DaysSince() = %d, want 308", got)
+	}
+	if got := NewDate(2024, time.February, 30); got != MustParseDate("2024-03-01") {
+		t.Errorf("NewDate() = %v, want normalized 2024-03-01", got)
+	}
+	if got := d.Weekday(); got != time.Wednesday {
+		t.Errorf("Weekday() = %v, want Wednesday", got)
+	}
+
+	next := d.AddDays(1)
+	if !d.Before(next) || d.After(next) || !next.After(d) {
+		t.Error("Before/After returned unexpected results")
+	}
+	if d.Compare(d) != 0 || d.Compare(next) != -1 || next.Compare(d) != 1 {
+		t.Error("Compare returned unexpected results")
+	}
+	if MustParseDate("2023-12-31").Compare(MustParseDate("2024-01-01")) != -1 {
+		t.Error("Compare across years returned unexpected result")
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	var v struct {
+		Date    Date `json:"Date"`
+		SchDate Date `json:"SchDate"`
+		Nil     Date `json:"Nil"`
+	}
+	if err := json.Unmarshal([]byte(`{"Date":"2024-01-15","SchDate":"","Nil":null}`), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if v.Date != NewDate(2024, time.January, 15) {
+		t.Errorf("Date = %v", v.Date)
+	}
+	if !v.SchDate.IsZero() || !v.Nil.IsZero() {
+		t.Errorf("SchDate = %v, Nil = %v, want zero", v.SchDate, v.Nil)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := string(b); got != `{"Date":"2024-01-15","SchDate":"","Nil":""}` {
+		t.Errorf("Marshal = %s", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Date":"2024-02-30"}`), &v); err == nil {
+		t.Error("expected error for invalid date")
+	}
+	if err := json.Unmarshal([]byte(`{"Date":20240115}`), &v); err == nil {
+		t.Error("expected error for numeric date")
+	}
+
+	// mapのキー（TextMarshaler）としても使用できる
+	m := map[Date]int{NewDate(2024, time.January, 15): 1}
+	b, err = json.Marshal(m)
+	if err != nil || string(b) != `{"2024-01-15":1}` {
+		t.Errorf("Marshal(map) = %s, %v", b, err)
+	}
+}
@@ -0,0 +1,294 @@
+// Package valuation は財務情報（/fins/summary）と日次株価（/equities/bars/daily）を突き合わせ、
+// 営業日ごとのPER・PBR・配当利回りなどの株価指標を求めます。
+//
+// 各営業日には、その日より前に開示された財務情報のみを使用します（先読みを避けるため、当日の開示は翌営業日から反映）。
+// 開示後に株式分割・併合があった場合は、日次株価の調整係数（AdjFactor）で一株あたりの値と株式数を調整し、
+// 調整前の終値と同じ株数基準に揃えます。
+//
+//	svc := valuation.NewService(httpClient)
+//	metrics, err := svc.Metrics(ctx, "7203", types.NewDate(2024, 4, 1), types.NewDate(2025, 3, 31))
+//	for _, m := range metrics {
+//		if m.PER != nil {
+//			fmt.Println(m.Date, *m.PER)
+//		}
+//	}
+package valuation
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+// Metrics は1営業日の時点での株価指標です。求められない指標はnilです。
+type Metrics struct {
+	Date  types.Date // 日付
+	Code  string     // 銘柄コード
+	Close float64    // 終値（調整前）
+
+	StatementDiscDate types.Date // 使用した財務情報のうち最新の開示日
+	StatementDiscNo   string     // 使用した財務情報のうち最新の開示番号
+
+	// 一株あたりの値（当日の株数基準に調整済み）
+	EPS     *float64 // 実績EPS（直近の通期決算短信）
+	FEPS    *float64 // 予想EPS（当事業年度の会社予想）
+	BPS     *float64 // BPS（直近の開示）
+	FDivAnn *float64 // 予想年間配当
+
+	Shares    *float64 // 期末発行済株式数（自己株式を除く。当日の株数基準に調整済み）
+	MarketCap *float64 // 時価総額（円）
+
+	PER           *float64 // 実績PER（終値 / 実績EPS。EPSが正の場合のみ）
+	ForwardPER    *float64 // 予想PER（終値 / 予想EPS。予想EPSが正の場合のみ）
+	PBR           *float64 // PBR（終値 / BPS。BPSが正の場合のみ）
+	DividendYield *float64 // 予想配当利回り（予想年間配当 / 終値）
+	PSR           *float64 // PSR（時価総額 / 実績売上高）
+	EVToOP        *float64 // 簡易EV/営業利益（(時価総額 - 現金及び現金同等物) / 実績営業利益。有利子負債は含まない）
+}
+
+// Service は財務情報と日次株価を取得して株価指標を求めるサービスです。
+type Service struct {
+	quotes     *jquants.QuotesService
+	statements *jquants.StatementsService
+}
+
+// NewService は新しいServiceを作成します。
+func NewService(c client.HTTPClient) *Service {
+	return &Service{
+		quotes:     jquants.NewQuotesService(c),
+		statements: jquants.NewStatementsService(c),
+	}
+}
+
+// Metrics は指定銘柄のfromからtoまでの営業日ごとの株価指標を古い順に返します。
+// 期間の初日時点で使用する財務情報の開示日まで遡って日次株価を取得し、その間の株式分割・併合を調整に使用します。
+func (s *Service) Metrics(ctx context.Context, code string, from, to types.Date) ([]Metrics, error) {
+	if code == "" {
+		return nil, fmt.Errorf("code parameter is required")
+	}
+	if from.IsZero() || to.IsZero() {
+		return nil, fmt.Errorf("from and to parameters are required")
+	}
+
+	statements, err := s.statements.GetAllStatementsByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	sortStatements(statements)
+
+	// 期間の初日時点で参照する開示のうち最も古い開示日から株価を取得する
+	var st state
+	for i := range statements {
+		if !statements[i].DiscDate.Before(from) {
+			break
+		}
+		st.apply(&statements[i], 1)
+	}
+	quoteFrom := from
+	if earliest, ok := st.earliest(); ok && earliest.Before(from) {
+		quoteFrom = earliest
+	}
+
+	quotes, err := s.quotes.GetDailyQuotesByCodeAndDateRange(ctx, code, quoteFrom, to)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := Compute(statements, quotes)
+	i, _ := slices.BinarySearchFunc(metrics, from, func(m Metrics, date types.Date) int {
+		return m.Date.Compare(date)
+	})
+	return metrics[i:], nil
+}
+
+// Compute は1銘柄の財務情報と日次株価から営業日ごとの株価指標を求め、日付の古い順に返します。
+// 終値がない日（売買が成立しなかった日）は含みません。
+//
+// 財務情報は次のように使用します。
+//   - 実績EPS・売上高・営業利益・当期純利益は直近の通期決算短信の値
+//   - 予想EPS・予想年間配当は、通期決算短信の翌期予想、またはそれ以降の四半期決算短信・予想修正の予想のうち最新の値
+//   - BPS・現金及び現金同等物・株式数は値がある直近の開示の値
+//
+// 連結の値がない項目は非連結の値を使用します。
+// 株式分割・併合の調整には、開示日の翌日以降の権利落ち日の調整係数を使用します（開示日以前の分割は開示の値に反映済みとみなします）。
+// quotesが開示日まで遡っていない場合、その間の調整係数は1とみなします。
+func Compute(statements []jquants.Statement, quotes []jquants.DailyQuote) []Metrics {
+	statements = slices.Clone(statements)
+	sortStatements(statements)
+	quotes = slices.Clone(quotes)
+	slices.SortStableFunc(quotes, func(a, b jquants.DailyQuote) int { return a.Date.Compare(b.Date) })
+
+	var (
+		st      state
+		cum     = 1.0
+		next    int
+		metrics []Metrics
+	)
+	for _, q := range quotes {
+		for next < len(statements) && statements[next].DiscDate.Before(q.Date) {
+			st.apply(&statements[next], cum)
+			next++
+		}
+		if q.AdjFactor > 0 {
+			cum *= q.AdjFactor
+		}
+		if q.C == nil || *q.C <= 0 {
+			continue
+		}
+		metrics = append(metrics, st.metrics(&q, cum))
+	}
+	return metrics
+}
+
+func sortStatements(statements []jquants.Statement) {
+	slices.SortStableFunc(statements, func(a, b jquants.Statement) int {
+		return cmp.Or(a.DiscDate.Compare(b.DiscDate), cmp.Compare(a.DiscTime, b.DiscTime), cmp.Compare(a.DiscNo, b.DiscNo))
+	})
+}
+
+// sourced は開示から取り出した値と、開示時点の調整係数の累積値です。
+type sourced struct {
+	value    float64
+	cum      float64
+	discDate types.Date
+}
+
+// perShare は一株あたりの値を、調整係数の累積値がcumの日の株数基準に換算します。
+func (v *sourced) perShare(cum float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	x := v.value * cum / v.cum
+	return &x
+}
+
+// shares は株式数を、調整係数の累積値がcumの日の株数基準に換算します。
+func (v *sourced) shares(cum float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	x := v.value * v.cum / cum
+	return &x
+}
+
+// state はある時点で参照できる財務情報です。
+type state struct {
+	discDate types.Date
+	discNo   string
+
+	eps, feps, bps, fdiv, shares *sourced
+	sales, op, np, cashEq        *sourced
+}
+
+// apply は開示stを反映します。cumは開示時点の調整係数の累積値です。
+func (s *state) apply(st *jquants.Statement, cum float64) {
+	src := func(v *float64) *sourced {
+		if v == nil {
+			return nil
+		}
+		return &sourced{value: *v, cum: cum, discDate: st.DiscDate}
+	}
+	set := func(dst **sourced, v *float64) {
+		if v != nil {
+			*dst = src(v)
+		}
+	}
+
+	annual := st.CurPerType == "FY" && !st.DocType.IsForecastRevision()
+	if annual {
+		set(&s.eps, or(st.EPS, st.NCEPS))
+		set(&s.sales, or(st.Sales, st.NCSales))
+		set(&s.op, or(st.OP, st.NCOP))
+		set(&s.np, or(st.NP, st.NCNP))
+		// 翌期予想がない場合は終了した事業年度の予想を使用しない
+		s.feps = src(or(st.NxFEPS, st.NxFNCEPS))
+		s.fdiv = src(st.NxFDivAnn)
+	} else {
+		set(&s.feps, or(st.FEPS, st.FNCEPS))
+		set(&s.fdiv, st.FDivAnn)
+	}
+	set(&s.bps, or(st.BPS, st.NCBPS))
+	set(&s.cashEq, st.CashEq)
+	if st.ShOutFY != nil {
+		shares := float64(*st.ShOutFY)
+		if st.TrShFY != nil {
+			shares -= float64(*st.TrShFY)
+		}
+		set(&s.shares, &shares)
+	}
+	s.discDate = st.DiscDate
+	s.discNo = st.DiscNo
+}
+
+// earliest は参照している値のうち最も古い開示日を返します。
+func (s *state) earliest() (types.Date, bool) {
+	var earliest types.Date
+	for _, v := range []*sourced{s.eps, s.feps, s.bps, s.fdiv, s.shares, s.sales, s.op, s.np, s.cashEq} {
+		if v != nil && (earliest.IsZero() || v.discDate.Before(earliest)) {
+			earliest = v.discDate
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// metrics は日次株価qの日の株価指標を求めます。cumはその日までの調整係数の累積値です。
+func (s *state) metrics(q *jquants.DailyQuote, cum float64) Metrics {
+	price := *q.C
+	m := Metrics{
+		Date:              q.Date,
+		Code:              q.Code,
+		Close:             price,
+		StatementDiscDate: s.discDate,
+		StatementDiscNo:   s.discNo,
+		EPS:               s.eps.perShare(cum),
+		FEPS:              s.feps.perShare(cum),
+		BPS:               s.bps.perShare(cum),
+		FDivAnn:           s.fdiv.perShare(cum),
+		Shares:            s.shares.shares(cum),
+	}
+
+	if q.MktCap != nil {
+		mc := *q.MktCap * 1e6 // 百万円単位
+		m.MarketCap = &mc
+	} else if m.Shares != nil {
+		mc := price * *m.Shares
+		m.MarketCap = &mc
+	}
+
+	m.PER = ratio(&price, m.EPS)
+	m.ForwardPER = ratio(&price, m.FEPS)
+	m.PBR = ratio(&price, m.BPS)
+	if m.FDivAnn != nil {
+		y := *m.FDivAnn / price
+		m.DividendYield = &y
+	}
+	if s.sales != nil {
+		m.PSR = ratio(m.MarketCap, &s.sales.value)
+	}
+	if m.MarketCap != nil && s.cashEq != nil && s.op != nil {
+		ev := *m.MarketCap - s.cashEq.value
+		m.EVToOP = ratio(&ev, &s.op.value)
+	}
+	return m
+}
+
+// ratio はa/bを返します。いずれかがnil、またはbが正でない場合はnilを返します。
+func ratio(a, b *float64) *float64 {
+	if a == nil || b == nil || *b <= 0 {
+		return nil
+	}
+	x := *a / *b
+	return &x
+}
+
+func or(a, b *float64) *float64 {
+	if a != nil {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,135 @@
+package valuation
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testStatements() []jquants.Statement {
+	return []jquants.Statement{
+		// 四半期決算短信（分割後の開示。予想EPSは分割後の株数基準）
+		{DiscDate: types.MustParseDate("2024-07-30"), DiscTime: "15:00", Code: "86970", DiscNo: "20240730000001", DocType: jquants.TypeOfDocument1QConsolidatedJP,
+			CurPerType: "1Q", CurFYEn: "2025-03-31", FEPS: ptr(65.0)},
+		// 通期決算短信
+		{DiscDate: types.MustParseDate("2024-04-25"), DiscTime: "15:00", Code: "86970", DiscNo: "20240425000001", DocType: jquants.TypeOfDocumentFYConsolidatedJP,
+			CurPerType: "FY", CurFYEn: "2024-03-31", NxtFYEn: "2025-03-31",
+			Sales: ptr(1e9), OP: ptr(1e8), NP: ptr(1e8), EPS: ptr(100.0), BPS: ptr(1000.0), CashEq: ptr(2e7),
+			NxFEPS: ptr(120.0), NxFDivAnn: ptr(40.0), ShOutFY: ptr(int64(1_100_000)), TrShFY: ptr(int64(100_000))},
+	}
+}
+
+func testQuotes() []jquants.DailyQuote {
+	return []jquants.DailyQuote{
+		{Date: types.MustParseDate("2024-04-25"), Code: "86970", C: ptr(1000.0), AdjFactor: 1},
+		{Date: types.MustParseDate("2024-04-26"), Code: "86970", C: ptr(1200.0), AdjFactor: 1, MktCap: ptr(1200.0)},
+		{Date: types.MustParseDate("2024-05-01"), Code: "86970", C: ptr(600.0), AdjFactor: 0.5}, // 1:2の株式分割
+		{Date: types.MustParseDate("2024-05-02"), Code: "86970", AdjFactor: 1},                  // 売買不成立
+		{Date: types.MustParseDate("2024-07-30"), Code: "86970", C: ptr(650.0), AdjFactor: 1},
+		{Date: types.MustParseDate("2024-07-31"), Code: "86970", C: ptr(650.0), AdjFactor: 1},
+	}
+}
+
+func approx(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("%s = nil, want %v", name, want)
+		return
+	}
+	if math.Abs(*got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, *got, want)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	metrics := Compute(testStatements(), testQuotes())
+	if len(metrics) != 5 {
+		t.Fatalf("len(metrics) = %d, want 5", len(metrics))
+	}
+
+	// 開示当日は反映しない
+	if m := metrics[0]; m.EPS != nil || m.PER != nil || m.StatementDiscNo != "" {
+		t.Errorf("metrics[0] = %+v", m)
+	}
+
+	m := metrics[1]
+	if m.StatementDiscNo != "20240425000001" {
+		t.Errorf("StatementDiscNo = %q", m.StatementDiscNo)
+	}
+	approx(t, "PER", m.PER, 12)
+	approx(t, "ForwardPER", m.ForwardPER, 10)
+	approx(t, "PBR", m.PBR, 1.2)
+	approx(t, "DividendYield", m.DividendYield, 40.0/1200)
+	approx(t, "MarketCap (MktCap)", m.MarketCap, 1.2e9)
+	approx(t, "PSR", m.PSR, 1.2)
+	approx(t, "EVToOP", m.EVToOP, (1.2e9-2e7)/1e8)
+
+	// 分割後は一株あたりの値と株式数を分割後の基準に換算する
+	m = metrics[2]
+	approx(t, "split EPS", m.EPS, 50)
+	approx(t, "split PER", m.PER, 12)
+	approx(t, "split BPS", m.BPS, 500)
+	approx(t, "split FDivAnn", m.FDivAnn, 20)
+	approx(t, "split Shares", m.Shares, 2_000_000)
+	approx(t, "split MarketCap", m.MarketCap, 1.2e9)
+
+	// 1Qの予想EPSは開示の翌営業日から反映し、分割後の開示のため調整しない
+	approx(t, "before 1Q ForwardPER", metrics[3].ForwardPER, 650.0/60)
+	approx(t, "after 1Q ForwardPER", metrics[4].ForwardPER, 10)
+	approx(t, "after 1Q EPS", metrics[4].EPS, 50)
+	if metrics[4].StatementDiscDate.String() != "2024-07-30" {
+		t.Errorf("StatementDiscDate = %q", metrics[4].StatementDiscDate)
+	}
+}
+
+func TestCompute_NoForecastAfterAnnual(t *testing.T) {
+	statements := []jquants.Statement{
+		{DiscDate: types.MustParseDate("2024-02-01"), DiscNo: "1", CurPerType: "3Q", CurFYEn: "2024-03-31", FEPS: ptr(100.0), EPS: ptr(-10.0)},
+		{DiscDate: types.MustParseDate("2024-04-25"), DiscNo: "2", CurPerType: "FY", CurFYEn: "2024-03-31", EPS: ptr(-5.0)},
+	}
+	quotes := []jquants.DailyQuote{
+		{Date: types.MustParseDate("2024-02-02"), C: ptr(1000.0)},
+		{Date: types.MustParseDate("2024-04-26"), C: ptr(1000.0)},
+	}
+	metrics := Compute(statements, quotes)
+	approx(t, "ForwardPER", metrics[0].ForwardPER, 10)
+	if metrics[0].EPS != nil {
+		t.Error("quarterly EPS should not be used")
+	}
+	// 翌期予想がない通期決算短信の後は予想を使用しない。EPSが負の場合PERはnil
+	if metrics[1].FEPS != nil || metrics[1].PER != nil {
+		t.Errorf("metrics[1] = %+v", metrics[1])
+	}
+	approx(t, "EPS", metrics[1].EPS, -5)
+}
+
+func TestService_Metrics(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse("GET", "/fins/summary?code=86970", jquants.StatementsResponse{Data: testStatements()})
+	// 期間の初日時点で参照する通期決算短信の開示日から株価を取得する
+	mockClient.SetResponse("GET", "/equities/bars/daily?code=86970&from=20240425&to=20240731", jquants.DailyQuotesResponse{Data: testQuotes()})
+	svc := NewService(mockClient)
+
+	metrics, err := svc.Metrics(context.Background(), "8697", types.MustParseDate("2024-05-01"), types.MustParseDate("2024-07-31"))
+	if err != nil {
+		t.Fatalf("Metrics() error = %v", err)
+	}
+	if len(metrics) != 3 || metrics[0].Date.String() != "2024-05-01" {
+		t.Fatalf("metrics = %+v", metrics)
+	}
+	approx(t, "EPS", metrics[0].EPS, 50)
+
+	from, to := types.MustParseDate("2024-05-01"), types.MustParseDate("2024-07-31")
+	if _, err := svc.Metrics(context.Background(), "", from, to); err == nil {
+		t.Error("Metrics(no code) expected error")
+	}
+	if _, err := svc.Metrics(context.Background(), "8697", types.Date{}, to); err == nil {
+		t.Error("Metrics(no from) expected error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 
 	"github.com/utahta/jquants/client"
 	"github.com/utahta/jquants/types"
@@ -21,11 +22,11 @@ func NewWeeklyMarginInterestService(c client.HTTPClient) *WeeklyMarginInterestSe
 
 // WeeklyMarginInterestParams は信用取引週末残高のリクエストパラメータです。
 type WeeklyMarginInterestParams struct {
-	Code          string // 銘柄コード（codeまたはdateのいずれかが必須）
-	Date          string // 申込日付（YYYYMMDD または YYYY-MM-DD）（codeまたはdateのいずれかが必須）
-	From          string // 期間の開始日（YYYYMMDD または YYYY-MM-DD）
-	To            string // 期間の終了日（YYYYMMDD または YYYY-MM-DD）
-	PaginationKey string // ページネーションキー
+	Code          string     // 銘柄コード（codeまたはdateのいずれかが必須）
+	Date          types.Date // 申込日付（codeまたはdateのいずれかが必須）
+	From          types.Date // 期間の開始日
+	To            types.Date // 期間の終了日
+	PaginationKey string     // ページネーションキー
 }
 
 // WeeklyMarginInterestResponse は信用取引週末残高のレスポンスです。
@@ -45,9 +46,9 @@ const (
 // J-Quants API /markets/margin-interest エンドポイントのレスポンスデータ。
 type WeeklyMarginInterest struct {
 	// 基本情報
-	Date    string `json:"Date"`    // 申込日付（YYYY-MM-DD形式）
-	Code    string `json:"Code"`    // 銘柄コード
-	IssType string `json:"IssType"` // 銘柄区分（1: 信用銘柄、2: 貸借銘柄、3: その他）
+	Date    types.Date `json:"Date"`    // 申込日付
+	Code    string     `json:"Code"`    // 銘柄コード
+	IssType string     `json:"IssType"` // 銘柄区分（1: 信用銘柄、2: 貸借銘柄、3: その他）
 
 	// 信用取引残高（売建）
 	ShrtVol    float64 `json:"ShrtVol"`    // 売合計信用取引週末残高
@@ -63,9 +64,9 @@ type WeeklyMarginInterest struct {
 // RawWeeklyMarginInterest is used for unmarshaling JSON response with mixed types
 type RawWeeklyMarginInterest struct {
 	// 基本情報
-	Date    string `json:"Date"`
-	Code    string `json:"Code"`
-	IssType string `json:"IssType"`
+	Date    types.Date `json:"Date"`
+	Code    string     `json:"Code"`
+	IssType string     `json:"IssType"`
 
 	// 信用取引残高（売建）
 	ShrtVol    types.NullableFloat64 `json:"ShrtVol"`
@@ -121,32 +122,23 @@ func (r *WeeklyMarginInterestResponse) UnmarshalJSON(data []byte) error {
 // GetWeeklyMarginInterest は信用取引週末残高を取得します。
 func (s *WeeklyMarginInterestService) GetWeeklyMarginInterest(ctx context.Context, params WeeklyMarginInterestParams) (*WeeklyMarginInterestResponse, error) {
 	// codeまたはdateのいずれかが必須
-	if params.Code == "" && params.Date == "" {
+	if params.Code == "" && params.Date.IsZero() {
 		return nil, fmt.Errorf("either code or date parameter is required")
 	}
 
 	path := "/markets/margin-interest"
 
-	query := "?"
-	if params.Code != "" {
-		query += fmt.Sprintf("code=%s&", params.Code)
-	}
-	if params.Date != "" {
-		query += fmt.Sprintf("date=%s&", params.Date)
-	}
-	if params.From != "" {
-		query += fmt.Sprintf("from=%s&", params.From)
-	}
-	if params.To != "" {
-		query += fmt.Sprintf("to=%s&", params.To)
-	}
-	if params.PaginationKey != "" {
-		query += fmt.Sprintf("pagination_key=%s&", params.PaginationKey)
-	}
+	query := newQuery()
+	query.setCode("code", params.Code)
+	query.setDate("date", params.Date)
+	query.setDate("from", params.From)
+	query.setDate("to", params.To)
+	query.set("pagination_key", params.PaginationKey)
 
-	if len(query) > 1 {
-		path += query[:len(query)-1] // Remove trailing &
+	if err := query.err(); err != nil {
+		return nil, err
 	}
+	path += query.encode()
 
 	var resp WeeklyMarginInterestResponse
 	if err := s.client.DoRequest(ctx, "GET", path, nil, &resp); err != nil {
@@ -156,96 +148,45 @@ func (s *WeeklyMarginInterestService) GetWeeklyMarginInterest(ctx context.Contex
 	return &resp, nil
 }
 
-// GetWeeklyMarginInterestByCode は指定銘柄の信用取引週末残高を取得します。
-// ページネーションを使用して全データを取得します。
-func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCode(ctx context.Context, code string) ([]WeeklyMarginInterest, error) {
-	var allData []WeeklyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := WeeklyMarginInterestParams{
-			Code:          code,
-			PaginationKey: paginationKey,
-		}
-
+// AllWeeklyMarginInterest は指定条件の信用取引週末残高を1件ずつ返すイテレータです。
+func (s *WeeklyMarginInterestService) AllWeeklyMarginInterest(ctx context.Context, params WeeklyMarginInterestParams) iter.Seq2[WeeklyMarginInterest, error] {
+	return paginate(ctx, params.PaginationKey, func(ctx context.Context, paginationKey string) ([]WeeklyMarginInterest, string, error) {
+		params.PaginationKey = paginationKey
 		resp, err := s.GetWeeklyMarginInterest(ctx, params)
 		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
+			return nil, "", err
 		}
-		paginationKey = resp.PaginationKey
-	}
+		return resp.Data, resp.PaginationKey, nil
+	})
+}
 
-	return allData, nil
+// GetWeeklyMarginInterestByCode は指定銘柄の信用取引週末残高を取得します。
+// ページネーションを使用して全データを取得します。
+func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCode(ctx context.Context, code string) ([]WeeklyMarginInterest, error) {
+	return collectAll(s.AllWeeklyMarginInterest(ctx, WeeklyMarginInterestParams{
+		Code: code,
+	}))
 }
 
 // GetWeeklyMarginInterestByDate は指定日の全銘柄信用取引週末残高を取得します。
 // ページネーションを使用して全データを取得します。
-func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByDate(ctx context.Context, date string) ([]WeeklyMarginInterest, error) {
-	var allData []WeeklyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := WeeklyMarginInterestParams{
-			Date:          date,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetWeeklyMarginInterest(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByDate(ctx context.Context, date types.Date) ([]WeeklyMarginInterest, error) {
+	return collectAll(s.AllWeeklyMarginInterest(ctx, WeeklyMarginInterestParams{
+		Date: date,
+	}))
 }
 
 // GetWeeklyMarginInterestByCodeAndDateRange は指定銘柄・期間の信用取引週末残高を取得します。
-func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCodeAndDateRange(ctx context.Context, code, from, to string) ([]WeeklyMarginInterest, error) {
-	var allData []WeeklyMarginInterest
-	paginationKey := ""
-
-	for {
-		params := WeeklyMarginInterestParams{
-			Code:          code,
-			From:          from,
-			To:            to,
-			PaginationKey: paginationKey,
-		}
-
-		resp, err := s.GetWeeklyMarginInterest(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, resp.Data...)
-
-		// ページネーションキーがなければ終了
-		if resp.PaginationKey == "" {
-			break
-		}
-		paginationKey = resp.PaginationKey
-	}
-
-	return allData, nil
+func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCodeAndDateRange(ctx context.Context, code string, from, to types.Date) ([]WeeklyMarginInterest, error) {
+	return collectAll(s.AllWeeklyMarginInterest(ctx, WeeklyMarginInterestParams{
+		Code: code,
+		From: from,
+		To:   to,
+	}))
 }
 
 // GetWeeklyMarginInterestByCodeAndDate は指定銘柄の指定公表日の信用取引週末残高を取得します。
-func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCodeAndDate(ctx context.Context, code, date string) ([]WeeklyMarginInterest, error) {
+func (s *WeeklyMarginInterestService) GetWeeklyMarginInterestByCodeAndDate(ctx context.Context, code string, date types.Date) ([]WeeklyMarginInterest, error) {
 	resp, err := s.GetWeeklyMarginInterest(ctx, WeeklyMarginInterestParams{
 		Code: code,
 		Date: date,
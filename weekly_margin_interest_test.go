@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/utahta/jquants/client"
+	"github.com/utahta/jquants/types"
 )
 
 func TestWeeklyMarginInterestService_GetWeeklyMarginInterest(t *testing.T) {
@@ -18,8 +19,8 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterest(t *testing.T) {
 			name: "with code and date range",
 			params: WeeklyMarginInterestParams{
 				Code: "13010",
-				From: "20230101",
-				To:   "20230331",
+				From: types.MustParseDate("20230101"),
+				To:   types.MustParseDate("20230331"),
 			},
 			wantPath: "/markets/margin-interest?code=13010&from=20230101&to=20230331",
 		},
@@ -33,14 +34,14 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterest(t *testing.T) {
 		{
 			name: "with date only",
 			params: WeeklyMarginInterestParams{
-				Date: "20230217",
+				Date: types.MustParseDate("20230217"),
 			},
 			wantPath: "/markets/margin-interest?date=20230217",
 		},
 		{
 			name: "with date and pagination key",
 			params: WeeklyMarginInterestParams{
-				Date:          "20230217",
+				Date:          types.MustParseDate("20230217"),
 				PaginationKey: "key123",
 			},
 			wantPath: "/markets/margin-interest?date=20230217&pagination_key=key123",
@@ -57,7 +58,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterest(t *testing.T) {
 			mockResponse := WeeklyMarginInterestResponse{
 				Data: []WeeklyMarginInterest{
 					{
-						Date:       "2023-02-17",
+						Date:       types.MustParseDate("2023-02-17"),
 						Code:       "13010",
 						IssType:    "2",
 						ShrtVol:    4100.0,
@@ -119,14 +120,14 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCode(t *testing.T)
 	mockResponse1 := WeeklyMarginInterestResponse{
 		Data: []WeeklyMarginInterest{
 			{
-				Date:    "2023-02-17",
+				Date:    types.MustParseDate("2023-02-17"),
 				Code:    "13010",
 				IssType: "2",
 				ShrtVol: 4100.0,
 				LongVol: 27600.0,
 			},
 			{
-				Date:    "2023-02-10",
+				Date:    types.MustParseDate("2023-02-10"),
 				Code:    "13010",
 				IssType: "2",
 				ShrtVol: 3900.0,
@@ -140,7 +141,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCode(t *testing.T)
 	mockResponse2 := WeeklyMarginInterestResponse{
 		Data: []WeeklyMarginInterest{
 			{
-				Date:    "2023-02-03",
+				Date:    types.MustParseDate("2023-02-03"),
 				Code:    "13010",
 				IssType: "2",
 				ShrtVol: 3800.0,
@@ -179,14 +180,14 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByDate(t *testing.T)
 	mockResponse := WeeklyMarginInterestResponse{
 		Data: []WeeklyMarginInterest{
 			{
-				Date:    "2023-02-17",
+				Date:    types.MustParseDate("2023-02-17"),
 				Code:    "13010",
 				IssType: "2",
 				ShrtVol: 4100.0,
 				LongVol: 27600.0,
 			},
 			{
-				Date:    "2023-02-17",
+				Date:    types.MustParseDate("2023-02-17"),
 				Code:    "86970",
 				IssType: "1",
 				ShrtVol: 2300.0,
@@ -198,7 +199,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByDate(t *testing.T)
 	mockClient.SetResponse("GET", "/markets/margin-interest?date=20230217", mockResponse)
 
 	// Execute
-	data, err := service.GetWeeklyMarginInterestByDate(context.Background(), "20230217")
+	data, err := service.GetWeeklyMarginInterestByDate(context.Background(), types.MustParseDate("20230217"))
 
 	// Verify
 	if err != nil {
@@ -208,7 +209,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByDate(t *testing.T)
 		t.Errorf("GetWeeklyMarginInterestByDate() returned %d items, want 2", len(data))
 	}
 	for _, item := range data {
-		if item.Date != "2023-02-17" {
+		if item.Date.String() != "2023-02-17" {
 			t.Errorf("GetWeeklyMarginInterestByDate() returned date %v, want 2023-02-17", item.Date)
 		}
 	}
@@ -223,14 +224,14 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCodeAndDateRange(t
 	mockResponse := WeeklyMarginInterestResponse{
 		Data: []WeeklyMarginInterest{
 			{
-				Date:    "2023-02-17",
+				Date:    types.MustParseDate("2023-02-17"),
 				Code:    "86970",
 				IssType: "1",
 				ShrtVol: 2300.0,
 				LongVol: 15400.0,
 			},
 			{
-				Date:    "2023-02-10",
+				Date:    types.MustParseDate("2023-02-10"),
 				Code:    "86970",
 				IssType: "1",
 				ShrtVol: 2200.0,
@@ -242,7 +243,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCodeAndDateRange(t
 	mockClient.SetResponse("GET", "/markets/margin-interest?code=86970&from=20230101&to=20230331", mockResponse)
 
 	// Execute
-	data, err := service.GetWeeklyMarginInterestByCodeAndDateRange(context.Background(), "86970", "20230101", "20230331")
+	data, err := service.GetWeeklyMarginInterestByCodeAndDateRange(context.Background(), "86970", types.MustParseDate("20230101"), types.MustParseDate("20230331"))
 
 	// Verify
 	if err != nil {
@@ -267,7 +268,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCodeAndDate(t *tes
 	mockResponse := WeeklyMarginInterestResponse{
 		Data: []WeeklyMarginInterest{
 			{
-				Date:    "2023-03-24",
+				Date:    types.MustParseDate("2023-03-24"),
 				Code:    "86970",
 				IssType: "1",
 				ShrtVol: 2300.0,
@@ -279,7 +280,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCodeAndDate(t *tes
 	mockClient.SetResponse("GET", "/markets/margin-interest?code=86970&date=20230324", mockResponse)
 
 	// Execute
-	data, err := service.GetWeeklyMarginInterestByCodeAndDate(context.Background(), "86970", "20230324")
+	data, err := service.GetWeeklyMarginInterestByCodeAndDate(context.Background(), "86970", types.MustParseDate("20230324"))
 
 	// Verify
 	if err != nil {
@@ -291,7 +292,7 @@ func TestWeeklyMarginInterestService_GetWeeklyMarginInterestByCodeAndDate(t *tes
 	if data[0].Code != "86970" {
 		t.Errorf("GetWeeklyMarginInterestByCodeAndDate() returned code %v, want 86970", data[0].Code)
 	}
-	if data[0].Date != "2023-03-24" {
+	if data[0].Date.String() != "2023-03-24" {
 		t.Errorf("GetWeeklyMarginInterestByCodeAndDate() returned date %v, want 2023-03-24", data[0].Date)
 	}
 }
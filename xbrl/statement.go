@@ -0,0 +1,277 @@
+package xbrl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/utahta/jquants"
+	"github.com/utahta/jquants/types"
+)
+
+// periodKind はコンテキストIDの先頭部分（"CurrentYearDuration" など）から判定した期間の種類です。
+type periodKind int
+
+const (
+	periodUnknown        periodKind = iota
+	periodCurrent                   // 当期（当事業年度または当四半期累計期間）の期間
+	periodCurrentInstant            // 当期末の時点
+	periodCurrentYear               // 当事業年度（予想）
+	periodCurrentQ2                 // 当事業年度の第2四半期累計期間（予想）
+	periodNextYear                  // 翌事業年度（予想）
+	periodNextQ2                    // 翌事業年度の第2四半期累計期間（予想）
+)
+
+// statementField は連結・非連結それぞれの対応するStatementのフィールドです。
+type statementField struct {
+	cons    func(*jquants.Statement) **float64
+	nonCons func(*jquants.Statement) **float64
+}
+
+func field(cons, nonCons func(*jquants.Statement) **float64) statementField {
+	return statementField{cons: cons, nonCons: nonCons}
+}
+
+type tagPeriod struct {
+	tag    string
+	period periodKind
+}
+
+// statementFields は決算短信サマリーの要素名（接頭辞、IFRS・USの接尾辞を除く）と期間から、
+// Statementのフィールドへの対応です。期間が当期のものは実績、それ以外は予想の値です。
+var statementFields = map[tagPeriod]statementField{
+	{"NetSales", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.Sales }, func(s *jquants.Statement) **float64 { return &s.NCSales }),
+	{"NetSales", periodCurrentYear}: field(func(s *jquants.Statement) **float64 { return &s.FSales }, func(s *jquants.Statement) **float64 { return &s.FNCSales }),
+	{"NetSales", periodCurrentQ2}:   field(func(s *jquants.Statement) **float64 { return &s.FSales2Q }, func(s *jquants.Statement) **float64 { return &s.FNCSales2Q }),
+	{"NetSales", periodNextYear}:    field(func(s *jquants.Statement) **float64 { return &s.NxFSales }, func(s *jquants.Statement) **float64 { return &s.NxFNCSales }),
+	{"NetSales", periodNextQ2}:      field(func(s *jquants.Statement) **float64 { return &s.NxFSales2Q }, func(s *jquants.Statement) **float64 { return &s.NxFNCSales2Q }),
+
+	{"OperatingIncome", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.OP }, func(s *jquants.Statement) **float64 { return &s.NCOP }),
+	{"OperatingIncome", periodCurrentYear}: field(func(s *jquants.Statement) **float64 { return &s.FOP }, func(s *jquants.Statement) **float64 { return &s.FNCOP }),
+	{"OperatingIncome", periodCurrentQ2}:   field(func(s *jquants.Statement) **float64 { return &s.FOP2Q }, func(s *jquants.Statement) **float64 { return &s.FNCOP2Q }),
+	{"OperatingIncome", periodNextYear}:    field(func(s *jquants.Statement) **float64 { return &s.NxFOP }, func(s *jquants.Statement) **float64 { return &s.NxFNCOP }),
+	{"OperatingIncome", periodNextQ2}:      field(func(s *jquants.Statement) **float64 { return &s.NxFOP2Q }, func(s *jquants.Statement) **float64 { return &s.NxFNCOP2Q }),
+
+	{"OrdinaryIncome", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.OdP }, func(s *jquants.Statement) **float64 { return &s.NCOdP }),
+	{"OrdinaryIncome", periodCurrentYear}: field(func(s *jquants.Statement) **float64 { return &s.FOdP }, func(s *jquants.Statement) **float64 { return &s.FNCOdP }),
+	{"OrdinaryIncome", periodCurrentQ2}:   field(func(s *jquants.Statement) **float64 { return &s.FOdP2Q }, func(s *jquants.Statement) **float64 { return &s.FNCOdP2Q }),
+	{"OrdinaryIncome", periodNextYear}:    field(func(s *jquants.Statement) **float64 { return &s.NxFOdP }, func(s *jquants.Statement) **float64 { return &s.NxFNCOdP }),
+	{"OrdinaryIncome", periodNextQ2}:      field(func(s *jquants.Statement) **float64 { return &s.NxFOdP2Q }, func(s *jquants.Statement) **float64 { return &s.NxFNCOdP2Q }),
+
+	// 連結は親会社株主に帰属する当期純利益、非連結は当期純利益
+	{"ProfitAttributableToOwnersOfParent", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.NP }, nil),
+	{"ProfitAttributableToOwnersOfParent", periodCurrentYear}: field(func(s *jquants.Statement) **float64 { return &s.FNP }, nil),
+	{"ProfitAttributableToOwnersOfParent", periodCurrentQ2}:   field(func(s *jquants.Statement) **float64 { return &s.FNP2Q }, nil),
+	{"ProfitAttributableToOwnersOfParent", periodNextYear}:    field(func(s *jquants.Statement) **float64 { return &s.NxFNp }, nil),
+	{"ProfitAttributableToOwnersOfParent", periodNextQ2}:      field(func(s *jquants.Statement) **float64 { return &s.NxFNp2Q }, nil),
+	{"NetIncome", periodCurrent}:                              field(nil, func(s *jquants.Statement) **float64 { return &s.NCNP }),
+	{"NetIncome", periodCurrentYear}:                          field(nil, func(s *jquants.Statement) **float64 { return &s.FNCNP }),
+	{"NetIncome", periodCurrentQ2}:                            field(nil, func(s *jquants.Statement) **float64 { return &s.FNCNP2Q }),
+	{"NetIncome", periodNextYear}:                             field(nil, func(s *jquants.Statement) **float64 { return &s.NxFNCNP }),
+	{"NetIncome", periodNextQ2}:                               field(nil, func(s *jquants.Statement) **float64 { return &s.NxFNCNP2Q }),
+
+	{"NetIncomePerShare", periodCurrent}:        field(func(s *jquants.Statement) **float64 { return &s.EPS }, func(s *jquants.Statement) **float64 { return &s.NCEPS }),
+	{"NetIncomePerShare", periodCurrentYear}:    field(func(s *jquants.Statement) **float64 { return &s.FEPS }, func(s *jquants.Statement) **float64 { return &s.FNCEPS }),
+	{"NetIncomePerShare", periodCurrentQ2}:      field(func(s *jquants.Statement) **float64 { return &s.FEPS2Q }, func(s *jquants.Statement) **float64 { return &s.FNCEPS2Q }),
+	{"NetIncomePerShare", periodNextYear}:       field(func(s *jquants.Statement) **float64 { return &s.NxFEPS }, func(s *jquants.Statement) **float64 { return &s.NxFNCEPS }),
+	{"NetIncomePerShare", periodNextQ2}:         field(func(s *jquants.Statement) **float64 { return &s.NxFEPS2Q }, func(s *jquants.Statement) **float64 { return &s.NxFNCEPS2Q }),
+	{"DilutedNetIncomePerShare", periodCurrent}: field(func(s *jquants.Statement) **float64 { return &s.DEPS }, nil),
+
+	{"TotalAssets", periodCurrentInstant}:          field(func(s *jquants.Statement) **float64 { return &s.TA }, func(s *jquants.Statement) **float64 { return &s.NCTA }),
+	{"NetAssets", periodCurrentInstant}:            field(func(s *jquants.Statement) **float64 { return &s.Eq }, func(s *jquants.Statement) **float64 { return &s.NCEq }),
+	{"OwnersEquity", periodCurrentInstant}:         field(func(s *jquants.Statement) **float64 { return &s.ShEq }, func(s *jquants.Statement) **float64 { return &s.NCShEq }),
+	{"CapitalAdequacyRatio", periodCurrentInstant}: field(func(s *jquants.Statement) **float64 { return &s.EqAR }, func(s *jquants.Statement) **float64 { return &s.NCEqAR }),
+	{"NetAssetsPerShare", periodCurrentInstant}:    field(func(s *jquants.Statement) **float64 { return &s.BPS }, func(s *jquants.Statement) **float64 { return &s.NCBPS }),
+
+	{"CashFlowsFromOperatingActivities", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.CFO }, nil),
+	{"CashFlowsFromInvestingActivities", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.CFI }, nil),
+	{"CashFlowsFromFinancingActivities", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.CFF }, nil),
+	{"CashAndEquivalentsEndOfPeriod", periodCurrentInstant}: field(func(s *jquants.Statement) **float64 { return &s.CashEq }, nil),
+
+	{"PayoutRatio", periodCurrent}:     field(func(s *jquants.Statement) **float64 { return &s.PayoutRatioAn }, nil),
+	{"PayoutRatio", periodCurrentYear}: field(func(s *jquants.Statement) **float64 { return &s.FPayoutRatioAn }, nil),
+	{"PayoutRatio", periodNextYear}:    field(func(s *jquants.Statement) **float64 { return &s.NxFPayoutRatioAn }, nil),
+}
+
+// dividendFields は一株当たり配当金（DividendPerShare）の期間と配当基準日のメンバーから、Statementのフィールドへの対応です。
+var dividendFields = map[periodKind]map[string]func(*jquants.Statement) **float64{
+	periodCurrent: {
+		"FirstQuarterMember":  func(s *jquants.Statement) **float64 { return &s.Div1Q },
+		"SecondQuarterMember": func(s *jquants.Statement) **float64 { return &s.Div2Q },
+		"ThirdQuarterMember":  func(s *jquants.Statement) **float64 { return &s.Div3Q },
+		"YearEndMember":       func(s *jquants.Statement) **float64 { return &s.DivFY },
+		"AnnualMember":        func(s *jquants.Statement) **float64 { return &s.DivAnn },
+	},
+	periodCurrentYear: {
+		"FirstQuarterMember":  func(s *jquants.Statement) **float64 { return &s.FDiv1Q },
+		"SecondQuarterMember": func(s *jquants.Statement) **float64 { return &s.FDiv2Q },
+		"ThirdQuarterMember":  func(s *jquants.Statement) **float64 { return &s.FDiv3Q },
+		"YearEndMember":       func(s *jquants.Statement) **float64 { return &s.FDivFY },
+		"AnnualMember":        func(s *jquants.Statement) **float64 { return &s.FDivAnn },
+	},
+	periodNextYear: {
+		"FirstQuarterMember":  func(s *jquants.Statement) **float64 { return &s.NxFDiv1Q },
+		"SecondQuarterMember": func(s *jquants.Statement) **float64 { return &s.NxFDiv2Q },
+		"ThirdQuarterMember":  func(s *jquants.Statement) **float64 { return &s.NxFDiv3Q },
+		"YearEndMember":       func(s *jquants.Statement) **float64 { return &s.NxFDivFY },
+		"AnnualMember":        func(s *jquants.Statement) **float64 { return &s.NxFDivAnn },
+	},
+}
+
+// shareFields は株式数の要素名と期間から、Statementのフィールドへの対応です。
+// 期末の株式数は時点、期中平均株式数は期間のコンテキストで開示されます。
+var shareFields = map[tagPeriod]func(*jquants.Statement) **int64{
+	{"NumberOfIssuedAndOutstandingSharesAtTheEndOfFiscalYearIncludingTreasuryStock", periodCurrentInstant}: func(s *jquants.Statement) **int64 { return &s.ShOutFY },
+	{"NumberOfTreasuryStockAtTheEndOfFiscalYear", periodCurrentInstant}:                                    func(s *jquants.Statement) **int64 { return &s.TrShFY },
+	{"AverageNumberOfShares", periodCurrent}:                                                               func(s *jquants.Statement) **int64 { return &s.AvgSh },
+}
+
+// Statement は決算短信サマリーのドキュメントを読み込み、Statementに変換します。
+func (p *Package) Statement() (*jquants.Statement, error) {
+	doc, err := p.Summary()
+	if err != nil {
+		return nil, err
+	}
+	return doc.Statement(), nil
+}
+
+// Statement は決算短信サマリー（tse-ed-t）のファクトをStatementの対応するフィールドに設定して返します。
+//
+// 期間はコンテキストIDの先頭部分（CurrentYearDuration、CurrentAccumulatedQ2Duration、
+// NextYearDuration など）で判定し、ResultMember・ForecastMember で実績と予想を、
+// NonConsolidatedMember で非連結の値を区別します。予想が範囲（UpperMember・LowerMember）で
+// 開示されている場合は設定しません。IFRS・米国基準の要素（接尾辞 IFRS・US）は日本基準と同じ
+// フィールドに設定します。
+//
+// 売上高・営業利益・経常利益・純利益・EPS・総資産・純資産・自己資本比率・BPS・キャッシュ・フロー・
+// 配当・株式数などの主要な項目のみを対応付けます。それ以外のファクトはDocument.Factsから参照してください。
+// DiscNo・DocType・DiscTimeはXBRLに含まれないため設定されません。
+func (d *Document) Statement() *jquants.Statement {
+	st := &jquants.Statement{}
+	d.setPeriods(st)
+
+	for _, f := range d.Facts {
+		if f.Prefix() != SummaryTaxonomyPrefix {
+			continue
+		}
+		switch f.LocalName() {
+		case "SecuritiesCode":
+			if code, err := types.ParseCode(f.Value); err == nil {
+				st.Code = code.String()
+			}
+			continue
+		case "FilingDate":
+			if date, err := types.ParseDate(f.Value); err == nil {
+				st.DiscDate = date
+			}
+			continue
+		}
+
+		ctx := d.Contexts[f.ContextRef]
+		if ctx == nil {
+			continue
+		}
+		period := d.classify(ctx)
+
+		v, ok := f.Float()
+		if !ok || period == periodUnknown || ctx.HasMember("UpperMember") || ctx.HasMember("LowerMember") {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimSuffix(f.LocalName(), "IFRS"), "US")
+
+		if tag == "DividendPerShare" {
+			for member, target := range dividendFields[period] {
+				if ctx.HasMember(member) {
+					setFloat(target(st), v)
+				}
+			}
+			continue
+		}
+		if target, ok := shareFields[tagPeriod{tag, period}]; ok {
+			if p := target(st); *p == nil {
+				n := int64(v)
+				*p = &n
+			}
+			continue
+		}
+		fld, ok := statementFields[tagPeriod{tag, period}]
+		if !ok {
+			continue
+		}
+		target := fld.cons
+		if ctx.HasMember("NonConsolidatedMember") {
+			target = fld.nonCons
+		}
+		if target != nil {
+			setFloat(target(st), v)
+		}
+	}
+	return st
+}
+
+// classify はコンテキストの期間の種類を返します。実績（ResultMember）は当期のみ、
+// 予想（ForecastMember）は当事業年度・翌事業年度とその第2四半期累計期間のみを対象とします。
+func (d *Document) classify(ctx *Context) periodKind {
+	prefix, _, _ := strings.Cut(ctx.ID, "_")
+	forecast := ctx.HasMember("ForecastMember")
+	switch {
+	case !forecast && strings.HasPrefix(prefix, "Current") && strings.HasSuffix(prefix, "Instant"):
+		return periodCurrentInstant
+	case !forecast && strings.HasPrefix(prefix, "Current") && strings.HasSuffix(prefix, "Duration"):
+		return periodCurrent
+	case forecast && prefix == "CurrentYearDuration":
+		return periodCurrentYear
+	case forecast && prefix == "CurrentAccumulatedQ2Duration":
+		return periodCurrentQ2
+	case forecast && prefix == "NextYearDuration":
+		return periodNextYear
+	case forecast && prefix == "NextAccumulatedQ2Duration":
+		return periodNextQ2
+	}
+	return periodUnknown
+}
+
+// setPeriods はコンテキストの期間から、当会計期間・当事業年度・翌事業年度の期間を設定します。
+// 四半期の決算短信では当事業年度の配当実績も期間が当事業年度のため、当会計期間は四半期累計期間を優先します。
+func (d *Document) setPeriods(st *jquants.Statement) {
+	for _, ctx := range d.Contexts {
+		if ctx.StartDate == "" || ctx.EndDate == "" {
+			continue
+		}
+		prefix, _, _ := strings.Cut(ctx.ID, "_")
+		switch prefix {
+		case "CurrentYearDuration":
+			st.CurFYSt, st.CurFYEn = ctx.StartDate, ctx.EndDate
+		case "NextYearDuration":
+			st.NxtFYSt, st.NxtFYEn = ctx.StartDate, ctx.EndDate
+		}
+		if ctx.HasMember("ForecastMember") {
+			continue
+		}
+		if perType := currentPeriodType(prefix); perType != "" && (st.CurPerType == "" || st.CurPerType == "FY") {
+			st.CurPerType, st.CurPerSt, st.CurPerEn = perType, ctx.StartDate, ctx.EndDate
+		}
+	}
+}
+
+// currentPeriodType は実績の期間のコンテキストIDの先頭部分から当会計期間の種類（1Q〜3Q、FY）を返します。
+func currentPeriodType(prefix string) string {
+	if prefix == "CurrentYearDuration" {
+		return "FY"
+	}
+	q, ok := strings.CutPrefix(prefix, "CurrentAccumulatedQ")
+	if !ok {
+		return ""
+	}
+	q = strings.TrimSuffix(q, "Duration")
+	if n, err := strconv.Atoi(q); err == nil && n >= 1 && n <= 3 {
+		return q + "Q"
+	}
+	return ""
+}
+
+// setFloat は未設定のフィールドに値を設定します。同じフィールドのファクトが複数ある場合は最初の値を使用します。
+func setFloat(p **float64, v float64) {
+	if *p == nil {
+		*p = &v
+	}
+}
@@ -0,0 +1,457 @@
+// Package xbrl はTDnetの適時開示で提供されるXBRL関連ファイル（zip）を読み込みます。
+//
+// XBRL関連ファイルには決算短信サマリー等のインラインXBRL（*-ixbrl.htm）が含まれます。
+// このパッケージはインラインXBRLからファクト（ix:nonFraction・ix:nonNumeric）と
+// コンテキスト・単位を取り出し、決算短信サマリー（tse-ed-t タクソノミ）の主要な項目を
+// jquants.Statement の同名フィールドに対応付けます。
+//
+//	files, _ := jq.TimelyDisclosure.GetDisclosureFiles(ctx, jquants.TimelyDisclosureFilesParams{DiscNo: td.DiscNo, Docs: "x"})
+//	// files.Files.XBRL をダウンロードして保存した後
+//	pkg, err := xbrl.OpenFile("xbrl.zip")
+//	st, err := pkg.Statement()
+package xbrl
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// インラインXBRL・XBRLインスタンスの名前空間
+const (
+	nsInlineXBRL     = "http://www.xbrl.org/2013/inlineXBRL"
+	nsInlineXBRL2008 = "http://www.xbrl.org/2008/inlineXBRL"
+	nsXBRLInstance   = "http://www.xbrl.org/2003/instance"
+	nsXBRLDimensions = "http://xbrl.org/2006/xbrldi"
+	nsXSI            = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// SummaryTaxonomyPrefix は決算短信サマリーのタクソノミ（tse-ed-t）の接頭辞です。
+const SummaryTaxonomyPrefix = "tse-ed-t"
+
+// Fact はインラインXBRLの1つのファクト（タグ付けされた値）です。
+type Fact struct {
+	Name       string // 要素名（"tse-ed-t:NetSales" など接頭辞付き）
+	ContextRef string // コンテキストID
+	UnitRef    string // 単位ID（数値のみ）
+	Decimals   string // 精度（数値のみ。"-6"、"INF" など）
+	Numeric    bool   // 数値のファクト（ix:nonFraction）か
+	Nil        bool   // 値なし（xsi:nil="true"）か
+	Value      string // 値。数値はscale・signを適用した10進表記、文字列は表示テキスト
+}
+
+// Prefix は要素名の接頭辞（"tse-ed-t" など）を返します。
+func (f *Fact) Prefix() string {
+	prefix, _, _ := strings.Cut(f.Name, ":")
+	return prefix
+}
+
+// LocalName は要素名の接頭辞を除いた部分（"NetSales" など）を返します。
+func (f *Fact) LocalName() string {
+	if _, local, ok := strings.Cut(f.Name, ":"); ok {
+		return local
+	}
+	return f.Name
+}
+
+// Float は数値のファクトの値を返します。値がない場合や数値でない場合はfalseを返します。
+func (f *Fact) Float() (float64, bool) {
+	if !f.Numeric || f.Nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(f.Value, 64)
+	return v, err == nil
+}
+
+// Context はファクトの期間と次元（メンバー）を表すコンテキストです。
+type Context struct {
+	ID         string            // コンテキストID（"CurrentYearDuration_ConsolidatedMember_ResultMember" など）
+	Entity     string            // 報告主体の識別子
+	StartDate  string            // 期間の開始日（YYYY-MM-DD。時点の場合は空）
+	EndDate    string            // 期間の終了日（YYYY-MM-DD。時点の場合は空）
+	Instant    string            // 時点（YYYY-MM-DD。期間の場合は空）
+	Dimensions map[string]string // 軸の要素名からメンバーの要素名への対応（"tse-ed-t:ConsolidatedNonconsolidatedAxis" など）
+}
+
+// HasMember はコンテキストが接頭辞を除いた名前memberのメンバーを持つかを返します。
+func (c *Context) HasMember(member string) bool {
+	for _, m := range c.Dimensions {
+		if localName(m) == member {
+			return true
+		}
+	}
+	return false
+}
+
+// Unit はファクトの単位です。
+type Unit struct {
+	ID      string // 単位ID
+	Measure string // 単位（"iso4217:JPY"、"xbrli:shares"。除算の単位は "iso4217:JPY/xbrli:shares"）
+}
+
+// Document は1つのインラインXBRLファイルから読み込んだ内容です。
+type Document struct {
+	Name     string              // zip内のパス（ParseInlineで読み込んだ場合は空）
+	Facts    []Fact              // 出現順のファクト
+	Contexts map[string]*Context // IDからコンテキストへの対応
+	Units    map[string]*Unit    // IDから単位への対応
+}
+
+// Lookup は要素名nameのファクトを出現順に返します。
+func (d *Document) Lookup(name string) []Fact {
+	var facts []Fact
+	for _, f := range d.Facts {
+		if f.Name == name {
+			facts = append(facts, f)
+		}
+	}
+	return facts
+}
+
+// Package はXBRL関連ファイル（zip）に含まれるインラインXBRLの一覧です。
+type Package struct {
+	Documents []*Document
+}
+
+// OpenFile はXBRL関連ファイル（zip）を読み込みます。
+func OpenFile(name string) (*Package, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XBRL package: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+	return readPackage(&zr.Reader)
+}
+
+// ReadPackage はrから読み込んだXBRL関連ファイル（zip）を読み込みます。sizeはzipのバイト数です。
+func ReadPackage(r io.ReaderAt, size int64) (*Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XBRL package: %w", err)
+	}
+	return readPackage(zr)
+}
+
+func readPackage(zr *zip.Reader) (*Package, error) {
+	pkg := &Package{}
+	for _, f := range zr.File {
+		if !isInlineXBRL(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		doc, err := ParseInline(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		doc.Name = f.Name
+		pkg.Documents = append(pkg.Documents, doc)
+	}
+	if len(pkg.Documents) == 0 {
+		return nil, errors.New("no inline XBRL document in package")
+	}
+	return pkg, nil
+}
+
+// isInlineXBRL はzip内のパスがインラインXBRLのファイルかを返します。
+func isInlineXBRL(name string) bool {
+	base := strings.ToLower(path.Base(name))
+	return strings.HasSuffix(base, "-ixbrl.htm") || strings.HasSuffix(base, "-ixbrl.html")
+}
+
+// Summary は決算短信サマリーのドキュメントを返します。
+// "Summary" ディレクトリのファイルを優先し、なければtse-ed-tのファクトを含む最初のファイルを返します。
+func (p *Package) Summary() (*Document, error) {
+	for _, doc := range p.Documents {
+		if strings.Contains(doc.Name, "/Summary/") || strings.HasPrefix(doc.Name, "Summary/") {
+			return doc, nil
+		}
+	}
+	for _, doc := range p.Documents {
+		for _, f := range doc.Facts {
+			if f.Prefix() == SummaryTaxonomyPrefix {
+				return doc, nil
+			}
+		}
+	}
+	return nil, errors.New("summary document not found in package")
+}
+
+// ParseInline はインラインXBRL（XHTML）を読み込みます。
+func ParseInline(r io.Reader) (*Document, error) {
+	p := &inlineParser{
+		doc: &Document{Contexts: map[string]*Context{}, Units: map[string]*Unit{}},
+	}
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	dec.AutoClose = xml.HTMLAutoClose
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.token(tok); err != nil {
+			return nil, err
+		}
+	}
+	return p.doc, nil
+}
+
+// openFact は終了タグを待っているファクトです。
+type openFact struct {
+	fact   Fact
+	depth  int // 開始タグの深さ
+	text   strings.Builder
+	format string
+	scale  int
+	sign   string
+}
+
+type inlineParser struct {
+	doc   *Document
+	depth int
+
+	facts        []*openFact // 入れ子になっているファクト（内側が末尾）
+	excludeDepth int         // ix:excludeの深さ（0は範囲外）
+
+	context   *Context // 読み込み中のコンテキスト
+	dimension string   // 読み込み中のxbrldi:explicitMemberの軸
+	unit      *Unit    // 読み込み中の単位
+	measures  []string // 読み込み中の単位の要素
+	text      strings.Builder
+}
+
+func (p *inlineParser) token(tok xml.Token) error {
+	switch t := tok.(type) {
+	case xml.StartElement:
+		p.depth++
+		p.text.Reset()
+		return p.start(t)
+	case xml.EndElement:
+		p.end(t)
+		p.depth--
+	case xml.CharData:
+		if p.excludeDepth == 0 {
+			for _, f := range p.facts {
+				f.text.Write(t)
+			}
+		}
+		p.text.Write(t)
+	}
+	return nil
+}
+
+func (p *inlineParser) start(t xml.StartElement) error {
+	switch {
+	case isInline(t.Name, "nonFraction"), isInline(t.Name, "nonNumeric"):
+		f := &openFact{depth: p.depth}
+		f.fact.Numeric = t.Name.Local == "nonFraction"
+		for _, a := range t.Attr {
+			switch {
+			case a.Name.Local == "name":
+				f.fact.Name = a.Value
+			case a.Name.Local == "contextRef":
+				f.fact.ContextRef = a.Value
+			case a.Name.Local == "unitRef":
+				f.fact.UnitRef = a.Value
+			case a.Name.Local == "decimals":
+				f.fact.Decimals = a.Value
+			case a.Name.Local == "format":
+				f.format = a.Value
+			case a.Name.Local == "sign":
+				f.sign = a.Value
+			case a.Name.Local == "scale":
+				n, err := strconv.Atoi(a.Value)
+				if err != nil {
+					return fmt.Errorf("invalid scale %q of %s", a.Value, f.fact.Name)
+				}
+				f.scale = n
+			case a.Name.Local == "nil" && a.Name.Space == nsXSI:
+				f.fact.Nil = a.Value == "true"
+			}
+		}
+		p.facts = append(p.facts, f)
+	case isInline(t.Name, "exclude"):
+		if p.excludeDepth == 0 {
+			p.excludeDepth = p.depth
+		}
+	case t.Name.Space == nsXBRLInstance && t.Name.Local == "context":
+		p.context = &Context{ID: attr(t, "id"), Dimensions: map[string]string{}}
+	case t.Name.Space == nsXBRLInstance && t.Name.Local == "unit":
+		p.unit = &Unit{ID: attr(t, "id")}
+		p.measures = nil
+	case t.Name.Space == nsXBRLDimensions && t.Name.Local == "explicitMember":
+		// メンバーは要素の内容で、終了タグで設定する
+		p.dimension = attr(t, "dimension")
+	}
+	return nil
+}
+
+func (p *inlineParser) end(t xml.EndElement) {
+	text := strings.TrimSpace(p.text.String())
+	switch {
+	case len(p.facts) > 0 && p.facts[len(p.facts)-1].depth == p.depth:
+		f := p.facts[len(p.facts)-1]
+		p.facts = p.facts[:len(p.facts)-1]
+		p.doc.Facts = append(p.doc.Facts, f.value())
+	case isInline(t.Name, "exclude"):
+		if p.excludeDepth == p.depth {
+			p.excludeDepth = 0
+		}
+	case p.context != nil && t.Name.Space == nsXBRLInstance:
+		switch t.Name.Local {
+		case "identifier":
+			p.context.Entity = text
+		case "startDate":
+			p.context.StartDate = text
+		case "endDate":
+			p.context.EndDate = text
+		case "instant":
+			p.context.Instant = text
+		case "context":
+			p.doc.Contexts[p.context.ID] = p.context
+			p.context = nil
+		}
+	case p.context != nil && t.Name.Space == nsXBRLDimensions && t.Name.Local == "explicitMember":
+		if p.dimension != "" {
+			p.context.Dimensions[p.dimension] = text
+			p.dimension = ""
+		}
+	case p.unit != nil && t.Name.Space == nsXBRLInstance:
+		switch t.Name.Local {
+		case "measure":
+			p.measures = append(p.measures, text)
+		case "unit":
+			p.unit.Measure = strings.Join(p.measures, "/")
+			p.doc.Units[p.unit.ID] = p.unit
+			p.unit = nil
+		}
+	}
+	p.text.Reset()
+}
+
+// value はファクトの表示テキストに変換（format・scale・sign）を適用した値を設定して返します。
+func (f *openFact) value() Fact {
+	fact := f.fact
+	text := strings.TrimSpace(f.text.String())
+	if fact.Nil {
+		return fact
+	}
+	if !fact.Numeric {
+		fact.Value = transformText(text, f.format)
+		return fact
+	}
+
+	v, ok := transformNumber(text, f.format)
+	if !ok {
+		fact.Value = text
+		fact.Numeric = false
+		return fact
+	}
+	if _, err := strconv.ParseFloat(v, 64); err != nil {
+		fact.Value = text
+		fact.Numeric = false
+		return fact
+	}
+	v = shiftDecimal(v, f.scale)
+	if f.sign == "-" && v != "0" {
+		v = "-" + v
+	}
+	fact.Value = v
+	return fact
+}
+
+// shiftDecimal は10進表記の非負の数値vを10のscale乗倍した値を、浮動小数点の誤差なしで返します。
+func shiftDecimal(v string, scale int) string {
+	intPart, fracPart, _ := strings.Cut(v, ".")
+	digits := intPart + fracPart
+	point := len(intPart) + scale // digitsの中での小数点の位置
+	switch {
+	case point <= 0:
+		digits = strings.Repeat("0", 1-point) + digits
+		point = 1
+	case point > len(digits):
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	intPart = strings.TrimLeft(digits[:point], "0")
+	fracPart = strings.TrimRight(digits[point:], "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if fracPart == "" {
+		return intPart
+	}
+	return intPart + "." + fracPart
+}
+
+// transformNumber は数値の表示テキストを、formatに従って "1234.5" の形式に変換します。
+// 対応していないformatでは、数字と小数点以外の文字（桁区切りのカンマなど）を取り除きます。
+func transformNumber(text, format string) (string, bool) {
+	local := strings.ToLower(localName(format))
+	switch {
+	case strings.Contains(local, "zerodash"), strings.Contains(local, "fixed-zero"),
+		strings.Contains(local, "fixedzero"), strings.Contains(local, "numdash"):
+		return "0", true
+	case strings.Contains(local, "numcommadecimal"), strings.Contains(local, "num-comma-decimal"):
+		text = strings.NewReplacer(".", "", " ", "", ",", ".").Replace(text)
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9', r == '.':
+			b.WriteRune(r)
+		case r >= '０' && r <= '９':
+			b.WriteRune('0' + (r - '０'))
+		}
+	}
+	return b.String(), b.Len() > 0
+}
+
+// transformText は文字列の表示テキストを、日付のformatであればYYYY-MM-DD形式に変換します。
+func transformText(text, format string) string {
+	if !strings.Contains(strings.ToLower(localName(format)), "date") {
+		return text
+	}
+	fields := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(fields) != 3 || len(fields[0]) != 4 {
+		return text
+	}
+	y, _ := strconv.Atoi(fields[0])
+	m, _ := strconv.Atoi(fields[1])
+	d, _ := strconv.Atoi(fields[2])
+	return fmt.Sprintf("%04d-%02d-%02d", y, m, d)
+}
+
+func isInline(name xml.Name, local string) bool {
+	return name.Local == local && (name.Space == nsInlineXBRL || name.Space == nsInlineXBRL2008)
+}
+
+func attr(t xml.StartElement, local string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func localName(qname string) string {
+	if i := strings.LastIndexByte(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
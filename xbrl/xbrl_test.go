@@ -0,0 +1,329 @@
+package xbrl
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testSummary は第2四半期の決算短信サマリー（連結・日本基準）を模したインラインXBRLです。
+const testSummary = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"
+  xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2011-07-31"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance"
+  xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+  xmlns:tse-ed-t="http://www.xbrl.tdnet.info/taxonomy/jp/tse/tdnet/ed/t/2014-01-12">
+<head><title>決算短信</title></head>
+<body>
+<div style="display:none">
+<ix:header>
+<ix:hidden>
+  <ix:nonNumeric name="tse-ed-t:SecuritiesCode" contextRef="CurrentYearDuration">72030</ix:nonNumeric>
+</ix:hidden>
+<ix:resources>
+  <xbrli:context id="CurrentYearDuration">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2026-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="CurrentAccumulatedQ2Duration">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2025-09-30</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="CurrentAccumulatedQ2Instant">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-09-30</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="CurrentAccumulatedQ2Duration_ConsolidatedMember_ResultMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2025-09-30</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:ConsolidatedNonconsolidatedAxis">tse-ed-t:ConsolidatedMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ResultMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="CurrentAccumulatedQ2Instant_ConsolidatedMember_ResultMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-09-30</xbrli:instant></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:ConsolidatedNonconsolidatedAxis">tse-ed-t:ConsolidatedMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ResultMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="CurrentYearDuration_ConsolidatedMember_ForecastMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2026-03-31</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:ConsolidatedNonconsolidatedAxis">tse-ed-t:ConsolidatedMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ForecastMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="CurrentYearDuration_ConsolidatedMember_UpperMember_ForecastMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2026-03-31</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:ConsolidatedNonconsolidatedAxis">tse-ed-t:ConsolidatedMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ForecastRangeAxis">tse-ed-t:UpperMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ForecastMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="CurrentYearDuration_SecondQuarterMember_ResultMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2026-03-31</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:DividendPaymentDateAxis">tse-ed-t:SecondQuarterMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ResultMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="CurrentYearDuration_YearEndMember_ForecastMember">
+    <xbrli:entity><xbrli:identifier scheme="http://www.xbrl.tdnet.info/jp/br/tdnet">E02144-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-04-01</xbrli:startDate><xbrli:endDate>2026-03-31</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="tse-ed-t:DividendPaymentDateAxis">tse-ed-t:YearEndMember</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="tse-ed-t:ResultForecastAxis">tse-ed-t:ForecastMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="JPYPerShares">
+    <xbrli:divide>
+      <xbrli:unitNumerator><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unitNumerator>
+      <xbrli:unitDenominator><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unitDenominator>
+    </xbrli:divide>
+  </xbrli:unit>
+  <xbrli:unit id="Shares"><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="Pure"><xbrli:measure>xbrli:pure</xbrli:measure></xbrli:unit>
+</ix:resources>
+</ix:header>
+</div>
+<p>提出日 <ix:nonNumeric name="tse-ed-t:FilingDate" contextRef="CurrentYearDuration" format="ixt:dateyearmonthdaycjk">2025年11月5日</ix:nonNumeric></p>
+<p>会社名 <ix:nonNumeric name="tse-ed-t:CompanyName" contextRef="CurrentYearDuration">トヨタ自動車株式会社<ix:exclude>（注記）</ix:exclude></ix:nonNumeric></p>
+<table>
+<tr><td>売上高</td><td><ix:nonFraction name="tse-ed-t:NetSales" contextRef="CurrentAccumulatedQ2Duration_ConsolidatedMember_ResultMember" unitRef="JPY" decimals="-6" scale="6" format="ixt:numdotdecimal">24,630,753</ix:nonFraction></td></tr>
+<tr><td>営業利益</td><td>△<ix:nonFraction name="tse-ed-t:OperatingIncome" contextRef="CurrentAccumulatedQ2Duration_ConsolidatedMember_ResultMember" unitRef="JPY" decimals="-6" scale="6" sign="-" format="ixt:numdotdecimal">2,005</ix:nonFraction></td></tr>
+<tr><td>経常利益</td><td><ix:nonFraction name="tse-ed-t:OrdinaryIncome" contextRef="CurrentAccumulatedQ2Duration_ConsolidatedMember_ResultMember" unitRef="JPY" decimals="-6" scale="6" format="ixt:zerodash">－</ix:nonFraction></td></tr>
+<tr><td>EPS</td><td><ix:nonFraction name="tse-ed-t:NetIncomePerShare" contextRef="CurrentAccumulatedQ2Duration_ConsolidatedMember_ResultMember" unitRef="JPYPerShares" decimals="2" format="ixt:numdotdecimal">120.35</ix:nonFraction></td></tr>
+<tr><td>総資産</td><td><ix:nonFraction name="tse-ed-t:TotalAssets" contextRef="CurrentAccumulatedQ2Instant_ConsolidatedMember_ResultMember" unitRef="JPY" decimals="-6" scale="6" format="ixt:numdotdecimal">93,601,350</ix:nonFraction></td></tr>
+<tr><td>自己資本比率</td><td><ix:nonFraction name="tse-ed-t:CapitalAdequacyRatio" contextRef="CurrentAccumulatedQ2Instant_ConsolidatedMember_ResultMember" unitRef="Pure" decimals="3" scale="-2" format="ixt:numdotdecimal">38.2</ix:nonFraction></td></tr>
+<tr><td>売上高予想</td><td><ix:nonFraction name="tse-ed-t:NetSales" contextRef="CurrentYearDuration_ConsolidatedMember_ForecastMember" unitRef="JPY" decimals="-6" scale="6" format="ixt:numdotdecimal">48,500,000</ix:nonFraction></td></tr>
+<tr><td>売上高予想（上限）</td><td><ix:nonFraction name="tse-ed-t:NetSales" contextRef="CurrentYearDuration_ConsolidatedMember_UpperMember_ForecastMember" unitRef="JPY" decimals="-6" scale="6">49,000,000</ix:nonFraction></td></tr>
+<tr><td>純利益予想</td><td><ix:nonFraction name="tse-ed-t:ProfitAttributableToOwnersOfParentIFRS" contextRef="CurrentYearDuration_ConsolidatedMember_ForecastMember" unitRef="JPY" decimals="-6" scale="6">2,930,000</ix:nonFraction></td></tr>
+<tr><td>中間配当</td><td><ix:nonFraction name="tse-ed-t:DividendPerShare" contextRef="CurrentYearDuration_SecondQuarterMember_ResultMember" unitRef="JPYPerShares" decimals="2">45.00</ix:nonFraction></td></tr>
+<tr><td>期末配当予想</td><td><ix:nonFraction name="tse-ed-t:DividendPerShare" contextRef="CurrentYearDuration_YearEndMember_ForecastMember" unitRef="JPYPerShares" decimals="-1" xsi:nil="true"></ix:nonFraction></td></tr>
+<tr><td>期末発行済株式数</td><td><ix:nonFraction name="tse-ed-t:NumberOfIssuedAndOutstandingSharesAtTheEndOfFiscalYearIncludingTreasuryStock" contextRef="CurrentAccumulatedQ2Instant" unitRef="Shares" decimals="0" format="ixt:numdotdecimal">15,794,987,460</ix:nonFraction></td></tr>
+<tr><td>期末自己株式数</td><td><ix:nonFraction name="tse-ed-t:NumberOfTreasuryStockAtTheEndOfFiscalYear" contextRef="CurrentAccumulatedQ2Instant" unitRef="Shares" decimals="0" format="ixt:numdotdecimal">2,763,114,913</ix:nonFraction></td></tr>
+<tr><td>期中平均株式数</td><td><ix:nonFraction name="tse-ed-t:AverageNumberOfShares" contextRef="CurrentAccumulatedQ2Duration" unitRef="Shares" decimals="0" format="ixt:numdotdecimal">13,033,530,201</ix:nonFraction></td></tr>
+</table>
+</body>
+</html>
+`
+
+func zipBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseInline(t *testing.T) {
+	doc, err := ParseInline(strings.NewReader(testSummary))
+	if err != nil {
+		t.Fatalf("ParseInline() error = %v", err)
+	}
+	if len(doc.Facts) != 17 {
+		t.Errorf("got %d facts, want 17", len(doc.Facts))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"tse-ed-t:SecuritiesCode", "72030"},
+		{"tse-ed-t:FilingDate", "2025-11-05"},
+		{"tse-ed-t:CompanyName", "トヨタ自動車株式会社"},
+		{"tse-ed-t:NetSales", "24630753000000"},
+		{"tse-ed-t:OperatingIncome", "-2005000000"},
+		{"tse-ed-t:OrdinaryIncome", "0"},
+		{"tse-ed-t:NetIncomePerShare", "120.35"},
+		{"tse-ed-t:CapitalAdequacyRatio", "0.382"},
+	}
+	for _, tt := range tests {
+		facts := doc.Lookup(tt.name)
+		if len(facts) == 0 {
+			t.Errorf("%s not found", tt.name)
+			continue
+		}
+		if facts[0].Value != tt.value {
+			t.Errorf("%s = %q, want %q", tt.name, facts[0].Value, tt.value)
+		}
+	}
+
+	sales := doc.Lookup("tse-ed-t:NetSales")[0]
+	if v, ok := sales.Float(); !ok || v != 24630753e6 {
+		t.Errorf("Float() = (%v, %v)", v, ok)
+	}
+	if sales.LocalName() != "NetSales" || sales.Prefix() != "tse-ed-t" || sales.Decimals != "-6" {
+		t.Errorf("sales = %+v", sales)
+	}
+	div := doc.Lookup("tse-ed-t:DividendPerShare")[1]
+	if !div.Nil {
+		t.Errorf("nil fact = %+v", div)
+	}
+	if _, ok := div.Float(); ok {
+		t.Error("Float() of nil fact must be false")
+	}
+
+	ctx := doc.Contexts[sales.ContextRef]
+	if ctx == nil || ctx.StartDate != "2025-04-01" || ctx.EndDate != "2025-09-30" || ctx.Entity != "E02144-000" {
+		t.Fatalf("context = %+v", ctx)
+	}
+	if ctx.Dimensions["tse-ed-t:ResultForecastAxis"] != "tse-ed-t:ResultMember" || !ctx.HasMember("ConsolidatedMember") {
+		t.Errorf("dimensions = %v", ctx.Dimensions)
+	}
+	if doc.Contexts["CurrentAccumulatedQ2Instant_ConsolidatedMember_ResultMember"].Instant != "2025-09-30" {
+		t.Error("instant context not parsed")
+	}
+	if u := doc.Units["JPYPerShares"]; u == nil || u.Measure != "iso4217:JPY/xbrli:shares" {
+		t.Errorf("unit = %+v", u)
+	}
+}
+
+func TestPackage_Statement(t *testing.T) {
+	data := zipBytes(t, map[string]string{
+		"XBRLData/Summary/tse-qcedjpsm-72030-20251105372030-ixbrl.htm": testSummary,
+		"XBRLData/Summary/tse-qcedjpsm-72030-20251105372030.xsd":       "<schema/>",
+		"XBRLData/Attachment/index.txt":                                "",
+	})
+	pkg, err := ReadPackage(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadPackage() error = %v", err)
+	}
+	if len(pkg.Documents) != 1 {
+		t.Fatalf("got %d documents, want 1", len(pkg.Documents))
+	}
+
+	st, err := pkg.Statement()
+	if err != nil {
+		t.Fatalf("Statement() error = %v", err)
+	}
+	if st.Code != "72030" || st.DiscDate.String() != "2025-11-05" {
+		t.Errorf("Code = %q, DiscDate = %q", st.Code, st.DiscDate)
+	}
+	if st.CurPerType != "2Q" || st.CurPerSt != "2025-04-01" || st.CurPerEn != "2025-09-30" {
+		t.Errorf("CurPer = %s %s〜%s", st.CurPerType, st.CurPerSt, st.CurPerEn)
+	}
+	if st.CurFYSt != "2025-04-01" || st.CurFYEn != "2026-03-31" {
+		t.Errorf("CurFY = %s〜%s", st.CurFYSt, st.CurFYEn)
+	}
+
+	floats := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"Sales", st.Sales, 24630753e6},
+		{"OP", st.OP, -2005e6},
+		{"OdP", st.OdP, 0},
+		{"EPS", st.EPS, 120.35},
+		{"TA", st.TA, 93601350e6},
+		{"EqAR", st.EqAR, 0.382},
+		{"FSales", st.FSales, 48500000e6},
+		{"FNP", st.FNP, 2930000e6},
+		{"Div2Q", st.Div2Q, 45},
+	}
+	for _, f := range floats {
+		if f.got == nil || *f.got != f.want {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+
+	// 期末の株式数は時点、期中平均株式数は期間のコンテキストから読む
+	ints := []struct {
+		name string
+		got  *int64
+		want int64
+	}{
+		{"ShOutFY", st.ShOutFY, 15794987460},
+		{"TrShFY", st.TrShFY, 2763114913},
+		{"AvgSh", st.AvgSh, 13033530201},
+	}
+	for _, f := range ints {
+		if f.got == nil || *f.got != f.want {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+	if st.NCSales != nil || st.FDivFY != nil || st.NP != nil {
+		t.Errorf("unexpected values: NCSales = %v, FDivFY = %v, NP = %v", st.NCSales, st.FDivFY, st.NP)
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xbrl.zip")
+	if err := os.WriteFile(path, zipBytes(t, map[string]string{"Summary/a-ixbrl.htm": testSummary}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	if doc, err := pkg.Summary(); err != nil || doc.Name != "Summary/a-ixbrl.htm" {
+		t.Errorf("Summary() = (%v, %v)", doc, err)
+	}
+
+	empty := zipBytes(t, map[string]string{"readme.txt": ""})
+	if _, err := ReadPackage(bytes.NewReader(empty), int64(len(empty))); err == nil {
+		t.Error("ReadPackage() expected error for package without inline XBRL")
+	}
+}
+
+func TestTransformNumber(t *testing.T) {
+	tests := []struct {
+		text, format, want string
+	}{
+		{"1,234", "ixt:numdotdecimal", "1234"},
+		{"1.234,5", "ixt:numcommadecimal", "1234.5"},
+		{"－", "ixt:zerodash", "0"},
+		{"－", "ixt:fixed-zero", "0"},
+		{"１２３", "", "123"},
+	}
+	for _, tt := range tests {
+		if got, ok := transformNumber(tt.text, tt.format); !ok || got != tt.want {
+			t.Errorf("transformNumber(%q, %q) = (%q, %v), want %q", tt.text, tt.format, got, ok, tt.want)
+		}
+	}
+}
+
+func TestShiftDecimal(t *testing.T) {
+	tests := []struct {
+		v     string
+		scale int
+		want  string
+	}{
+		{"38.2", -2, "0.382"},
+		{"1", -3, "0.001"},
+		{"24630753", 6, "24630753000000"},
+		{"1.5", 1, "15"},
+		{"0", 6, "0"},
+		{"120.35", 0, "120.35"},
+	}
+	for _, tt := range tests {
+		if got := shiftDecimal(tt.v, tt.scale); got != tt.want {
+			t.Errorf("shiftDecimal(%q, %d) = %q, want %q", tt.v, tt.scale, got, tt.want)
+		}
+	}
+}